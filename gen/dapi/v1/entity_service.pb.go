@@ -12,6 +12,8 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -25,32 +27,721 @@ const (
 )
 
 type ListEntitiesFromEventRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	StartNode   string                 `protobuf:"bytes,1,opt,name=start_node,json=startNode,proto3" json:"start_node,omitempty"`
+	StartTime   int64                  `protobuf:"varint,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime     int64                  `protobuf:"varint,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	CountryCode string                 `protobuf:"bytes,4,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Tag         string                 `protobuf:"bytes,5,opt,name=tag,proto3" json:"tag,omitempty"`
+	Depth       int32                  `protobuf:"varint,6,opt,name=depth,proto3" json:"depth,omitempty"`
+	// Relationships below this confidence (0-100) are not traversed
+	MinConfidence int32 `protobuf:"varint,7,opt,name=min_confidence,json=minConfidence,proto3" json:"min_confidence,omitempty"`
+	// How relationship confidences combine along a path: "product" (default) or "min"
+	ConfidenceAggregation string `protobuf:"bytes,8,opt,name=confidence_aggregation,json=confidenceAggregation,proto3" json:"confidence_aggregation,omitempty"`
+	// Only relationships valid at as_of, or overlapping the window, are traversed.
+	// Validity is read from the valid_from/valid_to relationship attributes.
+	AsOf        int64 `protobuf:"varint,9,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	WindowStart int64 `protobuf:"varint,10,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd   int64 `protobuf:"varint,11,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	// Only events within radius_meters of near_latitude/near_longitude
+	NearLatitude  float64 `protobuf:"fixed64,12,opt,name=near_latitude,json=nearLatitude,proto3" json:"near_latitude,omitempty"`
+	NearLongitude float64 `protobuf:"fixed64,13,opt,name=near_longitude,json=nearLongitude,proto3" json:"near_longitude,omitempty"`
+	RadiusMeters  float64 `protobuf:"fixed64,14,opt,name=radius_meters,json=radiusMeters,proto3" json:"radius_meters,omitempty"`
+	// Only events inside the box between the south west (min) and north east (max) corners.
+	// A min_longitude greater than max_longitude selects a box across the antimeridian.
+	MinLatitude  float64 `protobuf:"fixed64,15,opt,name=min_latitude,json=minLatitude,proto3" json:"min_latitude,omitempty"`
+	MinLongitude float64 `protobuf:"fixed64,16,opt,name=min_longitude,json=minLongitude,proto3" json:"min_longitude,omitempty"`
+	MaxLatitude  float64 `protobuf:"fixed64,17,opt,name=max_latitude,json=maxLatitude,proto3" json:"max_latitude,omitempty"`
+	MaxLongitude float64 `protobuf:"fixed64,18,opt,name=max_longitude,json=maxLongitude,proto3" json:"max_longitude,omitempty"`
+	// Only events inside the polygon, given as latitude, longitude pairs of its
+	// vertices in counterclockwise order
+	Polygon       []float64 `protobuf:"fixed64,19,rep,packed,name=polygon,proto3" json:"polygon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEntitiesFromEventRequest) Reset() {
+	*x = ListEntitiesFromEventRequest{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntitiesFromEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntitiesFromEventRequest) ProtoMessage() {}
+
+func (x *ListEntitiesFromEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntitiesFromEventRequest.ProtoReflect.Descriptor instead.
+func (*ListEntitiesFromEventRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListEntitiesFromEventRequest) GetStartNode() string {
+	if x != nil {
+		return x.StartNode
+	}
+	return ""
+}
+
+func (x *ListEntitiesFromEventRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *ListEntitiesFromEventRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *ListEntitiesFromEventRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *ListEntitiesFromEventRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListEntitiesFromEventRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *ListEntitiesFromEventRequest) GetMinConfidence() int32 {
+	if x != nil {
+		return x.MinConfidence
+	}
+	return 0
+}
+
+func (x *ListEntitiesFromEventRequest) GetConfidenceAggregation() string {
+	if x != nil {
+		return x.ConfidenceAggregation
+	}
+	return ""
+}
+
+func (x *ListEntitiesFromEventRequest) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+func (x *ListEntitiesFromEventRequest) GetWindowStart() int64 {
+	if x != nil {
+		return x.WindowStart
+	}
+	return 0
+}
+
+func (x *ListEntitiesFromEventRequest) GetWindowEnd() int64 {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return 0
+}
+
+func (x *ListEntitiesFromEventRequest) GetNearLatitude() float64 {
+	if x != nil {
+		return x.NearLatitude
+	}
+	return 0
+}
+
+func (x *ListEntitiesFromEventRequest) GetNearLongitude() float64 {
+	if x != nil {
+		return x.NearLongitude
+	}
+	return 0
+}
+
+func (x *ListEntitiesFromEventRequest) GetRadiusMeters() float64 {
+	if x != nil {
+		return x.RadiusMeters
+	}
+	return 0
+}
+
+func (x *ListEntitiesFromEventRequest) GetMinLatitude() float64 {
+	if x != nil {
+		return x.MinLatitude
+	}
+	return 0
+}
+
+func (x *ListEntitiesFromEventRequest) GetMinLongitude() float64 {
+	if x != nil {
+		return x.MinLongitude
+	}
+	return 0
+}
+
+func (x *ListEntitiesFromEventRequest) GetMaxLatitude() float64 {
+	if x != nil {
+		return x.MaxLatitude
+	}
+	return 0
+}
+
+func (x *ListEntitiesFromEventRequest) GetMaxLongitude() float64 {
+	if x != nil {
+		return x.MaxLongitude
+	}
+	return 0
+}
+
+func (x *ListEntitiesFromEventRequest) GetPolygon() []float64 {
+	if x != nil {
+		return x.Polygon
+	}
+	return nil
+}
+
+type ListEntitiesFromEventResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Entities  []*v1.Entity           `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	Relations []*v1.Relation         `protobuf:"bytes,2,rep,name=relations,proto3" json:"relations,omitempty"`
+	// Best path confidence (0-1) from the start entities, keyed by entity _id
+	PathConfidence map[string]float64 `protobuf:"bytes,3,rep,name=path_confidence,json=pathConfidence,proto3" json:"path_confidence,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListEntitiesFromEventResponse) Reset() {
+	*x = ListEntitiesFromEventResponse{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntitiesFromEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntitiesFromEventResponse) ProtoMessage() {}
+
+func (x *ListEntitiesFromEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntitiesFromEventResponse.ProtoReflect.Descriptor instead.
+func (*ListEntitiesFromEventResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListEntitiesFromEventResponse) GetEntities() []*v1.Entity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *ListEntitiesFromEventResponse) GetRelations() []*v1.Relation {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+func (x *ListEntitiesFromEventResponse) GetPathConfidence() map[string]float64 {
+	if x != nil {
+		return x.PathConfidence
+	}
+	return nil
+}
+
+type NearbyEventsRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Latitude     float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude    float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	RadiusMeters float64                `protobuf:"fixed64,3,opt,name=radius_meters,json=radiusMeters,proto3" json:"radius_meters,omitempty"`
+	// Only events that happened in the time range, an end_time of 0 leaves it open
+	StartTime     int64 `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       int64 `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Limit         int32 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearbyEventsRequest) Reset() {
+	*x = NearbyEventsRequest{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbyEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyEventsRequest) ProtoMessage() {}
+
+func (x *NearbyEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyEventsRequest.ProtoReflect.Descriptor instead.
+func (*NearbyEventsRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NearbyEventsRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *NearbyEventsRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *NearbyEventsRequest) GetRadiusMeters() float64 {
+	if x != nil {
+		return x.RadiusMeters
+	}
+	return 0
+}
+
+func (x *NearbyEventsRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *NearbyEventsRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *NearbyEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type NearbyEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Event          *v1.Event              `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	DistanceMeters float64                `protobuf:"fixed64,2,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *NearbyEvent) Reset() {
+	*x = NearbyEvent{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbyEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyEvent) ProtoMessage() {}
+
+func (x *NearbyEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyEvent.ProtoReflect.Descriptor instead.
+func (*NearbyEvent) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *NearbyEvent) GetEvent() *v1.Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *NearbyEvent) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+type NearbyEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*NearbyEvent         `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearbyEventsResponse) Reset() {
+	*x = NearbyEventsResponse{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbyEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyEventsResponse) ProtoMessage() {}
+
+func (x *NearbyEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyEventsResponse.ProtoReflect.Descriptor instead.
+func (*NearbyEventsResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *NearbyEventsResponse) GetEvents() []*NearbyEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type GetEntityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntityType    string                 `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEntityRequest) Reset() {
+	*x = GetEntityRequest{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntityRequest) ProtoMessage() {}
+
+func (x *GetEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntityRequest.ProtoReflect.Descriptor instead.
+func (*GetEntityRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetEntityRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *GetEntityRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetEntityResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Entity *v1.Entity             `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	// Set to the requested _id when it was merged into the returned entity
+	RedirectedFrom string `protobuf:"bytes,2,opt,name=redirected_from,json=redirectedFrom,proto3" json:"redirected_from,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetEntityResponse) Reset() {
+	*x = GetEntityResponse{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntityResponse) ProtoMessage() {}
+
+func (x *GetEntityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntityResponse.ProtoReflect.Descriptor instead.
+func (*GetEntityResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetEntityResponse) GetEntity() *v1.Entity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+func (x *GetEntityResponse) GetRedirectedFrom() string {
+	if x != nil {
+		return x.RedirectedFrom
+	}
+	return ""
+}
+
+type CreateEntityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntityType    string                 `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	Entity        *v1.Entity             `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateEntityRequest) Reset() {
+	*x = CreateEntityRequest{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEntityRequest) ProtoMessage() {}
+
+func (x *CreateEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEntityRequest.ProtoReflect.Descriptor instead.
+func (*CreateEntityRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateEntityRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *CreateEntityRequest) GetEntity() *v1.Entity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+type CreateEntityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entity        *v1.Entity             `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateEntityResponse) Reset() {
+	*x = CreateEntityResponse{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEntityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEntityResponse) ProtoMessage() {}
+
+func (x *CreateEntityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEntityResponse.ProtoReflect.Descriptor instead.
+func (*CreateEntityResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateEntityResponse) GetEntity() *v1.Entity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+type UpsertEntityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	StartNode     string                 `protobuf:"bytes,1,opt,name=start_node,json=startNode,proto3" json:"start_node,omitempty"`
-	StartTime     int64                  `protobuf:"varint,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       int64                  `protobuf:"varint,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	CountryCode   string                 `protobuf:"bytes,4,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
-	Tag           string                 `protobuf:"bytes,5,opt,name=tag,proto3" json:"tag,omitempty"`
-	Depth         int32                  `protobuf:"varint,6,opt,name=depth,proto3" json:"depth,omitempty"`
+	EntityType    string                 `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	Entity        *v1.Entity             `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertEntityRequest) Reset() {
+	*x = UpsertEntityRequest{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertEntityRequest) ProtoMessage() {}
+
+func (x *UpsertEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertEntityRequest.ProtoReflect.Descriptor instead.
+func (*UpsertEntityRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpsertEntityRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *UpsertEntityRequest) GetEntity() *v1.Entity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+type UpsertEntityResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Entity *v1.Entity             `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	// True if no entity matched the natural key and a new one was created
+	Created       bool `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListEntitiesFromEventRequest) Reset() {
-	*x = ListEntitiesFromEventRequest{}
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[0]
+func (x *UpsertEntityResponse) Reset() {
+	*x = UpsertEntityResponse{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListEntitiesFromEventRequest) String() string {
+func (x *UpsertEntityResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListEntitiesFromEventRequest) ProtoMessage() {}
+func (*UpsertEntityResponse) ProtoMessage() {}
 
-func (x *ListEntitiesFromEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[0]
+func (x *UpsertEntityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -61,76 +752,111 @@ func (x *ListEntitiesFromEventRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListEntitiesFromEventRequest.ProtoReflect.Descriptor instead.
-func (*ListEntitiesFromEventRequest) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use UpsertEntityResponse.ProtoReflect.Descriptor instead.
+func (*UpsertEntityResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ListEntitiesFromEventRequest) GetStartNode() string {
+func (x *UpsertEntityResponse) GetEntity() *v1.Entity {
 	if x != nil {
-		return x.StartNode
+		return x.Entity
 	}
-	return ""
+	return nil
 }
 
-func (x *ListEntitiesFromEventRequest) GetStartTime() int64 {
+func (x *UpsertEntityResponse) GetCreated() bool {
 	if x != nil {
-		return x.StartTime
+		return x.Created
 	}
-	return 0
+	return false
 }
 
-func (x *ListEntitiesFromEventRequest) GetEndTime() int64 {
+type BatchCreateEntitiesRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EntityType string                 `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	Entities   []*v1.Entity           `protobuf:"bytes,2,rep,name=entities,proto3" json:"entities,omitempty"`
+	// Update entities matching the natural key instead of creating duplicates
+	Upsert        bool `protobuf:"varint,3,opt,name=upsert,proto3" json:"upsert,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateEntitiesRequest) Reset() {
+	*x = BatchCreateEntitiesRequest{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateEntitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateEntitiesRequest) ProtoMessage() {}
+
+func (x *BatchCreateEntitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[11]
 	if x != nil {
-		return x.EndTime
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *ListEntitiesFromEventRequest) GetCountryCode() string {
+// Deprecated: Use BatchCreateEntitiesRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateEntitiesRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchCreateEntitiesRequest) GetEntityType() string {
 	if x != nil {
-		return x.CountryCode
+		return x.EntityType
 	}
 	return ""
 }
 
-func (x *ListEntitiesFromEventRequest) GetTag() string {
+func (x *BatchCreateEntitiesRequest) GetEntities() []*v1.Entity {
 	if x != nil {
-		return x.Tag
+		return x.Entities
 	}
-	return ""
+	return nil
 }
 
-func (x *ListEntitiesFromEventRequest) GetDepth() int32 {
+func (x *BatchCreateEntitiesRequest) GetUpsert() bool {
 	if x != nil {
-		return x.Depth
+		return x.Upsert
 	}
-	return 0
+	return false
 }
 
-type ListEntitiesFromEventResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Entities      []*v1.Entity           `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
-	Relations     []*v1.Relation         `protobuf:"bytes,2,rep,name=relations,proto3" json:"relations,omitempty"`
+type BatchCreateEntitiesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entities in request order
+	Entities []*v1.Entity `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	// Whether each entity was created, false if it was updated in upsert mode
+	Created       []bool `protobuf:"varint,2,rep,packed,name=created,proto3" json:"created,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListEntitiesFromEventResponse) Reset() {
-	*x = ListEntitiesFromEventResponse{}
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[1]
+func (x *BatchCreateEntitiesResponse) Reset() {
+	*x = BatchCreateEntitiesResponse{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListEntitiesFromEventResponse) String() string {
+func (x *BatchCreateEntitiesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListEntitiesFromEventResponse) ProtoMessage() {}
+func (*BatchCreateEntitiesResponse) ProtoMessage() {}
 
-func (x *ListEntitiesFromEventResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[1]
+func (x *BatchCreateEntitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -141,48 +867,57 @@ func (x *ListEntitiesFromEventResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListEntitiesFromEventResponse.ProtoReflect.Descriptor instead.
-func (*ListEntitiesFromEventResponse) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use BatchCreateEntitiesResponse.ProtoReflect.Descriptor instead.
+func (*BatchCreateEntitiesResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ListEntitiesFromEventResponse) GetEntities() []*v1.Entity {
+func (x *BatchCreateEntitiesResponse) GetEntities() []*v1.Entity {
 	if x != nil {
 		return x.Entities
 	}
 	return nil
 }
 
-func (x *ListEntitiesFromEventResponse) GetRelations() []*v1.Relation {
+func (x *BatchCreateEntitiesResponse) GetCreated() []bool {
 	if x != nil {
-		return x.Relations
+		return x.Created
 	}
 	return nil
 }
 
-type GetEntityRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	EntityType    string                 `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
-	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+type ImportOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "jsonl", one model.v1.Entity or model.v1.Relation per line, or "csv"
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	// Entity type of the rows of a CSV file
+	EntityType string `protobuf:"bytes,2,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	// Maps CSV columns to entity field paths such as "location.latitude".
+	// Columns are used as field paths if empty. List fields are split on ";".
+	Columns map[string]string `protobuf:"bytes,3,rep,name=columns,proto3" json:"columns,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Records written per transaction, defaults to 100
+	BatchSize int32 `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// Update entities matching the natural key instead of creating duplicates
+	Upsert        bool `protobuf:"varint,5,opt,name=upsert,proto3" json:"upsert,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetEntityRequest) Reset() {
-	*x = GetEntityRequest{}
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[2]
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetEntityRequest) String() string {
+func (x *ImportOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEntityRequest) ProtoMessage() {}
+func (*ImportOptions) ProtoMessage() {}
 
-func (x *GetEntityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[2]
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -193,47 +928,71 @@ func (x *GetEntityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetEntityRequest.ProtoReflect.Descriptor instead.
-func (*GetEntityRequest) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GetEntityRequest) GetEntityType() string {
+func (x *ImportOptions) GetFormat() string {
 	if x != nil {
-		return x.EntityType
+		return x.Format
 	}
 	return ""
 }
 
-func (x *GetEntityRequest) GetKey() string {
+func (x *ImportOptions) GetEntityType() string {
 	if x != nil {
-		return x.Key
+		return x.EntityType
 	}
 	return ""
 }
 
-type GetEntityResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Entity        *v1.Entity             `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+func (x *ImportOptions) GetColumns() map[string]string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *ImportOptions) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *ImportOptions) GetUpsert() bool {
+	if x != nil {
+		return x.Upsert
+	}
+	return false
+}
+
+type ImportEntitiesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required in the first message, ignored afterwards
+	Options *ImportOptions `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	// Next chunk of the file
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetEntityResponse) Reset() {
-	*x = GetEntityResponse{}
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[3]
+func (x *ImportEntitiesRequest) Reset() {
+	*x = ImportEntitiesRequest{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetEntityResponse) String() string {
+func (x *ImportEntitiesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEntityResponse) ProtoMessage() {}
+func (*ImportEntitiesRequest) ProtoMessage() {}
 
-func (x *GetEntityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[3]
+func (x *ImportEntitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -244,41 +1003,55 @@ func (x *GetEntityResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetEntityResponse.ProtoReflect.Descriptor instead.
-func (*GetEntityResponse) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ImportEntitiesRequest.ProtoReflect.Descriptor instead.
+func (*ImportEntitiesRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetEntityResponse) GetEntity() *v1.Entity {
+func (x *ImportEntitiesRequest) GetOptions() *ImportOptions {
 	if x != nil {
-		return x.Entity
+		return x.Options
 	}
 	return nil
 }
 
-type CreateEntityRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	EntityType    string                 `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
-	Entity        *v1.Entity             `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+func (x *ImportEntitiesRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Line of the record in the file, or position of the object in a STIX
+	// bundle, starting at 1
+	Line    int64  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// STIX id of the object, for STIX imports
+	ObjectId string `protobuf:"bytes,3,opt,name=object_id,json=objectId,proto3" json:"object_id,omitempty"`
+	// gRPC status code and google.rpc error details of the failure
+	Code          int32        `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`
+	Details       []*anypb.Any `protobuf:"bytes,5,rep,name=details,proto3" json:"details,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateEntityRequest) Reset() {
-	*x = CreateEntityRequest{}
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[4]
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateEntityRequest) String() string {
+func (x *ImportError) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateEntityRequest) ProtoMessage() {}
+func (*ImportError) ProtoMessage() {}
 
-func (x *CreateEntityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[4]
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -289,47 +1062,84 @@ func (x *CreateEntityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateEntityRequest.ProtoReflect.Descriptor instead.
-func (*CreateEntityRequest) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *CreateEntityRequest) GetEntityType() string {
+func (x *ImportError) GetLine() int64 {
 	if x != nil {
-		return x.EntityType
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportError) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *CreateEntityRequest) GetEntity() *v1.Entity {
+func (x *ImportError) GetObjectId() string {
 	if x != nil {
-		return x.Entity
+		return x.ObjectId
+	}
+	return ""
+}
+
+func (x *ImportError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ImportError) GetDetails() []*anypb.Any {
+	if x != nil {
+		return x.Details
 	}
 	return nil
 }
 
-type CreateEntityResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Entity        *v1.Entity             `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+// Objects of a STIX bundle are imported as follows:
+//
+//	incident, report                    -> event
+//	identity (identity_class=individual) -> person
+//	identity (other classes)            -> organization
+//	infrastructure, url                 -> website
+//	relationship                        -> relationship named after relationship_type
+//
+// Their keys are the UUIDs of their STIX ids. External references are
+// imported as sources when reference_relation is set.
+type ImportStixRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// STIX 2.1 bundle, the HTTP request body
+	Bundle *structpb.Struct `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// Relationship connecting imported objects to the sources created from their
+	// external references. External references are ignored if empty.
+	ReferenceRelation string `protobuf:"bytes,2,opt,name=reference_relation,json=referenceRelation,proto3" json:"reference_relation,omitempty"`
+	// Objects written per transaction, defaults to 100
+	BatchSize     int32 `protobuf:"varint,3,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateEntityResponse) Reset() {
-	*x = CreateEntityResponse{}
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[5]
+func (x *ImportStixRequest) Reset() {
+	*x = ImportStixRequest{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateEntityResponse) String() string {
+func (x *ImportStixRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateEntityResponse) ProtoMessage() {}
+func (*ImportStixRequest) ProtoMessage() {}
 
-func (x *CreateEntityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[5]
+func (x *ImportStixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -340,14 +1150,97 @@ func (x *CreateEntityResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateEntityResponse.ProtoReflect.Descriptor instead.
-func (*CreateEntityResponse) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ImportStixRequest.ProtoReflect.Descriptor instead.
+func (*ImportStixRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *CreateEntityResponse) GetEntity() *v1.Entity {
+func (x *ImportStixRequest) GetBundle() *structpb.Struct {
 	if x != nil {
-		return x.Entity
+		return x.Bundle
+	}
+	return nil
+}
+
+func (x *ImportStixRequest) GetReferenceRelation() string {
+	if x != nil {
+		return x.ReferenceRelation
+	}
+	return ""
+}
+
+func (x *ImportStixRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+type ImportEntitiesResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	EntitiesImported      int64                  `protobuf:"varint,1,opt,name=entities_imported,json=entitiesImported,proto3" json:"entities_imported,omitempty"`
+	RelationshipsImported int64                  `protobuf:"varint,2,opt,name=relationships_imported,json=relationshipsImported,proto3" json:"relationships_imported,omitempty"`
+	RecordsFailed         int64                  `protobuf:"varint,3,opt,name=records_failed,json=recordsFailed,proto3" json:"records_failed,omitempty"`
+	// Errors of the first failed records
+	Errors        []*ImportError `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportEntitiesResponse) Reset() {
+	*x = ImportEntitiesResponse{}
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportEntitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportEntitiesResponse) ProtoMessage() {}
+
+func (x *ImportEntitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportEntitiesResponse.ProtoReflect.Descriptor instead.
+func (*ImportEntitiesResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ImportEntitiesResponse) GetEntitiesImported() int64 {
+	if x != nil {
+		return x.EntitiesImported
+	}
+	return 0
+}
+
+func (x *ImportEntitiesResponse) GetRelationshipsImported() int64 {
+	if x != nil {
+		return x.RelationshipsImported
+	}
+	return 0
+}
+
+func (x *ImportEntitiesResponse) GetRecordsFailed() int64 {
+	if x != nil {
+		return x.RecordsFailed
+	}
+	return 0
+}
+
+func (x *ImportEntitiesResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
 	}
 	return nil
 }
@@ -363,7 +1256,7 @@ type UpdateEntityRequest struct {
 
 func (x *UpdateEntityRequest) Reset() {
 	*x = UpdateEntityRequest{}
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[6]
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -375,7 +1268,7 @@ func (x *UpdateEntityRequest) String() string {
 func (*UpdateEntityRequest) ProtoMessage() {}
 
 func (x *UpdateEntityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[6]
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -388,7 +1281,7 @@ func (x *UpdateEntityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateEntityRequest.ProtoReflect.Descriptor instead.
 func (*UpdateEntityRequest) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{6}
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *UpdateEntityRequest) GetEntityType() string {
@@ -421,7 +1314,7 @@ type UpdateEntityResponse struct {
 
 func (x *UpdateEntityResponse) Reset() {
 	*x = UpdateEntityResponse{}
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[7]
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -433,7 +1326,7 @@ func (x *UpdateEntityResponse) String() string {
 func (*UpdateEntityResponse) ProtoMessage() {}
 
 func (x *UpdateEntityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[7]
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -446,7 +1339,7 @@ func (x *UpdateEntityResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateEntityResponse.ProtoReflect.Descriptor instead.
 func (*UpdateEntityResponse) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{7}
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *UpdateEntityResponse) GetEntity() *v1.Entity {
@@ -466,7 +1359,7 @@ type DeleteEntityRequest struct {
 
 func (x *DeleteEntityRequest) Reset() {
 	*x = DeleteEntityRequest{}
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[8]
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -478,7 +1371,7 @@ func (x *DeleteEntityRequest) String() string {
 func (*DeleteEntityRequest) ProtoMessage() {}
 
 func (x *DeleteEntityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[8]
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -491,7 +1384,7 @@ func (x *DeleteEntityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteEntityRequest.ProtoReflect.Descriptor instead.
 func (*DeleteEntityRequest) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{8}
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *DeleteEntityRequest) GetEntityType() string {
@@ -516,7 +1409,7 @@ type DeleteEntityResponse struct {
 
 func (x *DeleteEntityResponse) Reset() {
 	*x = DeleteEntityResponse{}
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[9]
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -528,7 +1421,7 @@ func (x *DeleteEntityResponse) String() string {
 func (*DeleteEntityResponse) ProtoMessage() {}
 
 func (x *DeleteEntityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_entity_service_proto_msgTypes[9]
+	mi := &file_dapi_v1_entity_service_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -541,14 +1434,14 @@ func (x *DeleteEntityResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteEntityResponse.ProtoReflect.Descriptor instead.
 func (*DeleteEntityResponse) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{9}
+	return file_dapi_v1_entity_service_proto_rawDescGZIP(), []int{21}
 }
 
 var File_dapi_v1_entity_service_proto protoreflect.FileDescriptor
 
 const file_dapi_v1_entity_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1cdapi/v1/entity_service.proto\x12\adapi.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x14model/v1/osint.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xc2\x01\n" +
+	"\x1cdapi/v1/entity_service.proto\x12\adapi.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x19google/protobuf/any.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x14model/v1/osint.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\x92\x05\n" +
 	"\x1cListEntitiesFromEventRequest\x12\x1d\n" +
 	"\n" +
 	"start_node\x18\x01 \x01(\tR\tstartNode\x12\x1d\n" +
@@ -557,22 +1450,100 @@ const file_dapi_v1_entity_service_proto_rawDesc = "" +
 	"\bend_time\x18\x03 \x01(\x03R\aendTime\x12!\n" +
 	"\fcountry_code\x18\x04 \x01(\tR\vcountryCode\x12\x10\n" +
 	"\x03tag\x18\x05 \x01(\tR\x03tag\x12\x14\n" +
-	"\x05depth\x18\x06 \x01(\x05R\x05depth\"\x7f\n" +
+	"\x05depth\x18\x06 \x01(\x05R\x05depth\x12%\n" +
+	"\x0emin_confidence\x18\a \x01(\x05R\rminConfidence\x125\n" +
+	"\x16confidence_aggregation\x18\b \x01(\tR\x15confidenceAggregation\x12\x13\n" +
+	"\x05as_of\x18\t \x01(\x03R\x04asOf\x12!\n" +
+	"\fwindow_start\x18\n" +
+	" \x01(\x03R\vwindowStart\x12\x1d\n" +
+	"\n" +
+	"window_end\x18\v \x01(\x03R\twindowEnd\x12#\n" +
+	"\rnear_latitude\x18\f \x01(\x01R\fnearLatitude\x12%\n" +
+	"\x0enear_longitude\x18\r \x01(\x01R\rnearLongitude\x12#\n" +
+	"\rradius_meters\x18\x0e \x01(\x01R\fradiusMeters\x12!\n" +
+	"\fmin_latitude\x18\x0f \x01(\x01R\vminLatitude\x12#\n" +
+	"\rmin_longitude\x18\x10 \x01(\x01R\fminLongitude\x12!\n" +
+	"\fmax_latitude\x18\x11 \x01(\x01R\vmaxLatitude\x12#\n" +
+	"\rmax_longitude\x18\x12 \x01(\x01R\fmaxLongitude\x12\x18\n" +
+	"\apolygon\x18\x13 \x03(\x01R\apolygon\"\xa7\x02\n" +
 	"\x1dListEntitiesFromEventResponse\x12,\n" +
 	"\bentities\x18\x01 \x03(\v2\x10.model.v1.EntityR\bentities\x120\n" +
-	"\trelations\x18\x02 \x03(\v2\x12.model.v1.RelationR\trelations\"E\n" +
+	"\trelations\x18\x02 \x03(\v2\x12.model.v1.RelationR\trelations\x12c\n" +
+	"\x0fpath_confidence\x18\x03 \x03(\v2:.dapi.v1.ListEntitiesFromEventResponse.PathConfidenceEntryR\x0epathConfidence\x1aA\n" +
+	"\x13PathConfidenceEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xc4\x01\n" +
+	"\x13NearbyEventsRequest\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12#\n" +
+	"\rradius_meters\x18\x03 \x01(\x01R\fradiusMeters\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x05 \x01(\x03R\aendTime\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\"]\n" +
+	"\vNearbyEvent\x12%\n" +
+	"\x05event\x18\x01 \x01(\v2\x0f.model.v1.EventR\x05event\x12'\n" +
+	"\x0fdistance_meters\x18\x02 \x01(\x01R\x0edistanceMeters\"D\n" +
+	"\x14NearbyEventsResponse\x12,\n" +
+	"\x06events\x18\x01 \x03(\v2\x14.dapi.v1.NearbyEventR\x06events\"E\n" +
 	"\x10GetEntityRequest\x12\x1f\n" +
 	"\ventity_type\x18\x01 \x01(\tR\n" +
 	"entityType\x12\x10\n" +
-	"\x03key\x18\x02 \x01(\tR\x03key\"=\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\"f\n" +
 	"\x11GetEntityResponse\x12(\n" +
-	"\x06entity\x18\x01 \x01(\v2\x10.model.v1.EntityR\x06entity\"`\n" +
+	"\x06entity\x18\x01 \x01(\v2\x10.model.v1.EntityR\x06entity\x12'\n" +
+	"\x0fredirected_from\x18\x02 \x01(\tR\x0eredirectedFrom\"`\n" +
 	"\x13CreateEntityRequest\x12\x1f\n" +
 	"\ventity_type\x18\x01 \x01(\tR\n" +
 	"entityType\x12(\n" +
 	"\x06entity\x18\x02 \x01(\v2\x10.model.v1.EntityR\x06entity\"@\n" +
 	"\x14CreateEntityResponse\x12(\n" +
-	"\x06entity\x18\x01 \x01(\v2\x10.model.v1.EntityR\x06entity\"r\n" +
+	"\x06entity\x18\x01 \x01(\v2\x10.model.v1.EntityR\x06entity\"`\n" +
+	"\x13UpsertEntityRequest\x12\x1f\n" +
+	"\ventity_type\x18\x01 \x01(\tR\n" +
+	"entityType\x12(\n" +
+	"\x06entity\x18\x02 \x01(\v2\x10.model.v1.EntityR\x06entity\"Z\n" +
+	"\x14UpsertEntityResponse\x12(\n" +
+	"\x06entity\x18\x01 \x01(\v2\x10.model.v1.EntityR\x06entity\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\"\x83\x01\n" +
+	"\x1aBatchCreateEntitiesRequest\x12\x1f\n" +
+	"\ventity_type\x18\x01 \x01(\tR\n" +
+	"entityType\x12,\n" +
+	"\bentities\x18\x02 \x03(\v2\x10.model.v1.EntityR\bentities\x12\x16\n" +
+	"\x06upsert\x18\x03 \x01(\bR\x06upsert\"e\n" +
+	"\x1bBatchCreateEntitiesResponse\x12,\n" +
+	"\bentities\x18\x01 \x03(\v2\x10.model.v1.EntityR\bentities\x12\x18\n" +
+	"\acreated\x18\x02 \x03(\bR\acreated\"\xfa\x01\n" +
+	"\rImportOptions\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x1f\n" +
+	"\ventity_type\x18\x02 \x01(\tR\n" +
+	"entityType\x12=\n" +
+	"\acolumns\x18\x03 \x03(\v2#.dapi.v1.ImportOptions.ColumnsEntryR\acolumns\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x04 \x01(\x05R\tbatchSize\x12\x16\n" +
+	"\x06upsert\x18\x05 \x01(\bR\x06upsert\x1a:\n" +
+	"\fColumnsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"]\n" +
+	"\x15ImportEntitiesRequest\x120\n" +
+	"\aoptions\x18\x01 \x01(\v2\x16.dapi.v1.ImportOptionsR\aoptions\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\x9c\x01\n" +
+	"\vImportError\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x03R\x04line\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\tobject_id\x18\x03 \x01(\tR\bobjectId\x12\x12\n" +
+	"\x04code\x18\x04 \x01(\x05R\x04code\x12.\n" +
+	"\adetails\x18\x05 \x03(\v2\x14.google.protobuf.AnyR\adetails\"\x92\x01\n" +
+	"\x11ImportStixRequest\x12/\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06bundle\x12-\n" +
+	"\x12reference_relation\x18\x02 \x01(\tR\x11referenceRelation\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x03 \x01(\x05R\tbatchSize\"\xd1\x01\n" +
+	"\x16ImportEntitiesResponse\x12+\n" +
+	"\x11entities_imported\x18\x01 \x01(\x03R\x10entitiesImported\x125\n" +
+	"\x16relationships_imported\x18\x02 \x01(\x03R\x15relationshipsImported\x12%\n" +
+	"\x0erecords_failed\x18\x03 \x01(\x03R\rrecordsFailed\x12,\n" +
+	"\x06errors\x18\x04 \x03(\v2\x14.dapi.v1.ImportErrorR\x06errors\"r\n" +
 	"\x13UpdateEntityRequest\x12\x1f\n" +
 	"\ventity_type\x18\x01 \x01(\tR\n" +
 	"entityType\x12\x10\n" +
@@ -584,11 +1555,18 @@ const file_dapi_v1_entity_service_proto_rawDesc = "" +
 	"\ventity_type\x18\x01 \x01(\tR\n" +
 	"entityType\x12\x10\n" +
 	"\x03key\x18\x02 \x01(\tR\x03key\"\x16\n" +
-	"\x14DeleteEntityResponse2\xf1\x04\n" +
+	"\x14DeleteEntityResponse2\xba\t\n" +
 	"\rEntityService\x12\x82\x01\n" +
-	"\x15ListEntitiesFromEvent\x12%.dapi.v1.ListEntitiesFromEventRequest\x1a&.dapi.v1.ListEntitiesFromEventResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/entities/event\x12l\n" +
+	"\x15ListEntitiesFromEvent\x12%.dapi.v1.ListEntitiesFromEventRequest\x1a&.dapi.v1.ListEntitiesFromEventResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/entities/event\x12f\n" +
+	"\fNearbyEvents\x12\x1c.dapi.v1.NearbyEventsRequest\x1a\x1d.dapi.v1.NearbyEventsResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/events/nearby\x12l\n" +
 	"\tGetEntity\x12\x19.dapi.v1.GetEntityRequest\x1a\x1a.dapi.v1.GetEntityResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /v1/entities/{entity_type}/{key}\x12w\n" +
-	"\fCreateEntity\x12\x1c.dapi.v1.CreateEntityRequest\x1a\x1d.dapi.v1.CreateEntityResponse\"*\x82\xd3\xe4\x93\x02$:\x06entity\"\x1a/v1/entities/{entity_type}\x12}\n" +
+	"\fCreateEntity\x12\x1c.dapi.v1.CreateEntityRequest\x1a\x1d.dapi.v1.CreateEntityResponse\"*\x82\xd3\xe4\x93\x02$:\x06entity\"\x1a/v1/entities/{entity_type}\x12w\n" +
+	"\fUpsertEntity\x12\x1c.dapi.v1.UpsertEntityRequest\x1a\x1d.dapi.v1.UpsertEntityResponse\"*\x82\xd3\xe4\x93\x02$:\x06entity\x1a\x1a/v1/entities/{entity_type}\x12\x8d\x01\n" +
+	"\x13BatchCreateEntities\x12#.dapi.v1.BatchCreateEntitiesRequest\x1a$.dapi.v1.BatchCreateEntitiesResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /v1/entities/{entity_type}/batch\x12j\n" +
+	"\x0eImportEntities\x12\x1e.dapi.v1.ImportEntitiesRequest\x1a\x1f.dapi.v1.ImportEntitiesResponse\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/v1/import(\x01\x12j\n" +
+	"\n" +
+	"ImportStix\x12\x1a.dapi.v1.ImportStixRequest\x1a\x1f.dapi.v1.ImportEntitiesResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x06bundle\"\x0f/v1/stix/import\x12}\n" +
 	"\fUpdateEntity\x12\x1c.dapi.v1.UpdateEntityRequest\x1a\x1d.dapi.v1.UpdateEntityResponse\"0\x82\xd3\xe4\x93\x02*:\x06entity\x1a /v1/entities/{entity_type}/{key}\x12u\n" +
 	"\fDeleteEntity\x12\x1c.dapi.v1.DeleteEntityRequest\x1a\x1d.dapi.v1.DeleteEntityResponse\"(\x82\xd3\xe4\x93\x02\"* /v1/entities/{entity_type}/{key}B\xf1\x01\x92A\xbf\x01\x12\x95\x01\n" +
 	"\bData API\x125The OSINT data API handles data for OSINT operations.\"\v\n" +
@@ -608,44 +1586,83 @@ func file_dapi_v1_entity_service_proto_rawDescGZIP() []byte {
 	return file_dapi_v1_entity_service_proto_rawDescData
 }
 
-var file_dapi_v1_entity_service_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_dapi_v1_entity_service_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_dapi_v1_entity_service_proto_goTypes = []any{
 	(*ListEntitiesFromEventRequest)(nil),  // 0: dapi.v1.ListEntitiesFromEventRequest
 	(*ListEntitiesFromEventResponse)(nil), // 1: dapi.v1.ListEntitiesFromEventResponse
-	(*GetEntityRequest)(nil),              // 2: dapi.v1.GetEntityRequest
-	(*GetEntityResponse)(nil),             // 3: dapi.v1.GetEntityResponse
-	(*CreateEntityRequest)(nil),           // 4: dapi.v1.CreateEntityRequest
-	(*CreateEntityResponse)(nil),          // 5: dapi.v1.CreateEntityResponse
-	(*UpdateEntityRequest)(nil),           // 6: dapi.v1.UpdateEntityRequest
-	(*UpdateEntityResponse)(nil),          // 7: dapi.v1.UpdateEntityResponse
-	(*DeleteEntityRequest)(nil),           // 8: dapi.v1.DeleteEntityRequest
-	(*DeleteEntityResponse)(nil),          // 9: dapi.v1.DeleteEntityResponse
-	(*v1.Entity)(nil),                     // 10: model.v1.Entity
-	(*v1.Relation)(nil),                   // 11: model.v1.Relation
+	(*NearbyEventsRequest)(nil),           // 2: dapi.v1.NearbyEventsRequest
+	(*NearbyEvent)(nil),                   // 3: dapi.v1.NearbyEvent
+	(*NearbyEventsResponse)(nil),          // 4: dapi.v1.NearbyEventsResponse
+	(*GetEntityRequest)(nil),              // 5: dapi.v1.GetEntityRequest
+	(*GetEntityResponse)(nil),             // 6: dapi.v1.GetEntityResponse
+	(*CreateEntityRequest)(nil),           // 7: dapi.v1.CreateEntityRequest
+	(*CreateEntityResponse)(nil),          // 8: dapi.v1.CreateEntityResponse
+	(*UpsertEntityRequest)(nil),           // 9: dapi.v1.UpsertEntityRequest
+	(*UpsertEntityResponse)(nil),          // 10: dapi.v1.UpsertEntityResponse
+	(*BatchCreateEntitiesRequest)(nil),    // 11: dapi.v1.BatchCreateEntitiesRequest
+	(*BatchCreateEntitiesResponse)(nil),   // 12: dapi.v1.BatchCreateEntitiesResponse
+	(*ImportOptions)(nil),                 // 13: dapi.v1.ImportOptions
+	(*ImportEntitiesRequest)(nil),         // 14: dapi.v1.ImportEntitiesRequest
+	(*ImportError)(nil),                   // 15: dapi.v1.ImportError
+	(*ImportStixRequest)(nil),             // 16: dapi.v1.ImportStixRequest
+	(*ImportEntitiesResponse)(nil),        // 17: dapi.v1.ImportEntitiesResponse
+	(*UpdateEntityRequest)(nil),           // 18: dapi.v1.UpdateEntityRequest
+	(*UpdateEntityResponse)(nil),          // 19: dapi.v1.UpdateEntityResponse
+	(*DeleteEntityRequest)(nil),           // 20: dapi.v1.DeleteEntityRequest
+	(*DeleteEntityResponse)(nil),          // 21: dapi.v1.DeleteEntityResponse
+	nil,                                   // 22: dapi.v1.ListEntitiesFromEventResponse.PathConfidenceEntry
+	nil,                                   // 23: dapi.v1.ImportOptions.ColumnsEntry
+	(*v1.Entity)(nil),                     // 24: model.v1.Entity
+	(*v1.Relation)(nil),                   // 25: model.v1.Relation
+	(*v1.Event)(nil),                      // 26: model.v1.Event
+	(*anypb.Any)(nil),                     // 27: google.protobuf.Any
+	(*structpb.Struct)(nil),               // 28: google.protobuf.Struct
 }
 var file_dapi_v1_entity_service_proto_depIdxs = []int32{
-	10, // 0: dapi.v1.ListEntitiesFromEventResponse.entities:type_name -> model.v1.Entity
-	11, // 1: dapi.v1.ListEntitiesFromEventResponse.relations:type_name -> model.v1.Relation
-	10, // 2: dapi.v1.GetEntityResponse.entity:type_name -> model.v1.Entity
-	10, // 3: dapi.v1.CreateEntityRequest.entity:type_name -> model.v1.Entity
-	10, // 4: dapi.v1.CreateEntityResponse.entity:type_name -> model.v1.Entity
-	10, // 5: dapi.v1.UpdateEntityRequest.entity:type_name -> model.v1.Entity
-	10, // 6: dapi.v1.UpdateEntityResponse.entity:type_name -> model.v1.Entity
-	0,  // 7: dapi.v1.EntityService.ListEntitiesFromEvent:input_type -> dapi.v1.ListEntitiesFromEventRequest
-	2,  // 8: dapi.v1.EntityService.GetEntity:input_type -> dapi.v1.GetEntityRequest
-	4,  // 9: dapi.v1.EntityService.CreateEntity:input_type -> dapi.v1.CreateEntityRequest
-	6,  // 10: dapi.v1.EntityService.UpdateEntity:input_type -> dapi.v1.UpdateEntityRequest
-	8,  // 11: dapi.v1.EntityService.DeleteEntity:input_type -> dapi.v1.DeleteEntityRequest
-	1,  // 12: dapi.v1.EntityService.ListEntitiesFromEvent:output_type -> dapi.v1.ListEntitiesFromEventResponse
-	3,  // 13: dapi.v1.EntityService.GetEntity:output_type -> dapi.v1.GetEntityResponse
-	5,  // 14: dapi.v1.EntityService.CreateEntity:output_type -> dapi.v1.CreateEntityResponse
-	7,  // 15: dapi.v1.EntityService.UpdateEntity:output_type -> dapi.v1.UpdateEntityResponse
-	9,  // 16: dapi.v1.EntityService.DeleteEntity:output_type -> dapi.v1.DeleteEntityResponse
-	12, // [12:17] is the sub-list for method output_type
-	7,  // [7:12] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	24, // 0: dapi.v1.ListEntitiesFromEventResponse.entities:type_name -> model.v1.Entity
+	25, // 1: dapi.v1.ListEntitiesFromEventResponse.relations:type_name -> model.v1.Relation
+	22, // 2: dapi.v1.ListEntitiesFromEventResponse.path_confidence:type_name -> dapi.v1.ListEntitiesFromEventResponse.PathConfidenceEntry
+	26, // 3: dapi.v1.NearbyEvent.event:type_name -> model.v1.Event
+	3,  // 4: dapi.v1.NearbyEventsResponse.events:type_name -> dapi.v1.NearbyEvent
+	24, // 5: dapi.v1.GetEntityResponse.entity:type_name -> model.v1.Entity
+	24, // 6: dapi.v1.CreateEntityRequest.entity:type_name -> model.v1.Entity
+	24, // 7: dapi.v1.CreateEntityResponse.entity:type_name -> model.v1.Entity
+	24, // 8: dapi.v1.UpsertEntityRequest.entity:type_name -> model.v1.Entity
+	24, // 9: dapi.v1.UpsertEntityResponse.entity:type_name -> model.v1.Entity
+	24, // 10: dapi.v1.BatchCreateEntitiesRequest.entities:type_name -> model.v1.Entity
+	24, // 11: dapi.v1.BatchCreateEntitiesResponse.entities:type_name -> model.v1.Entity
+	23, // 12: dapi.v1.ImportOptions.columns:type_name -> dapi.v1.ImportOptions.ColumnsEntry
+	13, // 13: dapi.v1.ImportEntitiesRequest.options:type_name -> dapi.v1.ImportOptions
+	27, // 14: dapi.v1.ImportError.details:type_name -> google.protobuf.Any
+	28, // 15: dapi.v1.ImportStixRequest.bundle:type_name -> google.protobuf.Struct
+	15, // 16: dapi.v1.ImportEntitiesResponse.errors:type_name -> dapi.v1.ImportError
+	24, // 17: dapi.v1.UpdateEntityRequest.entity:type_name -> model.v1.Entity
+	24, // 18: dapi.v1.UpdateEntityResponse.entity:type_name -> model.v1.Entity
+	0,  // 19: dapi.v1.EntityService.ListEntitiesFromEvent:input_type -> dapi.v1.ListEntitiesFromEventRequest
+	2,  // 20: dapi.v1.EntityService.NearbyEvents:input_type -> dapi.v1.NearbyEventsRequest
+	5,  // 21: dapi.v1.EntityService.GetEntity:input_type -> dapi.v1.GetEntityRequest
+	7,  // 22: dapi.v1.EntityService.CreateEntity:input_type -> dapi.v1.CreateEntityRequest
+	9,  // 23: dapi.v1.EntityService.UpsertEntity:input_type -> dapi.v1.UpsertEntityRequest
+	11, // 24: dapi.v1.EntityService.BatchCreateEntities:input_type -> dapi.v1.BatchCreateEntitiesRequest
+	14, // 25: dapi.v1.EntityService.ImportEntities:input_type -> dapi.v1.ImportEntitiesRequest
+	16, // 26: dapi.v1.EntityService.ImportStix:input_type -> dapi.v1.ImportStixRequest
+	18, // 27: dapi.v1.EntityService.UpdateEntity:input_type -> dapi.v1.UpdateEntityRequest
+	20, // 28: dapi.v1.EntityService.DeleteEntity:input_type -> dapi.v1.DeleteEntityRequest
+	1,  // 29: dapi.v1.EntityService.ListEntitiesFromEvent:output_type -> dapi.v1.ListEntitiesFromEventResponse
+	4,  // 30: dapi.v1.EntityService.NearbyEvents:output_type -> dapi.v1.NearbyEventsResponse
+	6,  // 31: dapi.v1.EntityService.GetEntity:output_type -> dapi.v1.GetEntityResponse
+	8,  // 32: dapi.v1.EntityService.CreateEntity:output_type -> dapi.v1.CreateEntityResponse
+	10, // 33: dapi.v1.EntityService.UpsertEntity:output_type -> dapi.v1.UpsertEntityResponse
+	12, // 34: dapi.v1.EntityService.BatchCreateEntities:output_type -> dapi.v1.BatchCreateEntitiesResponse
+	17, // 35: dapi.v1.EntityService.ImportEntities:output_type -> dapi.v1.ImportEntitiesResponse
+	17, // 36: dapi.v1.EntityService.ImportStix:output_type -> dapi.v1.ImportEntitiesResponse
+	19, // 37: dapi.v1.EntityService.UpdateEntity:output_type -> dapi.v1.UpdateEntityResponse
+	21, // 38: dapi.v1.EntityService.DeleteEntity:output_type -> dapi.v1.DeleteEntityResponse
+	29, // [29:39] is the sub-list for method output_type
+	19, // [19:29] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_dapi_v1_entity_service_proto_init() }
@@ -659,7 +1676,7 @@ func file_dapi_v1_entity_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dapi_v1_entity_service_proto_rawDesc), len(file_dapi_v1_entity_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
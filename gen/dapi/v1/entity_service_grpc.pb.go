@@ -20,8 +20,13 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	EntityService_ListEntitiesFromEvent_FullMethodName = "/dapi.v1.EntityService/ListEntitiesFromEvent"
+	EntityService_NearbyEvents_FullMethodName          = "/dapi.v1.EntityService/NearbyEvents"
 	EntityService_GetEntity_FullMethodName             = "/dapi.v1.EntityService/GetEntity"
 	EntityService_CreateEntity_FullMethodName          = "/dapi.v1.EntityService/CreateEntity"
+	EntityService_UpsertEntity_FullMethodName          = "/dapi.v1.EntityService/UpsertEntity"
+	EntityService_BatchCreateEntities_FullMethodName   = "/dapi.v1.EntityService/BatchCreateEntities"
+	EntityService_ImportEntities_FullMethodName        = "/dapi.v1.EntityService/ImportEntities"
+	EntityService_ImportStix_FullMethodName            = "/dapi.v1.EntityService/ImportStix"
 	EntityService_UpdateEntity_FullMethodName          = "/dapi.v1.EntityService/UpdateEntity"
 	EntityService_DeleteEntity_FullMethodName          = "/dapi.v1.EntityService/DeleteEntity"
 )
@@ -31,8 +36,26 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type EntityServiceClient interface {
 	ListEntitiesFromEvent(ctx context.Context, in *ListEntitiesFromEventRequest, opts ...grpc.CallOption) (*ListEntitiesFromEventResponse, error)
+	// NearbyEvents returns the events within a radius of a point, nearest first
+	NearbyEvents(ctx context.Context, in *NearbyEventsRequest, opts ...grpc.CallOption) (*NearbyEventsResponse, error)
 	GetEntity(ctx context.Context, in *GetEntityRequest, opts ...grpc.CallOption) (*GetEntityResponse, error)
 	CreateEntity(ctx context.Context, in *CreateEntityRequest, opts ...grpc.CallOption) (*CreateEntityResponse, error)
+	// UpsertEntity updates the entity matching the natural key of the given
+	// entity, or creates it if there is none. Natural keys are the url of
+	// websites and sources, the name and birth_date of persons and the name and
+	// country attribute of organizations.
+	UpsertEntity(ctx context.Context, in *UpsertEntityRequest, opts ...grpc.CallOption) (*UpsertEntityResponse, error)
+	// BatchCreateEntities creates entities of one type in a single transaction,
+	// or upserts them by natural key in upsert mode
+	BatchCreateEntities(ctx context.Context, in *BatchCreateEntitiesRequest, opts ...grpc.CallOption) (*BatchCreateEntitiesResponse, error)
+	// ImportEntities ingests entities and relationships streamed as a JSON Lines
+	// or CSV file. Records are validated one by one and written in batches,
+	// invalid records are reported by line and do not stop the import.
+	ImportEntities(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportEntitiesRequest, ImportEntitiesResponse], error)
+	// ImportStix ingests a STIX 2.1 bundle. Objects exported by ExportStix keep
+	// their ids and fields, other objects are mapped to entities and relationships
+	// as described in ImportStixRequest. Invalid objects are reported and skipped.
+	ImportStix(ctx context.Context, in *ImportStixRequest, opts ...grpc.CallOption) (*ImportEntitiesResponse, error)
 	UpdateEntity(ctx context.Context, in *UpdateEntityRequest, opts ...grpc.CallOption) (*UpdateEntityResponse, error)
 	DeleteEntity(ctx context.Context, in *DeleteEntityRequest, opts ...grpc.CallOption) (*DeleteEntityResponse, error)
 }
@@ -55,6 +78,16 @@ func (c *entityServiceClient) ListEntitiesFromEvent(ctx context.Context, in *Lis
 	return out, nil
 }
 
+func (c *entityServiceClient) NearbyEvents(ctx context.Context, in *NearbyEventsRequest, opts ...grpc.CallOption) (*NearbyEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NearbyEventsResponse)
+	err := c.cc.Invoke(ctx, EntityService_NearbyEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *entityServiceClient) GetEntity(ctx context.Context, in *GetEntityRequest, opts ...grpc.CallOption) (*GetEntityResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetEntityResponse)
@@ -75,6 +108,49 @@ func (c *entityServiceClient) CreateEntity(ctx context.Context, in *CreateEntity
 	return out, nil
 }
 
+func (c *entityServiceClient) UpsertEntity(ctx context.Context, in *UpsertEntityRequest, opts ...grpc.CallOption) (*UpsertEntityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertEntityResponse)
+	err := c.cc.Invoke(ctx, EntityService_UpsertEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entityServiceClient) BatchCreateEntities(ctx context.Context, in *BatchCreateEntitiesRequest, opts ...grpc.CallOption) (*BatchCreateEntitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchCreateEntitiesResponse)
+	err := c.cc.Invoke(ctx, EntityService_BatchCreateEntities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entityServiceClient) ImportEntities(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportEntitiesRequest, ImportEntitiesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EntityService_ServiceDesc.Streams[0], EntityService_ImportEntities_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportEntitiesRequest, ImportEntitiesResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EntityService_ImportEntitiesClient = grpc.ClientStreamingClient[ImportEntitiesRequest, ImportEntitiesResponse]
+
+func (c *entityServiceClient) ImportStix(ctx context.Context, in *ImportStixRequest, opts ...grpc.CallOption) (*ImportEntitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportEntitiesResponse)
+	err := c.cc.Invoke(ctx, EntityService_ImportStix_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *entityServiceClient) UpdateEntity(ctx context.Context, in *UpdateEntityRequest, opts ...grpc.CallOption) (*UpdateEntityResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateEntityResponse)
@@ -100,8 +176,26 @@ func (c *entityServiceClient) DeleteEntity(ctx context.Context, in *DeleteEntity
 // for forward compatibility.
 type EntityServiceServer interface {
 	ListEntitiesFromEvent(context.Context, *ListEntitiesFromEventRequest) (*ListEntitiesFromEventResponse, error)
+	// NearbyEvents returns the events within a radius of a point, nearest first
+	NearbyEvents(context.Context, *NearbyEventsRequest) (*NearbyEventsResponse, error)
 	GetEntity(context.Context, *GetEntityRequest) (*GetEntityResponse, error)
 	CreateEntity(context.Context, *CreateEntityRequest) (*CreateEntityResponse, error)
+	// UpsertEntity updates the entity matching the natural key of the given
+	// entity, or creates it if there is none. Natural keys are the url of
+	// websites and sources, the name and birth_date of persons and the name and
+	// country attribute of organizations.
+	UpsertEntity(context.Context, *UpsertEntityRequest) (*UpsertEntityResponse, error)
+	// BatchCreateEntities creates entities of one type in a single transaction,
+	// or upserts them by natural key in upsert mode
+	BatchCreateEntities(context.Context, *BatchCreateEntitiesRequest) (*BatchCreateEntitiesResponse, error)
+	// ImportEntities ingests entities and relationships streamed as a JSON Lines
+	// or CSV file. Records are validated one by one and written in batches,
+	// invalid records are reported by line and do not stop the import.
+	ImportEntities(grpc.ClientStreamingServer[ImportEntitiesRequest, ImportEntitiesResponse]) error
+	// ImportStix ingests a STIX 2.1 bundle. Objects exported by ExportStix keep
+	// their ids and fields, other objects are mapped to entities and relationships
+	// as described in ImportStixRequest. Invalid objects are reported and skipped.
+	ImportStix(context.Context, *ImportStixRequest) (*ImportEntitiesResponse, error)
 	UpdateEntity(context.Context, *UpdateEntityRequest) (*UpdateEntityResponse, error)
 	DeleteEntity(context.Context, *DeleteEntityRequest) (*DeleteEntityResponse, error)
 	mustEmbedUnimplementedEntityServiceServer()
@@ -117,12 +211,27 @@ type UnimplementedEntityServiceServer struct{}
 func (UnimplementedEntityServiceServer) ListEntitiesFromEvent(context.Context, *ListEntitiesFromEventRequest) (*ListEntitiesFromEventResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListEntitiesFromEvent not implemented")
 }
+func (UnimplementedEntityServiceServer) NearbyEvents(context.Context, *NearbyEventsRequest) (*NearbyEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method NearbyEvents not implemented")
+}
 func (UnimplementedEntityServiceServer) GetEntity(context.Context, *GetEntityRequest) (*GetEntityResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetEntity not implemented")
 }
 func (UnimplementedEntityServiceServer) CreateEntity(context.Context, *CreateEntityRequest) (*CreateEntityResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateEntity not implemented")
 }
+func (UnimplementedEntityServiceServer) UpsertEntity(context.Context, *UpsertEntityRequest) (*UpsertEntityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpsertEntity not implemented")
+}
+func (UnimplementedEntityServiceServer) BatchCreateEntities(context.Context, *BatchCreateEntitiesRequest) (*BatchCreateEntitiesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchCreateEntities not implemented")
+}
+func (UnimplementedEntityServiceServer) ImportEntities(grpc.ClientStreamingServer[ImportEntitiesRequest, ImportEntitiesResponse]) error {
+	return status.Error(codes.Unimplemented, "method ImportEntities not implemented")
+}
+func (UnimplementedEntityServiceServer) ImportStix(context.Context, *ImportStixRequest) (*ImportEntitiesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportStix not implemented")
+}
 func (UnimplementedEntityServiceServer) UpdateEntity(context.Context, *UpdateEntityRequest) (*UpdateEntityResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateEntity not implemented")
 }
@@ -168,6 +277,24 @@ func _EntityService_ListEntitiesFromEvent_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EntityService_NearbyEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NearbyEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntityServiceServer).NearbyEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntityService_NearbyEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntityServiceServer).NearbyEvents(ctx, req.(*NearbyEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _EntityService_GetEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetEntityRequest)
 	if err := dec(in); err != nil {
@@ -204,6 +331,67 @@ func _EntityService_CreateEntity_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EntityService_UpsertEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntityServiceServer).UpsertEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntityService_UpsertEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntityServiceServer).UpsertEntity(ctx, req.(*UpsertEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntityService_BatchCreateEntities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateEntitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntityServiceServer).BatchCreateEntities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntityService_BatchCreateEntities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntityServiceServer).BatchCreateEntities(ctx, req.(*BatchCreateEntitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntityService_ImportEntities_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EntityServiceServer).ImportEntities(&grpc.GenericServerStream[ImportEntitiesRequest, ImportEntitiesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EntityService_ImportEntitiesServer = grpc.ClientStreamingServer[ImportEntitiesRequest, ImportEntitiesResponse]
+
+func _EntityService_ImportStix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportStixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntityServiceServer).ImportStix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EntityService_ImportStix_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntityServiceServer).ImportStix(ctx, req.(*ImportStixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _EntityService_UpdateEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateEntityRequest)
 	if err := dec(in); err != nil {
@@ -251,6 +439,10 @@ var EntityService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListEntitiesFromEvent",
 			Handler:    _EntityService_ListEntitiesFromEvent_Handler,
 		},
+		{
+			MethodName: "NearbyEvents",
+			Handler:    _EntityService_NearbyEvents_Handler,
+		},
 		{
 			MethodName: "GetEntity",
 			Handler:    _EntityService_GetEntity_Handler,
@@ -259,6 +451,18 @@ var EntityService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateEntity",
 			Handler:    _EntityService_CreateEntity_Handler,
 		},
+		{
+			MethodName: "UpsertEntity",
+			Handler:    _EntityService_UpsertEntity_Handler,
+		},
+		{
+			MethodName: "BatchCreateEntities",
+			Handler:    _EntityService_BatchCreateEntities_Handler,
+		},
+		{
+			MethodName: "ImportStix",
+			Handler:    _EntityService_ImportStix_Handler,
+		},
 		{
 			MethodName: "UpdateEntity",
 			Handler:    _EntityService_UpdateEntity_Handler,
@@ -268,6 +472,12 @@ var EntityService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _EntityService_DeleteEntity_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportEntities",
+			Handler:       _EntityService_ImportEntities_Handler,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "dapi/v1/entity_service.proto",
 }
@@ -0,0 +1,1415 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: dapi/v1/graph_service.proto
+
+package dapi
+
+import (
+	v1 "github.com/omnsight/omniscent-library/gen/model/v1"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FindPathsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entity _id at both ends of the paths
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// Maximum number of relationships in a path
+	MaxDepth int32 `protobuf:"varint,3,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	// Number of paths to return, shortest first. Defaults to 1.
+	K int32 `protobuf:"varint,4,opt,name=k,proto3" json:"k,omitempty"`
+	// One of "outbound", "inbound" or "any" (default)
+	Direction string `protobuf:"bytes,5,opt,name=direction,proto3" json:"direction,omitempty"`
+	// Relation names that paths may or may not follow
+	AllowedRelations []string `protobuf:"bytes,6,rep,name=allowed_relations,json=allowedRelations,proto3" json:"allowed_relations,omitempty"`
+	DeniedRelations  []string `protobuf:"bytes,7,rep,name=denied_relations,json=deniedRelations,proto3" json:"denied_relations,omitempty"`
+	// Weight each relationship by its confidence so that paths over confident
+	// relationships cost less than paths over uncertain ones
+	WeightByConfidence bool `protobuf:"varint,8,opt,name=weight_by_confidence,json=weightByConfidence,proto3" json:"weight_by_confidence,omitempty"`
+	// Only relationships valid at as_of, or overlapping the window, are followed.
+	// Validity is read from the valid_from/valid_to relationship attributes.
+	AsOf        int64 `protobuf:"varint,9,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	WindowStart int64 `protobuf:"varint,10,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd   int64 `protobuf:"varint,11,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	// Relationships below this confidence (0-100) are not followed
+	MinConfidence int32 `protobuf:"varint,12,opt,name=min_confidence,json=minConfidence,proto3" json:"min_confidence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindPathsRequest) Reset() {
+	*x = FindPathsRequest{}
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindPathsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindPathsRequest) ProtoMessage() {}
+
+func (x *FindPathsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindPathsRequest.ProtoReflect.Descriptor instead.
+func (*FindPathsRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_graph_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FindPathsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *FindPathsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *FindPathsRequest) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+func (x *FindPathsRequest) GetK() int32 {
+	if x != nil {
+		return x.K
+	}
+	return 0
+}
+
+func (x *FindPathsRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *FindPathsRequest) GetAllowedRelations() []string {
+	if x != nil {
+		return x.AllowedRelations
+	}
+	return nil
+}
+
+func (x *FindPathsRequest) GetDeniedRelations() []string {
+	if x != nil {
+		return x.DeniedRelations
+	}
+	return nil
+}
+
+func (x *FindPathsRequest) GetWeightByConfidence() bool {
+	if x != nil {
+		return x.WeightByConfidence
+	}
+	return false
+}
+
+func (x *FindPathsRequest) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+func (x *FindPathsRequest) GetWindowStart() int64 {
+	if x != nil {
+		return x.WindowStart
+	}
+	return 0
+}
+
+func (x *FindPathsRequest) GetWindowEnd() int64 {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return 0
+}
+
+func (x *FindPathsRequest) GetMinConfidence() int32 {
+	if x != nil {
+		return x.MinConfidence
+	}
+	return 0
+}
+
+type Path struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entities in path order, from the start entity to the end entity
+	Entities      []*v1.Entity   `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	Relations     []*v1.Relation `protobuf:"bytes,2,rep,name=relations,proto3" json:"relations,omitempty"`
+	Cost          float64        `protobuf:"fixed64,3,opt,name=cost,proto3" json:"cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Path) Reset() {
+	*x = Path{}
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Path) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Path) ProtoMessage() {}
+
+func (x *Path) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Path.ProtoReflect.Descriptor instead.
+func (*Path) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_graph_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Path) GetEntities() []*v1.Entity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *Path) GetRelations() []*v1.Relation {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+func (x *Path) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+type FindPathsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paths         []*Path                `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindPathsResponse) Reset() {
+	*x = FindPathsResponse{}
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindPathsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindPathsResponse) ProtoMessage() {}
+
+func (x *FindPathsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindPathsResponse.ProtoReflect.Descriptor instead.
+func (*FindPathsResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_graph_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FindPathsResponse) GetPaths() []*Path {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+// Relation names a traversal may follow at one hop, empty allows any relation
+type HopFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relations     []string               `protobuf:"bytes,1,rep,name=relations,proto3" json:"relations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HopFilter) Reset() {
+	*x = HopFilter{}
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HopFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HopFilter) ProtoMessage() {}
+
+func (x *HopFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HopFilter.ProtoReflect.Descriptor instead.
+func (*HopFilter) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_graph_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HopFilter) GetRelations() []string {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+type ExpandGraphRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entity _ids to start the expansion from
+	StartNodes []string `protobuf:"bytes,1,rep,name=start_nodes,json=startNodes,proto3" json:"start_nodes,omitempty"`
+	// One of "outbound", "inbound" or "any" (default)
+	Direction string `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"`
+	Depth     int32  `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`
+	// hop_filters[i] applies to the (i+1)-th hop away from the start nodes
+	HopFilters []*HopFilter `protobuf:"bytes,4,rep,name=hop_filters,json=hopFilters,proto3" json:"hop_filters,omitempty"`
+	// Entity types to return, empty returns every type. Start nodes are always returned.
+	VertexTypes []string `protobuf:"bytes,5,rep,name=vertex_types,json=vertexTypes,proto3" json:"vertex_types,omitempty"`
+	// Maximum number of entities to return, closest to the start nodes first
+	NodeBudget int32 `protobuf:"varint,6,opt,name=node_budget,json=nodeBudget,proto3" json:"node_budget,omitempty"`
+	// Relationships below this confidence (0-100) are not traversed
+	MinConfidence int32 `protobuf:"varint,7,opt,name=min_confidence,json=minConfidence,proto3" json:"min_confidence,omitempty"`
+	// How relationship confidences combine along a path: "product" (default) or "min"
+	ConfidenceAggregation string `protobuf:"bytes,8,opt,name=confidence_aggregation,json=confidenceAggregation,proto3" json:"confidence_aggregation,omitempty"`
+	// Only relationships valid at as_of, or overlapping the window, are traversed.
+	// Validity is read from the valid_from/valid_to relationship attributes.
+	AsOf          int64 `protobuf:"varint,9,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	WindowStart   int64 `protobuf:"varint,10,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd     int64 `protobuf:"varint,11,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpandGraphRequest) Reset() {
+	*x = ExpandGraphRequest{}
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpandGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpandGraphRequest) ProtoMessage() {}
+
+func (x *ExpandGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpandGraphRequest.ProtoReflect.Descriptor instead.
+func (*ExpandGraphRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_graph_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExpandGraphRequest) GetStartNodes() []string {
+	if x != nil {
+		return x.StartNodes
+	}
+	return nil
+}
+
+func (x *ExpandGraphRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *ExpandGraphRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *ExpandGraphRequest) GetHopFilters() []*HopFilter {
+	if x != nil {
+		return x.HopFilters
+	}
+	return nil
+}
+
+func (x *ExpandGraphRequest) GetVertexTypes() []string {
+	if x != nil {
+		return x.VertexTypes
+	}
+	return nil
+}
+
+func (x *ExpandGraphRequest) GetNodeBudget() int32 {
+	if x != nil {
+		return x.NodeBudget
+	}
+	return 0
+}
+
+func (x *ExpandGraphRequest) GetMinConfidence() int32 {
+	if x != nil {
+		return x.MinConfidence
+	}
+	return 0
+}
+
+func (x *ExpandGraphRequest) GetConfidenceAggregation() string {
+	if x != nil {
+		return x.ConfidenceAggregation
+	}
+	return ""
+}
+
+func (x *ExpandGraphRequest) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+func (x *ExpandGraphRequest) GetWindowStart() int64 {
+	if x != nil {
+		return x.WindowStart
+	}
+	return 0
+}
+
+func (x *ExpandGraphRequest) GetWindowEnd() int64 {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return 0
+}
+
+type ExpandGraphResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Entities  []*v1.Entity           `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	Relations []*v1.Relation         `protobuf:"bytes,2,rep,name=relations,proto3" json:"relations,omitempty"`
+	// Set when the node budget was exhausted before the expansion completed
+	Truncated bool `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// Best path confidence (0-1) from the start entities, keyed by entity _id
+	PathConfidence map[string]float64 `protobuf:"bytes,4,rep,name=path_confidence,json=pathConfidence,proto3" json:"path_confidence,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExpandGraphResponse) Reset() {
+	*x = ExpandGraphResponse{}
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpandGraphResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpandGraphResponse) ProtoMessage() {}
+
+func (x *ExpandGraphResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpandGraphResponse.ProtoReflect.Descriptor instead.
+func (*ExpandGraphResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_graph_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExpandGraphResponse) GetEntities() []*v1.Entity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *ExpandGraphResponse) GetRelations() []*v1.Relation {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+func (x *ExpandGraphResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *ExpandGraphResponse) GetPathConfidence() map[string]float64 {
+	if x != nil {
+		return x.PathConfidence
+	}
+	return nil
+}
+
+type AnalyzeSubgraphRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entity _ids to build the subgraph from. When empty, the subgraph is built
+	// from the events matching the event filters below.
+	EntityIds   []string `protobuf:"bytes,1,rep,name=entity_ids,json=entityIds,proto3" json:"entity_ids,omitempty"`
+	StartTime   int64    `protobuf:"varint,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime     int64    `protobuf:"varint,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	CountryCode string   `protobuf:"bytes,4,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Tag         string   `protobuf:"bytes,5,opt,name=tag,proto3" json:"tag,omitempty"`
+	// Number of hops to include around the root entities
+	Depth int32 `protobuf:"varint,6,opt,name=depth,proto3" json:"depth,omitempty"`
+	// Maximum number of entities in the subgraph, closest to the roots first
+	NodeBudget int32 `protobuf:"varint,7,opt,name=node_budget,json=nodeBudget,proto3" json:"node_budget,omitempty"`
+	// One of "louvain" (default) or "label_propagation"
+	CommunityAlgorithm string `protobuf:"bytes,8,opt,name=community_algorithm,json=communityAlgorithm,proto3" json:"community_algorithm,omitempty"`
+	// Relationships below this confidence (0-100) are not traversed
+	MinConfidence int32 `protobuf:"varint,9,opt,name=min_confidence,json=minConfidence,proto3" json:"min_confidence,omitempty"`
+	// How relationship confidences combine along a path: "product" (default) or "min"
+	ConfidenceAggregation string `protobuf:"bytes,10,opt,name=confidence_aggregation,json=confidenceAggregation,proto3" json:"confidence_aggregation,omitempty"`
+	// Only relationships valid at as_of, or overlapping the window, are traversed.
+	// Validity is read from the valid_from/valid_to relationship attributes.
+	AsOf          int64 `protobuf:"varint,11,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	WindowStart   int64 `protobuf:"varint,12,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd     int64 `protobuf:"varint,13,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeSubgraphRequest) Reset() {
+	*x = AnalyzeSubgraphRequest{}
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeSubgraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeSubgraphRequest) ProtoMessage() {}
+
+func (x *AnalyzeSubgraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeSubgraphRequest.ProtoReflect.Descriptor instead.
+func (*AnalyzeSubgraphRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_graph_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AnalyzeSubgraphRequest) GetEntityIds() []string {
+	if x != nil {
+		return x.EntityIds
+	}
+	return nil
+}
+
+func (x *AnalyzeSubgraphRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *AnalyzeSubgraphRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *AnalyzeSubgraphRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *AnalyzeSubgraphRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *AnalyzeSubgraphRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *AnalyzeSubgraphRequest) GetNodeBudget() int32 {
+	if x != nil {
+		return x.NodeBudget
+	}
+	return 0
+}
+
+func (x *AnalyzeSubgraphRequest) GetCommunityAlgorithm() string {
+	if x != nil {
+		return x.CommunityAlgorithm
+	}
+	return ""
+}
+
+func (x *AnalyzeSubgraphRequest) GetMinConfidence() int32 {
+	if x != nil {
+		return x.MinConfidence
+	}
+	return 0
+}
+
+func (x *AnalyzeSubgraphRequest) GetConfidenceAggregation() string {
+	if x != nil {
+		return x.ConfidenceAggregation
+	}
+	return ""
+}
+
+func (x *AnalyzeSubgraphRequest) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+func (x *AnalyzeSubgraphRequest) GetWindowStart() int64 {
+	if x != nil {
+		return x.WindowStart
+	}
+	return 0
+}
+
+func (x *AnalyzeSubgraphRequest) GetWindowEnd() int64 {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return 0
+}
+
+type EntityScore struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	EntityId string                 `protobuf:"bytes,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// Number of relationships of the entity within the subgraph
+	Degree int32 `protobuf:"varint,2,opt,name=degree,proto3" json:"degree,omitempty"`
+	// Normalized betweenness centrality, treating relationships as undirected
+	Betweenness   float64 `protobuf:"fixed64,3,opt,name=betweenness,proto3" json:"betweenness,omitempty"`
+	Pagerank      float64 `protobuf:"fixed64,4,opt,name=pagerank,proto3" json:"pagerank,omitempty"`
+	Community     int32   `protobuf:"varint,5,opt,name=community,proto3" json:"community,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityScore) Reset() {
+	*x = EntityScore{}
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityScore) ProtoMessage() {}
+
+func (x *EntityScore) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityScore.ProtoReflect.Descriptor instead.
+func (*EntityScore) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_graph_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EntityScore) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *EntityScore) GetDegree() int32 {
+	if x != nil {
+		return x.Degree
+	}
+	return 0
+}
+
+func (x *EntityScore) GetBetweenness() float64 {
+	if x != nil {
+		return x.Betweenness
+	}
+	return 0
+}
+
+func (x *EntityScore) GetPagerank() float64 {
+	if x != nil {
+		return x.Pagerank
+	}
+	return 0
+}
+
+func (x *EntityScore) GetCommunity() int32 {
+	if x != nil {
+		return x.Community
+	}
+	return 0
+}
+
+type AnalyzeSubgraphResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Entities       []*v1.Entity           `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	Relations      []*v1.Relation         `protobuf:"bytes,2,rep,name=relations,proto3" json:"relations,omitempty"`
+	Scores         []*EntityScore         `protobuf:"bytes,3,rep,name=scores,proto3" json:"scores,omitempty"`
+	CommunityCount int32                  `protobuf:"varint,4,opt,name=community_count,json=communityCount,proto3" json:"community_count,omitempty"`
+	// Modularity of the detected communities
+	Modularity float64 `protobuf:"fixed64,5,opt,name=modularity,proto3" json:"modularity,omitempty"`
+	// Set when the node budget was exhausted before the subgraph was complete
+	Truncated bool `protobuf:"varint,6,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// Best path confidence (0-1) from the start entities, keyed by entity _id
+	PathConfidence map[string]float64 `protobuf:"bytes,7,rep,name=path_confidence,json=pathConfidence,proto3" json:"path_confidence,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AnalyzeSubgraphResponse) Reset() {
+	*x = AnalyzeSubgraphResponse{}
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeSubgraphResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeSubgraphResponse) ProtoMessage() {}
+
+func (x *AnalyzeSubgraphResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeSubgraphResponse.ProtoReflect.Descriptor instead.
+func (*AnalyzeSubgraphResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_graph_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AnalyzeSubgraphResponse) GetEntities() []*v1.Entity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *AnalyzeSubgraphResponse) GetRelations() []*v1.Relation {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+func (x *AnalyzeSubgraphResponse) GetScores() []*EntityScore {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *AnalyzeSubgraphResponse) GetCommunityCount() int32 {
+	if x != nil {
+		return x.CommunityCount
+	}
+	return 0
+}
+
+func (x *AnalyzeSubgraphResponse) GetModularity() float64 {
+	if x != nil {
+		return x.Modularity
+	}
+	return 0
+}
+
+func (x *AnalyzeSubgraphResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *AnalyzeSubgraphResponse) GetPathConfidence() map[string]float64 {
+	if x != nil {
+		return x.PathConfidence
+	}
+	return nil
+}
+
+type ExportGraphRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of "graphml" (default), "gexf" or "cytoscape" (Cytoscape.js JSON)
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	// Entity _ids to build the subgraph from. When empty, the subgraph is built
+	// from start_node or the events matching the event filters below, as in
+	// ListEntitiesFromEvent.
+	RootIds     []string `protobuf:"bytes,2,rep,name=root_ids,json=rootIds,proto3" json:"root_ids,omitempty"`
+	StartNode   string   `protobuf:"bytes,3,opt,name=start_node,json=startNode,proto3" json:"start_node,omitempty"`
+	StartTime   int64    `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime     int64    `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	CountryCode string   `protobuf:"bytes,6,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Tag         string   `protobuf:"bytes,7,opt,name=tag,proto3" json:"tag,omitempty"`
+	// Number of hops to include around the root entities
+	Depth int32 `protobuf:"varint,8,opt,name=depth,proto3" json:"depth,omitempty"`
+	// Maximum number of entities in the subgraph, closest to the roots first
+	NodeBudget int32 `protobuf:"varint,9,opt,name=node_budget,json=nodeBudget,proto3" json:"node_budget,omitempty"`
+	// Relationships below this confidence (0-100) are not traversed
+	MinConfidence int32 `protobuf:"varint,10,opt,name=min_confidence,json=minConfidence,proto3" json:"min_confidence,omitempty"`
+	// Only relationships valid at as_of, or overlapping the window, are traversed.
+	// Validity is read from the valid_from/valid_to relationship attributes.
+	AsOf          int64 `protobuf:"varint,11,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	WindowStart   int64 `protobuf:"varint,12,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd     int64 `protobuf:"varint,13,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportGraphRequest) Reset() {
+	*x = ExportGraphRequest{}
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportGraphRequest) ProtoMessage() {}
+
+func (x *ExportGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportGraphRequest.ProtoReflect.Descriptor instead.
+func (*ExportGraphRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_graph_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExportGraphRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportGraphRequest) GetRootIds() []string {
+	if x != nil {
+		return x.RootIds
+	}
+	return nil
+}
+
+func (x *ExportGraphRequest) GetStartNode() string {
+	if x != nil {
+		return x.StartNode
+	}
+	return ""
+}
+
+func (x *ExportGraphRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *ExportGraphRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *ExportGraphRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *ExportGraphRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ExportGraphRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *ExportGraphRequest) GetNodeBudget() int32 {
+	if x != nil {
+		return x.NodeBudget
+	}
+	return 0
+}
+
+func (x *ExportGraphRequest) GetMinConfidence() int32 {
+	if x != nil {
+		return x.MinConfidence
+	}
+	return 0
+}
+
+func (x *ExportGraphRequest) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+func (x *ExportGraphRequest) GetWindowStart() int64 {
+	if x != nil {
+		return x.WindowStart
+	}
+	return 0
+}
+
+func (x *ExportGraphRequest) GetWindowEnd() int64 {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return 0
+}
+
+// Entities and relationships are exported as follows:
+//
+//	event        -> incident
+//	person       -> identity (identity_class=individual)
+//	organization -> identity (identity_class=organization)
+//	website      -> infrastructure
+//	source       -> external reference of the entities it is related to
+//	relationship -> relationship
+//
+// Fields without a STIX property are kept in x_omnsight_* custom properties.
+type ExportStixRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Same subgraph selection as ExportGraphRequest
+	RootIds       []string `protobuf:"bytes,1,rep,name=root_ids,json=rootIds,proto3" json:"root_ids,omitempty"`
+	StartNode     string   `protobuf:"bytes,2,opt,name=start_node,json=startNode,proto3" json:"start_node,omitempty"`
+	StartTime     int64    `protobuf:"varint,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       int64    `protobuf:"varint,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	CountryCode   string   `protobuf:"bytes,5,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Tag           string   `protobuf:"bytes,6,opt,name=tag,proto3" json:"tag,omitempty"`
+	Depth         int32    `protobuf:"varint,7,opt,name=depth,proto3" json:"depth,omitempty"`
+	NodeBudget    int32    `protobuf:"varint,8,opt,name=node_budget,json=nodeBudget,proto3" json:"node_budget,omitempty"`
+	MinConfidence int32    `protobuf:"varint,9,opt,name=min_confidence,json=minConfidence,proto3" json:"min_confidence,omitempty"`
+	AsOf          int64    `protobuf:"varint,10,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	WindowStart   int64    `protobuf:"varint,11,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd     int64    `protobuf:"varint,12,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportStixRequest) Reset() {
+	*x = ExportStixRequest{}
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportStixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStixRequest) ProtoMessage() {}
+
+func (x *ExportStixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStixRequest.ProtoReflect.Descriptor instead.
+func (*ExportStixRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_graph_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExportStixRequest) GetRootIds() []string {
+	if x != nil {
+		return x.RootIds
+	}
+	return nil
+}
+
+func (x *ExportStixRequest) GetStartNode() string {
+	if x != nil {
+		return x.StartNode
+	}
+	return ""
+}
+
+func (x *ExportStixRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *ExportStixRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *ExportStixRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *ExportStixRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ExportStixRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *ExportStixRequest) GetNodeBudget() int32 {
+	if x != nil {
+		return x.NodeBudget
+	}
+	return 0
+}
+
+func (x *ExportStixRequest) GetMinConfidence() int32 {
+	if x != nil {
+		return x.MinConfidence
+	}
+	return 0
+}
+
+func (x *ExportStixRequest) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+func (x *ExportStixRequest) GetWindowStart() int64 {
+	if x != nil {
+		return x.WindowStart
+	}
+	return 0
+}
+
+func (x *ExportStixRequest) GetWindowEnd() int64 {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return 0
+}
+
+type ExportStixResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// STIX 2.1 bundle, the HTTP response body
+	Bundle *structpb.Struct `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// Whether the node budget cut the subgraph short
+	Truncated     bool `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportStixResponse) Reset() {
+	*x = ExportStixResponse{}
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportStixResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStixResponse) ProtoMessage() {}
+
+func (x *ExportStixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_graph_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStixResponse.ProtoReflect.Descriptor instead.
+func (*ExportStixResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_graph_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ExportStixResponse) GetBundle() *structpb.Struct {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+func (x *ExportStixResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+var File_dapi_v1_graph_service_proto protoreflect.FileDescriptor
+
+const file_dapi_v1_graph_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1bdapi/v1/graph_service.proto\x12\adapi.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x19google/api/httpbody.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x14model/v1/osint.proto\"\x87\x03\n" +
+	"\x10FindPathsRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x1b\n" +
+	"\tmax_depth\x18\x03 \x01(\x05R\bmaxDepth\x12\f\n" +
+	"\x01k\x18\x04 \x01(\x05R\x01k\x12\x1c\n" +
+	"\tdirection\x18\x05 \x01(\tR\tdirection\x12+\n" +
+	"\x11allowed_relations\x18\x06 \x03(\tR\x10allowedRelations\x12)\n" +
+	"\x10denied_relations\x18\a \x03(\tR\x0fdeniedRelations\x120\n" +
+	"\x14weight_by_confidence\x18\b \x01(\bR\x12weightByConfidence\x12\x13\n" +
+	"\x05as_of\x18\t \x01(\x03R\x04asOf\x12!\n" +
+	"\fwindow_start\x18\n" +
+	" \x01(\x03R\vwindowStart\x12\x1d\n" +
+	"\n" +
+	"window_end\x18\v \x01(\x03R\twindowEnd\x12%\n" +
+	"\x0emin_confidence\x18\f \x01(\x05R\rminConfidence\"z\n" +
+	"\x04Path\x12,\n" +
+	"\bentities\x18\x01 \x03(\v2\x10.model.v1.EntityR\bentities\x120\n" +
+	"\trelations\x18\x02 \x03(\v2\x12.model.v1.RelationR\trelations\x12\x12\n" +
+	"\x04cost\x18\x03 \x01(\x01R\x04cost\"8\n" +
+	"\x11FindPathsResponse\x12#\n" +
+	"\x05paths\x18\x01 \x03(\v2\r.dapi.v1.PathR\x05paths\")\n" +
+	"\tHopFilter\x12\x1c\n" +
+	"\trelations\x18\x01 \x03(\tR\trelations\"\x97\x03\n" +
+	"\x12ExpandGraphRequest\x12\x1f\n" +
+	"\vstart_nodes\x18\x01 \x03(\tR\n" +
+	"startNodes\x12\x1c\n" +
+	"\tdirection\x18\x02 \x01(\tR\tdirection\x12\x14\n" +
+	"\x05depth\x18\x03 \x01(\x05R\x05depth\x123\n" +
+	"\vhop_filters\x18\x04 \x03(\v2\x12.dapi.v1.HopFilterR\n" +
+	"hopFilters\x12!\n" +
+	"\fvertex_types\x18\x05 \x03(\tR\vvertexTypes\x12\x1f\n" +
+	"\vnode_budget\x18\x06 \x01(\x05R\n" +
+	"nodeBudget\x12%\n" +
+	"\x0emin_confidence\x18\a \x01(\x05R\rminConfidence\x125\n" +
+	"\x16confidence_aggregation\x18\b \x01(\tR\x15confidenceAggregation\x12\x13\n" +
+	"\x05as_of\x18\t \x01(\x03R\x04asOf\x12!\n" +
+	"\fwindow_start\x18\n" +
+	" \x01(\x03R\vwindowStart\x12\x1d\n" +
+	"\n" +
+	"window_end\x18\v \x01(\x03R\twindowEnd\"\xb1\x02\n" +
+	"\x13ExpandGraphResponse\x12,\n" +
+	"\bentities\x18\x01 \x03(\v2\x10.model.v1.EntityR\bentities\x120\n" +
+	"\trelations\x18\x02 \x03(\v2\x12.model.v1.RelationR\trelations\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\x12Y\n" +
+	"\x0fpath_confidence\x18\x04 \x03(\v20.dapi.v1.ExpandGraphResponse.PathConfidenceEntryR\x0epathConfidence\x1aA\n" +
+	"\x13PathConfidenceEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xc3\x03\n" +
+	"\x16AnalyzeSubgraphRequest\x12\x1d\n" +
+	"\n" +
+	"entity_ids\x18\x01 \x03(\tR\tentityIds\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x03 \x01(\x03R\aendTime\x12!\n" +
+	"\fcountry_code\x18\x04 \x01(\tR\vcountryCode\x12\x10\n" +
+	"\x03tag\x18\x05 \x01(\tR\x03tag\x12\x14\n" +
+	"\x05depth\x18\x06 \x01(\x05R\x05depth\x12\x1f\n" +
+	"\vnode_budget\x18\a \x01(\x05R\n" +
+	"nodeBudget\x12/\n" +
+	"\x13community_algorithm\x18\b \x01(\tR\x12communityAlgorithm\x12%\n" +
+	"\x0emin_confidence\x18\t \x01(\x05R\rminConfidence\x125\n" +
+	"\x16confidence_aggregation\x18\n" +
+	" \x01(\tR\x15confidenceAggregation\x12\x13\n" +
+	"\x05as_of\x18\v \x01(\x03R\x04asOf\x12!\n" +
+	"\fwindow_start\x18\f \x01(\x03R\vwindowStart\x12\x1d\n" +
+	"\n" +
+	"window_end\x18\r \x01(\x03R\twindowEnd\"\x9e\x01\n" +
+	"\vEntityScore\x12\x1b\n" +
+	"\tentity_id\x18\x01 \x01(\tR\bentityId\x12\x16\n" +
+	"\x06degree\x18\x02 \x01(\x05R\x06degree\x12 \n" +
+	"\vbetweenness\x18\x03 \x01(\x01R\vbetweenness\x12\x1a\n" +
+	"\bpagerank\x18\x04 \x01(\x01R\bpagerank\x12\x1c\n" +
+	"\tcommunity\x18\x05 \x01(\x05R\tcommunity\"\xb0\x03\n" +
+	"\x17AnalyzeSubgraphResponse\x12,\n" +
+	"\bentities\x18\x01 \x03(\v2\x10.model.v1.EntityR\bentities\x120\n" +
+	"\trelations\x18\x02 \x03(\v2\x12.model.v1.RelationR\trelations\x12,\n" +
+	"\x06scores\x18\x03 \x03(\v2\x14.dapi.v1.EntityScoreR\x06scores\x12'\n" +
+	"\x0fcommunity_count\x18\x04 \x01(\x05R\x0ecommunityCount\x12\x1e\n" +
+	"\n" +
+	"modularity\x18\x05 \x01(\x01R\n" +
+	"modularity\x12\x1c\n" +
+	"\ttruncated\x18\x06 \x01(\bR\ttruncated\x12]\n" +
+	"\x0fpath_confidence\x18\a \x03(\v24.dapi.v1.AnalyzeSubgraphResponse.PathConfidenceEntryR\x0epathConfidence\x1aA\n" +
+	"\x13PathConfidenceEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\x8a\x03\n" +
+	"\x12ExportGraphRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x19\n" +
+	"\broot_ids\x18\x02 \x03(\tR\arootIds\x12\x1d\n" +
+	"\n" +
+	"start_node\x18\x03 \x01(\tR\tstartNode\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x05 \x01(\x03R\aendTime\x12!\n" +
+	"\fcountry_code\x18\x06 \x01(\tR\vcountryCode\x12\x10\n" +
+	"\x03tag\x18\a \x01(\tR\x03tag\x12\x14\n" +
+	"\x05depth\x18\b \x01(\x05R\x05depth\x12\x1f\n" +
+	"\vnode_budget\x18\t \x01(\x05R\n" +
+	"nodeBudget\x12%\n" +
+	"\x0emin_confidence\x18\n" +
+	" \x01(\x05R\rminConfidence\x12\x13\n" +
+	"\x05as_of\x18\v \x01(\x03R\x04asOf\x12!\n" +
+	"\fwindow_start\x18\f \x01(\x03R\vwindowStart\x12\x1d\n" +
+	"\n" +
+	"window_end\x18\r \x01(\x03R\twindowEnd\"\xf1\x02\n" +
+	"\x11ExportStixRequest\x12\x19\n" +
+	"\broot_ids\x18\x01 \x03(\tR\arootIds\x12\x1d\n" +
+	"\n" +
+	"start_node\x18\x02 \x01(\tR\tstartNode\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x04 \x01(\x03R\aendTime\x12!\n" +
+	"\fcountry_code\x18\x05 \x01(\tR\vcountryCode\x12\x10\n" +
+	"\x03tag\x18\x06 \x01(\tR\x03tag\x12\x14\n" +
+	"\x05depth\x18\a \x01(\x05R\x05depth\x12\x1f\n" +
+	"\vnode_budget\x18\b \x01(\x05R\n" +
+	"nodeBudget\x12%\n" +
+	"\x0emin_confidence\x18\t \x01(\x05R\rminConfidence\x12\x13\n" +
+	"\x05as_of\x18\n" +
+	" \x01(\x03R\x04asOf\x12!\n" +
+	"\fwindow_start\x18\v \x01(\x03R\vwindowStart\x12\x1d\n" +
+	"\n" +
+	"window_end\x18\f \x01(\x03R\twindowEnd\"c\n" +
+	"\x12ExportStixResponse\x12/\n" +
+	"\x06bundle\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06bundle\x12\x1c\n" +
+	"\ttruncated\x18\x02 \x01(\bR\ttruncated2\x8a\x04\n" +
+	"\fGraphService\x12[\n" +
+	"\tFindPaths\x12\x19.dapi.v1.FindPathsRequest\x1a\x1a.dapi.v1.FindPathsResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/graph/paths\x12e\n" +
+	"\vExpandGraph\x12\x1b.dapi.v1.ExpandGraphRequest\x1a\x1c.dapi.v1.ExpandGraphResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/graph/expand\x12r\n" +
+	"\x0fAnalyzeSubgraph\x12\x1f.dapi.v1.AnalyzeSubgraphRequest\x1a .dapi.v1.AnalyzeSubgraphResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/graph/analyze\x12Z\n" +
+	"\vExportGraph\x12\x1b.dapi.v1.ExportGraphRequest\x1a\x14.google.api.HttpBody\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/graph/export\x12f\n" +
+	"\n" +
+	"ExportStix\x12\x1a.dapi.v1.ExportStixRequest\x1a\x1b.dapi.v1.ExportStixResponse\"\x1f\x82\xd3\xe4\x93\x02\x19b\x06bundle\x12\x0f/v1/stix/exportB.Z,github.com/omnsight/omndapi/gen/dapi/v1;dapib\x06proto3"
+
+var (
+	file_dapi_v1_graph_service_proto_rawDescOnce sync.Once
+	file_dapi_v1_graph_service_proto_rawDescData []byte
+)
+
+func file_dapi_v1_graph_service_proto_rawDescGZIP() []byte {
+	file_dapi_v1_graph_service_proto_rawDescOnce.Do(func() {
+		file_dapi_v1_graph_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_dapi_v1_graph_service_proto_rawDesc), len(file_dapi_v1_graph_service_proto_rawDesc)))
+	})
+	return file_dapi_v1_graph_service_proto_rawDescData
+}
+
+var file_dapi_v1_graph_service_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_dapi_v1_graph_service_proto_goTypes = []any{
+	(*FindPathsRequest)(nil),        // 0: dapi.v1.FindPathsRequest
+	(*Path)(nil),                    // 1: dapi.v1.Path
+	(*FindPathsResponse)(nil),       // 2: dapi.v1.FindPathsResponse
+	(*HopFilter)(nil),               // 3: dapi.v1.HopFilter
+	(*ExpandGraphRequest)(nil),      // 4: dapi.v1.ExpandGraphRequest
+	(*ExpandGraphResponse)(nil),     // 5: dapi.v1.ExpandGraphResponse
+	(*AnalyzeSubgraphRequest)(nil),  // 6: dapi.v1.AnalyzeSubgraphRequest
+	(*EntityScore)(nil),             // 7: dapi.v1.EntityScore
+	(*AnalyzeSubgraphResponse)(nil), // 8: dapi.v1.AnalyzeSubgraphResponse
+	(*ExportGraphRequest)(nil),      // 9: dapi.v1.ExportGraphRequest
+	(*ExportStixRequest)(nil),       // 10: dapi.v1.ExportStixRequest
+	(*ExportStixResponse)(nil),      // 11: dapi.v1.ExportStixResponse
+	nil,                             // 12: dapi.v1.ExpandGraphResponse.PathConfidenceEntry
+	nil,                             // 13: dapi.v1.AnalyzeSubgraphResponse.PathConfidenceEntry
+	(*v1.Entity)(nil),               // 14: model.v1.Entity
+	(*v1.Relation)(nil),             // 15: model.v1.Relation
+	(*structpb.Struct)(nil),         // 16: google.protobuf.Struct
+	(*httpbody.HttpBody)(nil),       // 17: google.api.HttpBody
+}
+var file_dapi_v1_graph_service_proto_depIdxs = []int32{
+	14, // 0: dapi.v1.Path.entities:type_name -> model.v1.Entity
+	15, // 1: dapi.v1.Path.relations:type_name -> model.v1.Relation
+	1,  // 2: dapi.v1.FindPathsResponse.paths:type_name -> dapi.v1.Path
+	3,  // 3: dapi.v1.ExpandGraphRequest.hop_filters:type_name -> dapi.v1.HopFilter
+	14, // 4: dapi.v1.ExpandGraphResponse.entities:type_name -> model.v1.Entity
+	15, // 5: dapi.v1.ExpandGraphResponse.relations:type_name -> model.v1.Relation
+	12, // 6: dapi.v1.ExpandGraphResponse.path_confidence:type_name -> dapi.v1.ExpandGraphResponse.PathConfidenceEntry
+	14, // 7: dapi.v1.AnalyzeSubgraphResponse.entities:type_name -> model.v1.Entity
+	15, // 8: dapi.v1.AnalyzeSubgraphResponse.relations:type_name -> model.v1.Relation
+	7,  // 9: dapi.v1.AnalyzeSubgraphResponse.scores:type_name -> dapi.v1.EntityScore
+	13, // 10: dapi.v1.AnalyzeSubgraphResponse.path_confidence:type_name -> dapi.v1.AnalyzeSubgraphResponse.PathConfidenceEntry
+	16, // 11: dapi.v1.ExportStixResponse.bundle:type_name -> google.protobuf.Struct
+	0,  // 12: dapi.v1.GraphService.FindPaths:input_type -> dapi.v1.FindPathsRequest
+	4,  // 13: dapi.v1.GraphService.ExpandGraph:input_type -> dapi.v1.ExpandGraphRequest
+	6,  // 14: dapi.v1.GraphService.AnalyzeSubgraph:input_type -> dapi.v1.AnalyzeSubgraphRequest
+	9,  // 15: dapi.v1.GraphService.ExportGraph:input_type -> dapi.v1.ExportGraphRequest
+	10, // 16: dapi.v1.GraphService.ExportStix:input_type -> dapi.v1.ExportStixRequest
+	2,  // 17: dapi.v1.GraphService.FindPaths:output_type -> dapi.v1.FindPathsResponse
+	5,  // 18: dapi.v1.GraphService.ExpandGraph:output_type -> dapi.v1.ExpandGraphResponse
+	8,  // 19: dapi.v1.GraphService.AnalyzeSubgraph:output_type -> dapi.v1.AnalyzeSubgraphResponse
+	17, // 20: dapi.v1.GraphService.ExportGraph:output_type -> google.api.HttpBody
+	11, // 21: dapi.v1.GraphService.ExportStix:output_type -> dapi.v1.ExportStixResponse
+	17, // [17:22] is the sub-list for method output_type
+	12, // [12:17] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_dapi_v1_graph_service_proto_init() }
+func file_dapi_v1_graph_service_proto_init() {
+	if File_dapi_v1_graph_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dapi_v1_graph_service_proto_rawDesc), len(file_dapi_v1_graph_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_dapi_v1_graph_service_proto_goTypes,
+		DependencyIndexes: file_dapi_v1_graph_service_proto_depIdxs,
+		MessageInfos:      file_dapi_v1_graph_service_proto_msgTypes,
+	}.Build()
+	File_dapi_v1_graph_service_proto = out.File
+	file_dapi_v1_graph_service_proto_goTypes = nil
+	file_dapi_v1_graph_service_proto_depIdxs = nil
+}
@@ -0,0 +1,290 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: dapi/v1/graph_service.proto
+
+package dapi
+
+import (
+	context "context"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GraphService_FindPaths_FullMethodName       = "/dapi.v1.GraphService/FindPaths"
+	GraphService_ExpandGraph_FullMethodName     = "/dapi.v1.GraphService/ExpandGraph"
+	GraphService_AnalyzeSubgraph_FullMethodName = "/dapi.v1.GraphService/AnalyzeSubgraph"
+	GraphService_ExportGraph_FullMethodName     = "/dapi.v1.GraphService/ExportGraph"
+	GraphService_ExportStix_FullMethodName      = "/dapi.v1.GraphService/ExportStix"
+)
+
+// GraphServiceClient is the client API for GraphService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GraphService provides graph queries across entities and relationships
+type GraphServiceClient interface {
+	FindPaths(ctx context.Context, in *FindPathsRequest, opts ...grpc.CallOption) (*FindPathsResponse, error)
+	ExpandGraph(ctx context.Context, in *ExpandGraphRequest, opts ...grpc.CallOption) (*ExpandGraphResponse, error)
+	// AnalyzeSubgraph scores the entities of an investigation subgraph by
+	// centrality and groups them into communities
+	AnalyzeSubgraph(ctx context.Context, in *AnalyzeSubgraphRequest, opts ...grpc.CallOption) (*AnalyzeSubgraphResponse, error)
+	// ExportGraph serializes an investigation subgraph for Gephi or Cytoscape.
+	// The gateway serves it as a file download.
+	ExportGraph(ctx context.Context, in *ExportGraphRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	// ExportStix serializes an investigation subgraph as a STIX 2.1 bundle that
+	// ImportStix reads back without loss.
+	ExportStix(ctx context.Context, in *ExportStixRequest, opts ...grpc.CallOption) (*ExportStixResponse, error)
+}
+
+type graphServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGraphServiceClient(cc grpc.ClientConnInterface) GraphServiceClient {
+	return &graphServiceClient{cc}
+}
+
+func (c *graphServiceClient) FindPaths(ctx context.Context, in *FindPathsRequest, opts ...grpc.CallOption) (*FindPathsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindPathsResponse)
+	err := c.cc.Invoke(ctx, GraphService_FindPaths_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) ExpandGraph(ctx context.Context, in *ExpandGraphRequest, opts ...grpc.CallOption) (*ExpandGraphResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpandGraphResponse)
+	err := c.cc.Invoke(ctx, GraphService_ExpandGraph_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) AnalyzeSubgraph(ctx context.Context, in *AnalyzeSubgraphRequest, opts ...grpc.CallOption) (*AnalyzeSubgraphResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnalyzeSubgraphResponse)
+	err := c.cc.Invoke(ctx, GraphService_AnalyzeSubgraph_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) ExportGraph(ctx context.Context, in *ExportGraphRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, GraphService_ExportGraph_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) ExportStix(ctx context.Context, in *ExportStixRequest, opts ...grpc.CallOption) (*ExportStixResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportStixResponse)
+	err := c.cc.Invoke(ctx, GraphService_ExportStix_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GraphServiceServer is the server API for GraphService service.
+// All implementations must embed UnimplementedGraphServiceServer
+// for forward compatibility.
+//
+// GraphService provides graph queries across entities and relationships
+type GraphServiceServer interface {
+	FindPaths(context.Context, *FindPathsRequest) (*FindPathsResponse, error)
+	ExpandGraph(context.Context, *ExpandGraphRequest) (*ExpandGraphResponse, error)
+	// AnalyzeSubgraph scores the entities of an investigation subgraph by
+	// centrality and groups them into communities
+	AnalyzeSubgraph(context.Context, *AnalyzeSubgraphRequest) (*AnalyzeSubgraphResponse, error)
+	// ExportGraph serializes an investigation subgraph for Gephi or Cytoscape.
+	// The gateway serves it as a file download.
+	ExportGraph(context.Context, *ExportGraphRequest) (*httpbody.HttpBody, error)
+	// ExportStix serializes an investigation subgraph as a STIX 2.1 bundle that
+	// ImportStix reads back without loss.
+	ExportStix(context.Context, *ExportStixRequest) (*ExportStixResponse, error)
+	mustEmbedUnimplementedGraphServiceServer()
+}
+
+// UnimplementedGraphServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGraphServiceServer struct{}
+
+func (UnimplementedGraphServiceServer) FindPaths(context.Context, *FindPathsRequest) (*FindPathsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FindPaths not implemented")
+}
+func (UnimplementedGraphServiceServer) ExpandGraph(context.Context, *ExpandGraphRequest) (*ExpandGraphResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExpandGraph not implemented")
+}
+func (UnimplementedGraphServiceServer) AnalyzeSubgraph(context.Context, *AnalyzeSubgraphRequest) (*AnalyzeSubgraphResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AnalyzeSubgraph not implemented")
+}
+func (UnimplementedGraphServiceServer) ExportGraph(context.Context, *ExportGraphRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportGraph not implemented")
+}
+func (UnimplementedGraphServiceServer) ExportStix(context.Context, *ExportStixRequest) (*ExportStixResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportStix not implemented")
+}
+func (UnimplementedGraphServiceServer) mustEmbedUnimplementedGraphServiceServer() {}
+func (UnimplementedGraphServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeGraphServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GraphServiceServer will
+// result in compilation errors.
+type UnsafeGraphServiceServer interface {
+	mustEmbedUnimplementedGraphServiceServer()
+}
+
+func RegisterGraphServiceServer(s grpc.ServiceRegistrar, srv GraphServiceServer) {
+	// If the following call panics, it indicates UnimplementedGraphServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GraphService_ServiceDesc, srv)
+}
+
+func _GraphService_FindPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindPathsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).FindPaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_FindPaths_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).FindPaths(ctx, req.(*FindPathsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_ExpandGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpandGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).ExpandGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_ExpandGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).ExpandGraph(ctx, req.(*ExpandGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_AnalyzeSubgraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeSubgraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).AnalyzeSubgraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_AnalyzeSubgraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).AnalyzeSubgraph(ctx, req.(*AnalyzeSubgraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_ExportGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).ExportGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_ExportGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).ExportGraph(ctx, req.(*ExportGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_ExportStix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportStixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).ExportStix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GraphService_ExportStix_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).ExportStix(ctx, req.(*ExportStixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GraphService_ServiceDesc is the grpc.ServiceDesc for GraphService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GraphService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dapi.v1.GraphService",
+	HandlerType: (*GraphServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FindPaths",
+			Handler:    _GraphService_FindPaths_Handler,
+		},
+		{
+			MethodName: "ExpandGraph",
+			Handler:    _GraphService_ExpandGraph_Handler,
+		},
+		{
+			MethodName: "AnalyzeSubgraph",
+			Handler:    _GraphService_AnalyzeSubgraph_Handler,
+		},
+		{
+			MethodName: "ExportGraph",
+			Handler:    _GraphService_ExportGraph_Handler,
+		},
+		{
+			MethodName: "ExportStix",
+			Handler:    _GraphService_ExportStix_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapi/v1/graph_service.proto",
+}
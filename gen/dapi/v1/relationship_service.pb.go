@@ -24,28 +24,798 @@ const (
 )
 
 // Relationship messages
+type GetRelationshipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collection    string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelationshipRequest) Reset() {
+	*x = GetRelationshipRequest{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelationshipRequest) ProtoMessage() {}
+
+func (x *GetRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*GetRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetRelationshipRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *GetRelationshipRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetRelationshipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relationship  *v1.Relation           `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelationshipResponse) Reset() {
+	*x = GetRelationshipResponse{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelationshipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelationshipResponse) ProtoMessage() {}
+
+func (x *GetRelationshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelationshipResponse.ProtoReflect.Descriptor instead.
+func (*GetRelationshipResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetRelationshipResponse) GetRelationship() *v1.Relation {
+	if x != nil {
+		return x.Relationship
+	}
+	return nil
+}
+
+// Filters applied to relationships, zero values are ignored
+type RelationshipFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	MinConfidence int32                  `protobuf:"varint,3,opt,name=min_confidence,json=minConfidence,proto3" json:"min_confidence,omitempty"`
+	MaxConfidence int32                  `protobuf:"varint,4,opt,name=max_confidence,json=maxConfidence,proto3" json:"max_confidence,omitempty"`
+	CreatedAfter  int64                  `protobuf:"varint,5,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore int64                  `protobuf:"varint,6,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	// Only relationships valid at as_of, or overlapping the window, are matched.
+	// Validity is read from the valid_from/valid_to relationship attributes.
+	AsOf          int64 `protobuf:"varint,7,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	WindowStart   int64 `protobuf:"varint,8,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd     int64 `protobuf:"varint,9,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelationshipFilter) Reset() {
+	*x = RelationshipFilter{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationshipFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationshipFilter) ProtoMessage() {}
+
+func (x *RelationshipFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationshipFilter.ProtoReflect.Descriptor instead.
+func (*RelationshipFilter) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RelationshipFilter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RelationshipFilter) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *RelationshipFilter) GetMinConfidence() int32 {
+	if x != nil {
+		return x.MinConfidence
+	}
+	return 0
+}
+
+func (x *RelationshipFilter) GetMaxConfidence() int32 {
+	if x != nil {
+		return x.MaxConfidence
+	}
+	return 0
+}
+
+func (x *RelationshipFilter) GetCreatedAfter() int64 {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return 0
+}
+
+func (x *RelationshipFilter) GetCreatedBefore() int64 {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return 0
+}
+
+func (x *RelationshipFilter) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+func (x *RelationshipFilter) GetWindowStart() int64 {
+	if x != nil {
+		return x.WindowStart
+	}
+	return 0
+}
+
+func (x *RelationshipFilter) GetWindowEnd() int64 {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return 0
+}
+
+type ListRelationshipsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entity _id at the _from and _to end of the relationship. At least one is
+	// required and both must match when both are set.
+	From          string              `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string              `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Filter        *RelationshipFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	Offset        int32               `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int32               `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelationshipsRequest) Reset() {
+	*x = ListRelationshipsRequest{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelationshipsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelationshipsRequest) ProtoMessage() {}
+
+func (x *ListRelationshipsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelationshipsRequest.ProtoReflect.Descriptor instead.
+func (*ListRelationshipsRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListRelationshipsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ListRelationshipsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ListRelationshipsRequest) GetFilter() *RelationshipFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListRelationshipsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListRelationshipsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListRelationshipsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relationships []*v1.Relation         `protobuf:"bytes,1,rep,name=relationships,proto3" json:"relationships,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelationshipsResponse) Reset() {
+	*x = ListRelationshipsResponse{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelationshipsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelationshipsResponse) ProtoMessage() {}
+
+func (x *ListRelationshipsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelationshipsResponse.ProtoReflect.Descriptor instead.
+func (*ListRelationshipsResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListRelationshipsResponse) GetRelationships() []*v1.Relation {
+	if x != nil {
+		return x.Relationships
+	}
+	return nil
+}
+
+func (x *ListRelationshipsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type ListNeighborsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	EntityId string                 `protobuf:"bytes,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// One of "outbound", "inbound" or "any" (default)
+	Direction     string              `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"`
+	Filter        *RelationshipFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	Offset        int32               `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int32               `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNeighborsRequest) Reset() {
+	*x = ListNeighborsRequest{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNeighborsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNeighborsRequest) ProtoMessage() {}
+
+func (x *ListNeighborsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNeighborsRequest.ProtoReflect.Descriptor instead.
+func (*ListNeighborsRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListNeighborsRequest) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *ListNeighborsRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *ListNeighborsRequest) GetFilter() *RelationshipFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListNeighborsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListNeighborsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Neighbor struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Relationship *v1.Relation           `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	Entity       *v1.Entity             `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	// "outbound" when the relationship starts at the requested entity, "inbound" otherwise
+	Direction     string `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Neighbor) Reset() {
+	*x = Neighbor{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Neighbor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Neighbor) ProtoMessage() {}
+
+func (x *Neighbor) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Neighbor.ProtoReflect.Descriptor instead.
+func (*Neighbor) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Neighbor) GetRelationship() *v1.Relation {
+	if x != nil {
+		return x.Relationship
+	}
+	return nil
+}
+
+func (x *Neighbor) GetEntity() *v1.Entity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+func (x *Neighbor) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+type ListNeighborsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Neighbors     []*Neighbor            `protobuf:"bytes,1,rep,name=neighbors,proto3" json:"neighbors,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNeighborsResponse) Reset() {
+	*x = ListNeighborsResponse{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNeighborsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNeighborsResponse) ProtoMessage() {}
+
+func (x *ListNeighborsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNeighborsResponse.ProtoReflect.Descriptor instead.
+func (*ListNeighborsResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListNeighborsResponse) GetNeighbors() []*Neighbor {
+	if x != nil {
+		return x.Neighbors
+	}
+	return nil
+}
+
+func (x *ListNeighborsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
 type CreateRelationshipRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Relationship  *v1.Relation           `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	Relationship  *v1.Relation           `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRelationshipRequest) Reset() {
+	*x = CreateRelationshipRequest{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRelationshipRequest) ProtoMessage() {}
+
+func (x *CreateRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*CreateRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateRelationshipRequest) GetRelationship() *v1.Relation {
+	if x != nil {
+		return x.Relationship
+	}
+	return nil
+}
+
+type CreateRelationshipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relationship  *v1.Relation           `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRelationshipResponse) Reset() {
+	*x = CreateRelationshipResponse{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRelationshipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRelationshipResponse) ProtoMessage() {}
+
+func (x *CreateRelationshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRelationshipResponse.ProtoReflect.Descriptor instead.
+func (*CreateRelationshipResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateRelationshipResponse) GetRelationship() *v1.Relation {
+	if x != nil {
+		return x.Relationship
+	}
+	return nil
+}
+
+type UpdateRelationshipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collection    string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Relationship  *v1.Relation           `protobuf:"bytes,3,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRelationshipRequest) Reset() {
+	*x = UpdateRelationshipRequest{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRelationshipRequest) ProtoMessage() {}
+
+func (x *UpdateRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateRelationshipRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *UpdateRelationshipRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *UpdateRelationshipRequest) GetRelationship() *v1.Relation {
+	if x != nil {
+		return x.Relationship
+	}
+	return nil
+}
+
+type UpdateRelationshipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relationship  *v1.Relation           `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRelationshipResponse) Reset() {
+	*x = UpdateRelationshipResponse{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRelationshipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRelationshipResponse) ProtoMessage() {}
+
+func (x *UpdateRelationshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRelationshipResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRelationshipResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateRelationshipResponse) GetRelationship() *v1.Relation {
+	if x != nil {
+		return x.Relationship
+	}
+	return nil
+}
+
+type DeleteRelationshipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collection    string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRelationshipRequest) Reset() {
+	*x = DeleteRelationshipRequest{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRelationshipRequest) ProtoMessage() {}
+
+func (x *DeleteRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteRelationshipRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *DeleteRelationshipRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type DeleteRelationshipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateRelationshipRequest) Reset() {
-	*x = CreateRelationshipRequest{}
-	mi := &file_dapi_v1_relationship_service_proto_msgTypes[0]
+func (x *DeleteRelationshipResponse) Reset() {
+	*x = DeleteRelationshipResponse{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateRelationshipRequest) String() string {
+func (x *DeleteRelationshipResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRelationshipRequest) ProtoMessage() {}
+func (*DeleteRelationshipResponse) ProtoMessage() {}
 
-func (x *CreateRelationshipRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_relationship_service_proto_msgTypes[0]
+func (x *DeleteRelationshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -56,40 +826,158 @@ func (x *CreateRelationshipRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRelationshipRequest.ProtoReflect.Descriptor instead.
-func (*CreateRelationshipRequest) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use DeleteRelationshipResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRelationshipResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *CreateRelationshipRequest) GetRelationship() *v1.Relation {
+// Relation type registry messages
+type RelationType struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Common data
+	// @gotags: json:"_id,omitempty"
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"_id,omitempty"`
+	// @gotags: json:"_key,omitempty"
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"_key,omitempty"`
+	// @gotags: json:"_rev,omitempty"
+	Rev string `protobuf:"bytes,3,opt,name=rev,proto3" json:"_rev,omitempty"`
+	// Main Data
+	// Relation name used in edge collection names, e.g. "works_for"
+	Name string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// Entity types allowed at each end, empty allows any entity type
+	FromTypes []string `protobuf:"bytes,5,rep,name=from_types,json=fromTypes,proto3" json:"from_types,omitempty"`
+	ToTypes   []string `protobuf:"bytes,6,rep,name=to_types,json=toTypes,proto3" json:"to_types,omitempty"`
+	// Undirected relations may connect their entity types in either order
+	Directed bool `protobuf:"varint,7,opt,name=directed,proto3" json:"directed,omitempty"`
+	// Name of the relation read in the opposite direction, e.g. "employs"
+	InverseName   string `protobuf:"bytes,8,opt,name=inverse_name,json=inverseName,proto3" json:"inverse_name,omitempty"`
+	Label         string `protobuf:"bytes,9,opt,name=label,proto3" json:"label,omitempty"`
+	Description   string `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelationType) Reset() {
+	*x = RelationType{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationType) ProtoMessage() {}
+
+func (x *RelationType) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[14]
 	if x != nil {
-		return x.Relationship
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationType.ProtoReflect.Descriptor instead.
+func (*RelationType) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RelationType) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RelationType) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *RelationType) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
+func (x *RelationType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RelationType) GetFromTypes() []string {
+	if x != nil {
+		return x.FromTypes
 	}
 	return nil
 }
 
-type CreateRelationshipResponse struct {
+func (x *RelationType) GetToTypes() []string {
+	if x != nil {
+		return x.ToTypes
+	}
+	return nil
+}
+
+func (x *RelationType) GetDirected() bool {
+	if x != nil {
+		return x.Directed
+	}
+	return false
+}
+
+func (x *RelationType) GetInverseName() string {
+	if x != nil {
+		return x.InverseName
+	}
+	return ""
+}
+
+func (x *RelationType) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *RelationType) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ListRelationTypesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Relationship  *v1.Relation           `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateRelationshipResponse) Reset() {
-	*x = CreateRelationshipResponse{}
-	mi := &file_dapi_v1_relationship_service_proto_msgTypes[1]
+func (x *ListRelationTypesRequest) Reset() {
+	*x = ListRelationTypesRequest{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateRelationshipResponse) String() string {
+func (x *ListRelationTypesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRelationshipResponse) ProtoMessage() {}
+func (*ListRelationTypesRequest) ProtoMessage() {}
 
-func (x *CreateRelationshipResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_relationship_service_proto_msgTypes[1]
+func (x *ListRelationTypesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -100,42 +988,77 @@ func (x *CreateRelationshipResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRelationshipResponse.ProtoReflect.Descriptor instead.
-func (*CreateRelationshipResponse) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ListRelationTypesRequest.ProtoReflect.Descriptor instead.
+func (*ListRelationTypesRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *CreateRelationshipResponse) GetRelationship() *v1.Relation {
+type ListRelationTypesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RelationTypes []*RelationType        `protobuf:"bytes,1,rep,name=relation_types,json=relationTypes,proto3" json:"relation_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelationTypesResponse) Reset() {
+	*x = ListRelationTypesResponse{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelationTypesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelationTypesResponse) ProtoMessage() {}
+
+func (x *ListRelationTypesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[16]
 	if x != nil {
-		return x.Relationship
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelationTypesResponse.ProtoReflect.Descriptor instead.
+func (*ListRelationTypesResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListRelationTypesResponse) GetRelationTypes() []*RelationType {
+	if x != nil {
+		return x.RelationTypes
 	}
 	return nil
 }
 
-type UpdateRelationshipRequest struct {
+type CreateRelationTypeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Collection    string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
-	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	Relationship  *v1.Relation           `protobuf:"bytes,3,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	RelationType  *RelationType          `protobuf:"bytes,1,opt,name=relation_type,json=relationType,proto3" json:"relation_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateRelationshipRequest) Reset() {
-	*x = UpdateRelationshipRequest{}
-	mi := &file_dapi_v1_relationship_service_proto_msgTypes[2]
+func (x *CreateRelationTypeRequest) Reset() {
+	*x = CreateRelationTypeRequest{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateRelationshipRequest) String() string {
+func (x *CreateRelationTypeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateRelationshipRequest) ProtoMessage() {}
+func (*CreateRelationTypeRequest) ProtoMessage() {}
 
-func (x *UpdateRelationshipRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_relationship_service_proto_msgTypes[2]
+func (x *CreateRelationTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -146,54 +1069,85 @@ func (x *UpdateRelationshipRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateRelationshipRequest.ProtoReflect.Descriptor instead.
-func (*UpdateRelationshipRequest) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use CreateRelationTypeRequest.ProtoReflect.Descriptor instead.
+func (*CreateRelationTypeRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *UpdateRelationshipRequest) GetCollection() string {
+func (x *CreateRelationTypeRequest) GetRelationType() *RelationType {
 	if x != nil {
-		return x.Collection
+		return x.RelationType
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateRelationshipRequest) GetKey() string {
+type CreateRelationTypeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RelationType  *RelationType          `protobuf:"bytes,1,opt,name=relation_type,json=relationType,proto3" json:"relation_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRelationTypeResponse) Reset() {
+	*x = CreateRelationTypeResponse{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRelationTypeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRelationTypeResponse) ProtoMessage() {}
+
+func (x *CreateRelationTypeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[18]
 	if x != nil {
-		return x.Key
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateRelationshipRequest) GetRelationship() *v1.Relation {
+// Deprecated: Use CreateRelationTypeResponse.ProtoReflect.Descriptor instead.
+func (*CreateRelationTypeResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateRelationTypeResponse) GetRelationType() *RelationType {
 	if x != nil {
-		return x.Relationship
+		return x.RelationType
 	}
 	return nil
 }
 
-type UpdateRelationshipResponse struct {
+type UpdateRelationTypeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Relationship  *v1.Relation           `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	RelationType  *RelationType          `protobuf:"bytes,2,opt,name=relation_type,json=relationType,proto3" json:"relation_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateRelationshipResponse) Reset() {
-	*x = UpdateRelationshipResponse{}
-	mi := &file_dapi_v1_relationship_service_proto_msgTypes[3]
+func (x *UpdateRelationTypeRequest) Reset() {
+	*x = UpdateRelationTypeRequest{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateRelationshipResponse) String() string {
+func (x *UpdateRelationTypeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateRelationshipResponse) ProtoMessage() {}
+func (*UpdateRelationTypeRequest) ProtoMessage() {}
 
-func (x *UpdateRelationshipResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_relationship_service_proto_msgTypes[3]
+func (x *UpdateRelationTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -204,41 +1158,47 @@ func (x *UpdateRelationshipResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateRelationshipResponse.ProtoReflect.Descriptor instead.
-func (*UpdateRelationshipResponse) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use UpdateRelationTypeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRelationTypeRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *UpdateRelationshipResponse) GetRelationship() *v1.Relation {
+func (x *UpdateRelationTypeRequest) GetName() string {
 	if x != nil {
-		return x.Relationship
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateRelationTypeRequest) GetRelationType() *RelationType {
+	if x != nil {
+		return x.RelationType
 	}
 	return nil
 }
 
-type DeleteRelationshipRequest struct {
+type UpdateRelationTypeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Collection    string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
-	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	RelationType  *RelationType          `protobuf:"bytes,1,opt,name=relation_type,json=relationType,proto3" json:"relation_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteRelationshipRequest) Reset() {
-	*x = DeleteRelationshipRequest{}
-	mi := &file_dapi_v1_relationship_service_proto_msgTypes[4]
+func (x *UpdateRelationTypeResponse) Reset() {
+	*x = UpdateRelationTypeResponse{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteRelationshipRequest) String() string {
+func (x *UpdateRelationTypeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteRelationshipRequest) ProtoMessage() {}
+func (*UpdateRelationTypeResponse) ProtoMessage() {}
 
-func (x *DeleteRelationshipRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_relationship_service_proto_msgTypes[4]
+func (x *UpdateRelationTypeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,46 +1209,83 @@ func (x *DeleteRelationshipRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteRelationshipRequest.ProtoReflect.Descriptor instead.
-func (*DeleteRelationshipRequest) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use UpdateRelationTypeResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRelationTypeResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *DeleteRelationshipRequest) GetCollection() string {
+func (x *UpdateRelationTypeResponse) GetRelationType() *RelationType {
 	if x != nil {
-		return x.Collection
+		return x.RelationType
 	}
-	return ""
+	return nil
 }
 
-func (x *DeleteRelationshipRequest) GetKey() string {
+type DeleteRelationTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRelationTypeRequest) Reset() {
+	*x = DeleteRelationTypeRequest{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRelationTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRelationTypeRequest) ProtoMessage() {}
+
+func (x *DeleteRelationTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[21]
 	if x != nil {
-		return x.Key
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRelationTypeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRelationTypeRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteRelationTypeRequest) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
 
-type DeleteRelationshipResponse struct {
+type DeleteRelationTypeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteRelationshipResponse) Reset() {
-	*x = DeleteRelationshipResponse{}
-	mi := &file_dapi_v1_relationship_service_proto_msgTypes[5]
+func (x *DeleteRelationTypeResponse) Reset() {
+	*x = DeleteRelationTypeResponse{}
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteRelationshipResponse) String() string {
+func (x *DeleteRelationTypeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteRelationshipResponse) ProtoMessage() {}
+func (*DeleteRelationTypeResponse) ProtoMessage() {}
 
-func (x *DeleteRelationshipResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_dapi_v1_relationship_service_proto_msgTypes[5]
+func (x *DeleteRelationTypeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_relationship_service_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -299,16 +1296,58 @@ func (x *DeleteRelationshipResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteRelationshipResponse.ProtoReflect.Descriptor instead.
-func (*DeleteRelationshipResponse) Descriptor() ([]byte, []int) {
-	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use DeleteRelationTypeResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRelationTypeResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_relationship_service_proto_rawDescGZIP(), []int{22}
 }
 
 var File_dapi_v1_relationship_service_proto protoreflect.FileDescriptor
 
 const file_dapi_v1_relationship_service_proto_rawDesc = "" +
 	"\n" +
-	"\"dapi/v1/relationship_service.proto\x12\adapi.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x14model/v1/osint.proto\"S\n" +
+	"\"dapi/v1/relationship_service.proto\x12\adapi.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x14model/v1/osint.proto\"J\n" +
+	"\x16GetRelationshipRequest\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\"Q\n" +
+	"\x17GetRelationshipResponse\x126\n" +
+	"\frelationship\x18\x01 \x01(\v2\x12.model.v1.RelationR\frelationship\"\xaf\x02\n" +
+	"\x12RelationshipFilter\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12%\n" +
+	"\x0emin_confidence\x18\x03 \x01(\x05R\rminConfidence\x12%\n" +
+	"\x0emax_confidence\x18\x04 \x01(\x05R\rmaxConfidence\x12#\n" +
+	"\rcreated_after\x18\x05 \x01(\x03R\fcreatedAfter\x12%\n" +
+	"\x0ecreated_before\x18\x06 \x01(\x03R\rcreatedBefore\x12\x13\n" +
+	"\x05as_of\x18\a \x01(\x03R\x04asOf\x12!\n" +
+	"\fwindow_start\x18\b \x01(\x03R\vwindowStart\x12\x1d\n" +
+	"\n" +
+	"window_end\x18\t \x01(\x03R\twindowEnd\"\xa1\x01\n" +
+	"\x18ListRelationshipsRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x123\n" +
+	"\x06filter\x18\x03 \x01(\v2\x1b.dapi.v1.RelationshipFilterR\x06filter\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"v\n" +
+	"\x19ListRelationshipsResponse\x128\n" +
+	"\rrelationships\x18\x01 \x03(\v2\x12.model.v1.RelationR\rrelationships\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\"\xb4\x01\n" +
+	"\x14ListNeighborsRequest\x12\x1b\n" +
+	"\tentity_id\x18\x01 \x01(\tR\bentityId\x12\x1c\n" +
+	"\tdirection\x18\x02 \x01(\tR\tdirection\x123\n" +
+	"\x06filter\x18\x03 \x01(\v2\x1b.dapi.v1.RelationshipFilterR\x06filter\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"\x8a\x01\n" +
+	"\bNeighbor\x126\n" +
+	"\frelationship\x18\x01 \x01(\v2\x12.model.v1.RelationR\frelationship\x12(\n" +
+	"\x06entity\x18\x02 \x01(\v2\x10.model.v1.EntityR\x06entity\x12\x1c\n" +
+	"\tdirection\x18\x03 \x01(\tR\tdirection\"i\n" +
+	"\x15ListNeighborsResponse\x12/\n" +
+	"\tneighbors\x18\x01 \x03(\v2\x11.dapi.v1.NeighborR\tneighbors\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\"S\n" +
 	"\x19CreateRelationshipRequest\x126\n" +
 	"\frelationship\x18\x01 \x01(\v2\x12.model.v1.RelationR\frelationship\"T\n" +
 	"\x1aCreateRelationshipResponse\x126\n" +
@@ -326,11 +1365,47 @@ const file_dapi_v1_relationship_service_proto_rawDesc = "" +
 	"collection\x18\x01 \x01(\tR\n" +
 	"collection\x12\x10\n" +
 	"\x03key\x18\x02 \x01(\tR\x03key\"\x1c\n" +
-	"\x1aDeleteRelationshipResponse2\xc8\x03\n" +
-	"\x13RelationshipService\x12\x86\x01\n" +
+	"\x1aDeleteRelationshipResponse\"\x87\x02\n" +
+	"\fRelationType\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x10\n" +
+	"\x03rev\x18\x03 \x01(\tR\x03rev\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"from_types\x18\x05 \x03(\tR\tfromTypes\x12\x19\n" +
+	"\bto_types\x18\x06 \x03(\tR\atoTypes\x12\x1a\n" +
+	"\bdirected\x18\a \x01(\bR\bdirected\x12!\n" +
+	"\finverse_name\x18\b \x01(\tR\vinverseName\x12\x14\n" +
+	"\x05label\x18\t \x01(\tR\x05label\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\"\x1a\n" +
+	"\x18ListRelationTypesRequest\"Y\n" +
+	"\x19ListRelationTypesResponse\x12<\n" +
+	"\x0erelation_types\x18\x01 \x03(\v2\x15.dapi.v1.RelationTypeR\rrelationTypes\"W\n" +
+	"\x19CreateRelationTypeRequest\x12:\n" +
+	"\rrelation_type\x18\x01 \x01(\v2\x15.dapi.v1.RelationTypeR\frelationType\"X\n" +
+	"\x1aCreateRelationTypeResponse\x12:\n" +
+	"\rrelation_type\x18\x01 \x01(\v2\x15.dapi.v1.RelationTypeR\frelationType\"k\n" +
+	"\x19UpdateRelationTypeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12:\n" +
+	"\rrelation_type\x18\x02 \x01(\v2\x15.dapi.v1.RelationTypeR\frelationType\"X\n" +
+	"\x1aUpdateRelationTypeResponse\x12:\n" +
+	"\rrelation_type\x18\x01 \x01(\v2\x15.dapi.v1.RelationTypeR\frelationType\"/\n" +
+	"\x19DeleteRelationTypeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x1c\n" +
+	"\x1aDeleteRelationTypeResponse2\xd1\n" +
+	"\n" +
+	"\x13RelationshipService\x12\x82\x01\n" +
+	"\x0fGetRelationship\x12\x1f.dapi.v1.GetRelationshipRequest\x1a .dapi.v1.GetRelationshipResponse\",\x82\xd3\xe4\x93\x02&\x12$/v1/relationships/{collection}/{key}\x12u\n" +
+	"\x11ListRelationships\x12!.dapi.v1.ListRelationshipsRequest\x1a\".dapi.v1.ListRelationshipsResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/relationships\x12s\n" +
+	"\rListNeighbors\x12\x1d.dapi.v1.ListNeighborsRequest\x1a\x1e.dapi.v1.ListNeighborsResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/relationships/neighbors\x12\x86\x01\n" +
 	"\x12CreateRelationship\x12\".dapi.v1.CreateRelationshipRequest\x1a#.dapi.v1.CreateRelationshipResponse\"'\x82\xd3\xe4\x93\x02!:\frelationship\"\x11/v1/relationships\x12\x99\x01\n" +
 	"\x12UpdateRelationship\x12\".dapi.v1.UpdateRelationshipRequest\x1a#.dapi.v1.UpdateRelationshipResponse\":\x82\xd3\xe4\x93\x024:\frelationship\x1a$/v1/relationships/{collection}/{key}\x12\x8b\x01\n" +
-	"\x12DeleteRelationship\x12\".dapi.v1.DeleteRelationshipRequest\x1a#.dapi.v1.DeleteRelationshipResponse\",\x82\xd3\xe4\x93\x02&*$/v1/relationships/{collection}/{key}B.Z,github.com/omnsight/omndapi/gen/dapi/v1;dapib\x06proto3"
+	"\x12DeleteRelationship\x12\".dapi.v1.DeleteRelationshipRequest\x1a#.dapi.v1.DeleteRelationshipResponse\",\x82\xd3\xe4\x93\x02&*$/v1/relationships/{collection}/{key}\x12v\n" +
+	"\x11ListRelationTypes\x12!.dapi.v1.ListRelationTypesRequest\x1a\".dapi.v1.ListRelationTypesResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/relation-types\x12\x88\x01\n" +
+	"\x12CreateRelationType\x12\".dapi.v1.CreateRelationTypeRequest\x1a#.dapi.v1.CreateRelationTypeResponse\")\x82\xd3\xe4\x93\x02#:\rrelation_type\"\x12/v1/relation-types\x12\x8f\x01\n" +
+	"\x12UpdateRelationType\x12\".dapi.v1.UpdateRelationTypeRequest\x1a#.dapi.v1.UpdateRelationTypeResponse\"0\x82\xd3\xe4\x93\x02*:\rrelation_type\x1a\x19/v1/relation-types/{name}\x12\x80\x01\n" +
+	"\x12DeleteRelationType\x12\".dapi.v1.DeleteRelationTypeRequest\x1a#.dapi.v1.DeleteRelationTypeResponse\"!\x82\xd3\xe4\x93\x02\x1b*\x19/v1/relation-types/{name}B.Z,github.com/omnsight/omndapi/gen/dapi/v1;dapib\x06proto3"
 
 var (
 	file_dapi_v1_relationship_service_proto_rawDescOnce sync.Once
@@ -344,32 +1419,76 @@ func file_dapi_v1_relationship_service_proto_rawDescGZIP() []byte {
 	return file_dapi_v1_relationship_service_proto_rawDescData
 }
 
-var file_dapi_v1_relationship_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_dapi_v1_relationship_service_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_dapi_v1_relationship_service_proto_goTypes = []any{
-	(*CreateRelationshipRequest)(nil),  // 0: dapi.v1.CreateRelationshipRequest
-	(*CreateRelationshipResponse)(nil), // 1: dapi.v1.CreateRelationshipResponse
-	(*UpdateRelationshipRequest)(nil),  // 2: dapi.v1.UpdateRelationshipRequest
-	(*UpdateRelationshipResponse)(nil), // 3: dapi.v1.UpdateRelationshipResponse
-	(*DeleteRelationshipRequest)(nil),  // 4: dapi.v1.DeleteRelationshipRequest
-	(*DeleteRelationshipResponse)(nil), // 5: dapi.v1.DeleteRelationshipResponse
-	(*v1.Relation)(nil),                // 6: model.v1.Relation
+	(*GetRelationshipRequest)(nil),     // 0: dapi.v1.GetRelationshipRequest
+	(*GetRelationshipResponse)(nil),    // 1: dapi.v1.GetRelationshipResponse
+	(*RelationshipFilter)(nil),         // 2: dapi.v1.RelationshipFilter
+	(*ListRelationshipsRequest)(nil),   // 3: dapi.v1.ListRelationshipsRequest
+	(*ListRelationshipsResponse)(nil),  // 4: dapi.v1.ListRelationshipsResponse
+	(*ListNeighborsRequest)(nil),       // 5: dapi.v1.ListNeighborsRequest
+	(*Neighbor)(nil),                   // 6: dapi.v1.Neighbor
+	(*ListNeighborsResponse)(nil),      // 7: dapi.v1.ListNeighborsResponse
+	(*CreateRelationshipRequest)(nil),  // 8: dapi.v1.CreateRelationshipRequest
+	(*CreateRelationshipResponse)(nil), // 9: dapi.v1.CreateRelationshipResponse
+	(*UpdateRelationshipRequest)(nil),  // 10: dapi.v1.UpdateRelationshipRequest
+	(*UpdateRelationshipResponse)(nil), // 11: dapi.v1.UpdateRelationshipResponse
+	(*DeleteRelationshipRequest)(nil),  // 12: dapi.v1.DeleteRelationshipRequest
+	(*DeleteRelationshipResponse)(nil), // 13: dapi.v1.DeleteRelationshipResponse
+	(*RelationType)(nil),               // 14: dapi.v1.RelationType
+	(*ListRelationTypesRequest)(nil),   // 15: dapi.v1.ListRelationTypesRequest
+	(*ListRelationTypesResponse)(nil),  // 16: dapi.v1.ListRelationTypesResponse
+	(*CreateRelationTypeRequest)(nil),  // 17: dapi.v1.CreateRelationTypeRequest
+	(*CreateRelationTypeResponse)(nil), // 18: dapi.v1.CreateRelationTypeResponse
+	(*UpdateRelationTypeRequest)(nil),  // 19: dapi.v1.UpdateRelationTypeRequest
+	(*UpdateRelationTypeResponse)(nil), // 20: dapi.v1.UpdateRelationTypeResponse
+	(*DeleteRelationTypeRequest)(nil),  // 21: dapi.v1.DeleteRelationTypeRequest
+	(*DeleteRelationTypeResponse)(nil), // 22: dapi.v1.DeleteRelationTypeResponse
+	(*v1.Relation)(nil),                // 23: model.v1.Relation
+	(*v1.Entity)(nil),                  // 24: model.v1.Entity
 }
 var file_dapi_v1_relationship_service_proto_depIdxs = []int32{
-	6, // 0: dapi.v1.CreateRelationshipRequest.relationship:type_name -> model.v1.Relation
-	6, // 1: dapi.v1.CreateRelationshipResponse.relationship:type_name -> model.v1.Relation
-	6, // 2: dapi.v1.UpdateRelationshipRequest.relationship:type_name -> model.v1.Relation
-	6, // 3: dapi.v1.UpdateRelationshipResponse.relationship:type_name -> model.v1.Relation
-	0, // 4: dapi.v1.RelationshipService.CreateRelationship:input_type -> dapi.v1.CreateRelationshipRequest
-	2, // 5: dapi.v1.RelationshipService.UpdateRelationship:input_type -> dapi.v1.UpdateRelationshipRequest
-	4, // 6: dapi.v1.RelationshipService.DeleteRelationship:input_type -> dapi.v1.DeleteRelationshipRequest
-	1, // 7: dapi.v1.RelationshipService.CreateRelationship:output_type -> dapi.v1.CreateRelationshipResponse
-	3, // 8: dapi.v1.RelationshipService.UpdateRelationship:output_type -> dapi.v1.UpdateRelationshipResponse
-	5, // 9: dapi.v1.RelationshipService.DeleteRelationship:output_type -> dapi.v1.DeleteRelationshipResponse
-	7, // [7:10] is the sub-list for method output_type
-	4, // [4:7] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	23, // 0: dapi.v1.GetRelationshipResponse.relationship:type_name -> model.v1.Relation
+	2,  // 1: dapi.v1.ListRelationshipsRequest.filter:type_name -> dapi.v1.RelationshipFilter
+	23, // 2: dapi.v1.ListRelationshipsResponse.relationships:type_name -> model.v1.Relation
+	2,  // 3: dapi.v1.ListNeighborsRequest.filter:type_name -> dapi.v1.RelationshipFilter
+	23, // 4: dapi.v1.Neighbor.relationship:type_name -> model.v1.Relation
+	24, // 5: dapi.v1.Neighbor.entity:type_name -> model.v1.Entity
+	6,  // 6: dapi.v1.ListNeighborsResponse.neighbors:type_name -> dapi.v1.Neighbor
+	23, // 7: dapi.v1.CreateRelationshipRequest.relationship:type_name -> model.v1.Relation
+	23, // 8: dapi.v1.CreateRelationshipResponse.relationship:type_name -> model.v1.Relation
+	23, // 9: dapi.v1.UpdateRelationshipRequest.relationship:type_name -> model.v1.Relation
+	23, // 10: dapi.v1.UpdateRelationshipResponse.relationship:type_name -> model.v1.Relation
+	14, // 11: dapi.v1.ListRelationTypesResponse.relation_types:type_name -> dapi.v1.RelationType
+	14, // 12: dapi.v1.CreateRelationTypeRequest.relation_type:type_name -> dapi.v1.RelationType
+	14, // 13: dapi.v1.CreateRelationTypeResponse.relation_type:type_name -> dapi.v1.RelationType
+	14, // 14: dapi.v1.UpdateRelationTypeRequest.relation_type:type_name -> dapi.v1.RelationType
+	14, // 15: dapi.v1.UpdateRelationTypeResponse.relation_type:type_name -> dapi.v1.RelationType
+	0,  // 16: dapi.v1.RelationshipService.GetRelationship:input_type -> dapi.v1.GetRelationshipRequest
+	3,  // 17: dapi.v1.RelationshipService.ListRelationships:input_type -> dapi.v1.ListRelationshipsRequest
+	5,  // 18: dapi.v1.RelationshipService.ListNeighbors:input_type -> dapi.v1.ListNeighborsRequest
+	8,  // 19: dapi.v1.RelationshipService.CreateRelationship:input_type -> dapi.v1.CreateRelationshipRequest
+	10, // 20: dapi.v1.RelationshipService.UpdateRelationship:input_type -> dapi.v1.UpdateRelationshipRequest
+	12, // 21: dapi.v1.RelationshipService.DeleteRelationship:input_type -> dapi.v1.DeleteRelationshipRequest
+	15, // 22: dapi.v1.RelationshipService.ListRelationTypes:input_type -> dapi.v1.ListRelationTypesRequest
+	17, // 23: dapi.v1.RelationshipService.CreateRelationType:input_type -> dapi.v1.CreateRelationTypeRequest
+	19, // 24: dapi.v1.RelationshipService.UpdateRelationType:input_type -> dapi.v1.UpdateRelationTypeRequest
+	21, // 25: dapi.v1.RelationshipService.DeleteRelationType:input_type -> dapi.v1.DeleteRelationTypeRequest
+	1,  // 26: dapi.v1.RelationshipService.GetRelationship:output_type -> dapi.v1.GetRelationshipResponse
+	4,  // 27: dapi.v1.RelationshipService.ListRelationships:output_type -> dapi.v1.ListRelationshipsResponse
+	7,  // 28: dapi.v1.RelationshipService.ListNeighbors:output_type -> dapi.v1.ListNeighborsResponse
+	9,  // 29: dapi.v1.RelationshipService.CreateRelationship:output_type -> dapi.v1.CreateRelationshipResponse
+	11, // 30: dapi.v1.RelationshipService.UpdateRelationship:output_type -> dapi.v1.UpdateRelationshipResponse
+	13, // 31: dapi.v1.RelationshipService.DeleteRelationship:output_type -> dapi.v1.DeleteRelationshipResponse
+	16, // 32: dapi.v1.RelationshipService.ListRelationTypes:output_type -> dapi.v1.ListRelationTypesResponse
+	18, // 33: dapi.v1.RelationshipService.CreateRelationType:output_type -> dapi.v1.CreateRelationTypeResponse
+	20, // 34: dapi.v1.RelationshipService.UpdateRelationType:output_type -> dapi.v1.UpdateRelationTypeResponse
+	22, // 35: dapi.v1.RelationshipService.DeleteRelationType:output_type -> dapi.v1.DeleteRelationTypeResponse
+	26, // [26:36] is the sub-list for method output_type
+	16, // [16:26] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_dapi_v1_relationship_service_proto_init() }
@@ -383,7 +1502,7 @@ func file_dapi_v1_relationship_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dapi_v1_relationship_service_proto_rawDesc), len(file_dapi_v1_relationship_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
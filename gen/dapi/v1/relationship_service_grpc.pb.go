@@ -19,9 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
+	RelationshipService_GetRelationship_FullMethodName    = "/dapi.v1.RelationshipService/GetRelationship"
+	RelationshipService_ListRelationships_FullMethodName  = "/dapi.v1.RelationshipService/ListRelationships"
+	RelationshipService_ListNeighbors_FullMethodName      = "/dapi.v1.RelationshipService/ListNeighbors"
 	RelationshipService_CreateRelationship_FullMethodName = "/dapi.v1.RelationshipService/CreateRelationship"
 	RelationshipService_UpdateRelationship_FullMethodName = "/dapi.v1.RelationshipService/UpdateRelationship"
 	RelationshipService_DeleteRelationship_FullMethodName = "/dapi.v1.RelationshipService/DeleteRelationship"
+	RelationshipService_ListRelationTypes_FullMethodName  = "/dapi.v1.RelationshipService/ListRelationTypes"
+	RelationshipService_CreateRelationType_FullMethodName = "/dapi.v1.RelationshipService/CreateRelationType"
+	RelationshipService_UpdateRelationType_FullMethodName = "/dapi.v1.RelationshipService/UpdateRelationType"
+	RelationshipService_DeleteRelationType_FullMethodName = "/dapi.v1.RelationshipService/DeleteRelationType"
 )
 
 // RelationshipServiceClient is the client API for RelationshipService service.
@@ -30,9 +37,21 @@ const (
 //
 // RelationshipService provides operations for managing relationships between entities
 type RelationshipServiceClient interface {
+	GetRelationship(ctx context.Context, in *GetRelationshipRequest, opts ...grpc.CallOption) (*GetRelationshipResponse, error)
+	ListRelationships(ctx context.Context, in *ListRelationshipsRequest, opts ...grpc.CallOption) (*ListRelationshipsResponse, error)
+	ListNeighbors(ctx context.Context, in *ListNeighborsRequest, opts ...grpc.CallOption) (*ListNeighborsResponse, error)
 	CreateRelationship(ctx context.Context, in *CreateRelationshipRequest, opts ...grpc.CallOption) (*CreateRelationshipResponse, error)
+	// Changing from, to or name moves the relationship into the matching edge
+	// collection. Its key is kept but its id changes accordingly.
 	UpdateRelationship(ctx context.Context, in *UpdateRelationshipRequest, opts ...grpc.CallOption) (*UpdateRelationshipResponse, error)
 	DeleteRelationship(ctx context.Context, in *DeleteRelationshipRequest, opts ...grpc.CallOption) (*DeleteRelationshipResponse, error)
+	ListRelationTypes(ctx context.Context, in *ListRelationTypesRequest, opts ...grpc.CallOption) (*ListRelationTypesResponse, error)
+	// Admin only
+	CreateRelationType(ctx context.Context, in *CreateRelationTypeRequest, opts ...grpc.CallOption) (*CreateRelationTypeResponse, error)
+	// Admin only
+	UpdateRelationType(ctx context.Context, in *UpdateRelationTypeRequest, opts ...grpc.CallOption) (*UpdateRelationTypeResponse, error)
+	// Admin only
+	DeleteRelationType(ctx context.Context, in *DeleteRelationTypeRequest, opts ...grpc.CallOption) (*DeleteRelationTypeResponse, error)
 }
 
 type relationshipServiceClient struct {
@@ -43,6 +62,36 @@ func NewRelationshipServiceClient(cc grpc.ClientConnInterface) RelationshipServi
 	return &relationshipServiceClient{cc}
 }
 
+func (c *relationshipServiceClient) GetRelationship(ctx context.Context, in *GetRelationshipRequest, opts ...grpc.CallOption) (*GetRelationshipResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelationshipResponse)
+	err := c.cc.Invoke(ctx, RelationshipService_GetRelationship_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationshipServiceClient) ListRelationships(ctx context.Context, in *ListRelationshipsRequest, opts ...grpc.CallOption) (*ListRelationshipsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRelationshipsResponse)
+	err := c.cc.Invoke(ctx, RelationshipService_ListRelationships_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationshipServiceClient) ListNeighbors(ctx context.Context, in *ListNeighborsRequest, opts ...grpc.CallOption) (*ListNeighborsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNeighborsResponse)
+	err := c.cc.Invoke(ctx, RelationshipService_ListNeighbors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *relationshipServiceClient) CreateRelationship(ctx context.Context, in *CreateRelationshipRequest, opts ...grpc.CallOption) (*CreateRelationshipResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateRelationshipResponse)
@@ -73,15 +122,67 @@ func (c *relationshipServiceClient) DeleteRelationship(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *relationshipServiceClient) ListRelationTypes(ctx context.Context, in *ListRelationTypesRequest, opts ...grpc.CallOption) (*ListRelationTypesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRelationTypesResponse)
+	err := c.cc.Invoke(ctx, RelationshipService_ListRelationTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationshipServiceClient) CreateRelationType(ctx context.Context, in *CreateRelationTypeRequest, opts ...grpc.CallOption) (*CreateRelationTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateRelationTypeResponse)
+	err := c.cc.Invoke(ctx, RelationshipService_CreateRelationType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationshipServiceClient) UpdateRelationType(ctx context.Context, in *UpdateRelationTypeRequest, opts ...grpc.CallOption) (*UpdateRelationTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateRelationTypeResponse)
+	err := c.cc.Invoke(ctx, RelationshipService_UpdateRelationType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *relationshipServiceClient) DeleteRelationType(ctx context.Context, in *DeleteRelationTypeRequest, opts ...grpc.CallOption) (*DeleteRelationTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRelationTypeResponse)
+	err := c.cc.Invoke(ctx, RelationshipService_DeleteRelationType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RelationshipServiceServer is the server API for RelationshipService service.
 // All implementations must embed UnimplementedRelationshipServiceServer
 // for forward compatibility.
 //
 // RelationshipService provides operations for managing relationships between entities
 type RelationshipServiceServer interface {
+	GetRelationship(context.Context, *GetRelationshipRequest) (*GetRelationshipResponse, error)
+	ListRelationships(context.Context, *ListRelationshipsRequest) (*ListRelationshipsResponse, error)
+	ListNeighbors(context.Context, *ListNeighborsRequest) (*ListNeighborsResponse, error)
 	CreateRelationship(context.Context, *CreateRelationshipRequest) (*CreateRelationshipResponse, error)
+	// Changing from, to or name moves the relationship into the matching edge
+	// collection. Its key is kept but its id changes accordingly.
 	UpdateRelationship(context.Context, *UpdateRelationshipRequest) (*UpdateRelationshipResponse, error)
 	DeleteRelationship(context.Context, *DeleteRelationshipRequest) (*DeleteRelationshipResponse, error)
+	ListRelationTypes(context.Context, *ListRelationTypesRequest) (*ListRelationTypesResponse, error)
+	// Admin only
+	CreateRelationType(context.Context, *CreateRelationTypeRequest) (*CreateRelationTypeResponse, error)
+	// Admin only
+	UpdateRelationType(context.Context, *UpdateRelationTypeRequest) (*UpdateRelationTypeResponse, error)
+	// Admin only
+	DeleteRelationType(context.Context, *DeleteRelationTypeRequest) (*DeleteRelationTypeResponse, error)
 	mustEmbedUnimplementedRelationshipServiceServer()
 }
 
@@ -92,6 +193,15 @@ type RelationshipServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedRelationshipServiceServer struct{}
 
+func (UnimplementedRelationshipServiceServer) GetRelationship(context.Context, *GetRelationshipRequest) (*GetRelationshipResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRelationship not implemented")
+}
+func (UnimplementedRelationshipServiceServer) ListRelationships(context.Context, *ListRelationshipsRequest) (*ListRelationshipsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRelationships not implemented")
+}
+func (UnimplementedRelationshipServiceServer) ListNeighbors(context.Context, *ListNeighborsRequest) (*ListNeighborsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListNeighbors not implemented")
+}
 func (UnimplementedRelationshipServiceServer) CreateRelationship(context.Context, *CreateRelationshipRequest) (*CreateRelationshipResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateRelationship not implemented")
 }
@@ -101,6 +211,18 @@ func (UnimplementedRelationshipServiceServer) UpdateRelationship(context.Context
 func (UnimplementedRelationshipServiceServer) DeleteRelationship(context.Context, *DeleteRelationshipRequest) (*DeleteRelationshipResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteRelationship not implemented")
 }
+func (UnimplementedRelationshipServiceServer) ListRelationTypes(context.Context, *ListRelationTypesRequest) (*ListRelationTypesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRelationTypes not implemented")
+}
+func (UnimplementedRelationshipServiceServer) CreateRelationType(context.Context, *CreateRelationTypeRequest) (*CreateRelationTypeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateRelationType not implemented")
+}
+func (UnimplementedRelationshipServiceServer) UpdateRelationType(context.Context, *UpdateRelationTypeRequest) (*UpdateRelationTypeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateRelationType not implemented")
+}
+func (UnimplementedRelationshipServiceServer) DeleteRelationType(context.Context, *DeleteRelationTypeRequest) (*DeleteRelationTypeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteRelationType not implemented")
+}
 func (UnimplementedRelationshipServiceServer) mustEmbedUnimplementedRelationshipServiceServer() {}
 func (UnimplementedRelationshipServiceServer) testEmbeddedByValue()                             {}
 
@@ -122,6 +244,60 @@ func RegisterRelationshipServiceServer(s grpc.ServiceRegistrar, srv Relationship
 	s.RegisterService(&RelationshipService_ServiceDesc, srv)
 }
 
+func _RelationshipService_GetRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).GetRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationshipService_GetRelationship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).GetRelationship(ctx, req.(*GetRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationshipService_ListRelationships_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRelationshipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).ListRelationships(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationshipService_ListRelationships_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).ListRelationships(ctx, req.(*ListRelationshipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationshipService_ListNeighbors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNeighborsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).ListNeighbors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationshipService_ListNeighbors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).ListNeighbors(ctx, req.(*ListNeighborsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RelationshipService_CreateRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateRelationshipRequest)
 	if err := dec(in); err != nil {
@@ -176,6 +352,78 @@ func _RelationshipService_DeleteRelationship_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RelationshipService_ListRelationTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRelationTypesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).ListRelationTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationshipService_ListRelationTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).ListRelationTypes(ctx, req.(*ListRelationTypesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationshipService_CreateRelationType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRelationTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).CreateRelationType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationshipService_CreateRelationType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).CreateRelationType(ctx, req.(*CreateRelationTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationshipService_UpdateRelationType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRelationTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).UpdateRelationType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationshipService_UpdateRelationType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).UpdateRelationType(ctx, req.(*UpdateRelationTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RelationshipService_DeleteRelationType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRelationTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipServiceServer).DeleteRelationType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationshipService_DeleteRelationType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipServiceServer).DeleteRelationType(ctx, req.(*DeleteRelationTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RelationshipService_ServiceDesc is the grpc.ServiceDesc for RelationshipService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -183,6 +431,18 @@ var RelationshipService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "dapi.v1.RelationshipService",
 	HandlerType: (*RelationshipServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRelationship",
+			Handler:    _RelationshipService_GetRelationship_Handler,
+		},
+		{
+			MethodName: "ListRelationships",
+			Handler:    _RelationshipService_ListRelationships_Handler,
+		},
+		{
+			MethodName: "ListNeighbors",
+			Handler:    _RelationshipService_ListNeighbors_Handler,
+		},
 		{
 			MethodName: "CreateRelationship",
 			Handler:    _RelationshipService_CreateRelationship_Handler,
@@ -195,6 +455,22 @@ var RelationshipService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteRelationship",
 			Handler:    _RelationshipService_DeleteRelationship_Handler,
 		},
+		{
+			MethodName: "ListRelationTypes",
+			Handler:    _RelationshipService_ListRelationTypes_Handler,
+		},
+		{
+			MethodName: "CreateRelationType",
+			Handler:    _RelationshipService_CreateRelationType_Handler,
+		},
+		{
+			MethodName: "UpdateRelationType",
+			Handler:    _RelationshipService_UpdateRelationType_Handler,
+		},
+		{
+			MethodName: "DeleteRelationType",
+			Handler:    _RelationshipService_DeleteRelationType_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "dapi/v1/relationship_service.proto",
@@ -0,0 +1,770 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: dapi/v1/resolution_service.proto
+
+package dapi
+
+import (
+	v1 "github.com/omnsight/omniscent-library/gen/model/v1"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A scored pair of entities that may describe the same real world entity
+type DuplicatePair struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Common data
+	// @gotags: json:"_id,omitempty"
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"_id,omitempty"`
+	// @gotags: json:"_key,omitempty"
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"_key,omitempty"`
+	// Main Data
+	EntityType string `protobuf:"bytes,3,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	// Entity _ids of the pair, entity_a sorts before entity_b
+	EntityA string `protobuf:"bytes,4,opt,name=entity_a,json=entityA,proto3" json:"entity_a,omitempty"`
+	EntityB string `protobuf:"bytes,5,opt,name=entity_b,json=entityB,proto3" json:"entity_b,omitempty"`
+	// Combined score between 0 and 1
+	Score float64 `protobuf:"fixed64,6,opt,name=score,proto3" json:"score,omitempty"`
+	// Best similarity between the normalized names and aliases
+	NameScore float64 `protobuf:"fixed64,7,opt,name=name_score,json=nameScore,proto3" json:"name_score,omitempty"`
+	// Cosine similarity of the entity embeddings
+	EmbeddingScore float64 `protobuf:"fixed64,8,opt,name=embedding_score,json=embeddingScore,proto3" json:"embedding_score,omitempty"`
+	// Jaccard similarity of the directly related entities
+	NeighborScore float64 `protobuf:"fixed64,9,opt,name=neighbor_score,json=neighborScore,proto3" json:"neighbor_score,omitempty"`
+	// Time data
+	ScannedAt     int64 `protobuf:"varint,10,opt,name=scanned_at,json=scannedAt,proto3" json:"scanned_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicatePair) Reset() {
+	*x = DuplicatePair{}
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicatePair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicatePair) ProtoMessage() {}
+
+func (x *DuplicatePair) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicatePair.ProtoReflect.Descriptor instead.
+func (*DuplicatePair) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_resolution_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DuplicatePair) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DuplicatePair) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *DuplicatePair) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *DuplicatePair) GetEntityA() string {
+	if x != nil {
+		return x.EntityA
+	}
+	return ""
+}
+
+func (x *DuplicatePair) GetEntityB() string {
+	if x != nil {
+		return x.EntityB
+	}
+	return ""
+}
+
+func (x *DuplicatePair) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *DuplicatePair) GetNameScore() float64 {
+	if x != nil {
+		return x.NameScore
+	}
+	return 0
+}
+
+func (x *DuplicatePair) GetEmbeddingScore() float64 {
+	if x != nil {
+		return x.EmbeddingScore
+	}
+	return 0
+}
+
+func (x *DuplicatePair) GetNeighborScore() float64 {
+	if x != nil {
+		return x.NeighborScore
+	}
+	return 0
+}
+
+func (x *DuplicatePair) GetScannedAt() int64 {
+	if x != nil {
+		return x.ScannedAt
+	}
+	return 0
+}
+
+type DuplicateCluster struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Entities []*v1.Entity           `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	Pairs    []*DuplicatePair       `protobuf:"bytes,2,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	// Highest pair score in the cluster
+	Score         float64 `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicateCluster) Reset() {
+	*x = DuplicateCluster{}
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateCluster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateCluster) ProtoMessage() {}
+
+func (x *DuplicateCluster) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateCluster.ProtoReflect.Descriptor instead.
+func (*DuplicateCluster) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_resolution_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DuplicateCluster) GetEntities() []*v1.Entity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *DuplicateCluster) GetPairs() []*DuplicatePair {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+func (x *DuplicateCluster) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type FindDuplicatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of "person", "organization" or "source"
+	EntityType string `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	// Only return the cluster containing this entity _id
+	EntityId string `protobuf:"bytes,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// Minimum pair score between 0 and 1
+	MinScore float64 `protobuf:"fixed64,3,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+	// Maximum number of clusters to return
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Admin only. Scan the entity type again before answering.
+	Rescan        bool `protobuf:"varint,5,opt,name=rescan,proto3" json:"rescan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindDuplicatesRequest) Reset() {
+	*x = FindDuplicatesRequest{}
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindDuplicatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicatesRequest) ProtoMessage() {}
+
+func (x *FindDuplicatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicatesRequest.ProtoReflect.Descriptor instead.
+func (*FindDuplicatesRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_resolution_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FindDuplicatesRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *FindDuplicatesRequest) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *FindDuplicatesRequest) GetMinScore() float64 {
+	if x != nil {
+		return x.MinScore
+	}
+	return 0
+}
+
+func (x *FindDuplicatesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *FindDuplicatesRequest) GetRescan() bool {
+	if x != nil {
+		return x.Rescan
+	}
+	return false
+}
+
+type FindDuplicatesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Clusters ordered by score, highest first
+	Clusters      []*DuplicateCluster `protobuf:"bytes,1,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindDuplicatesResponse) Reset() {
+	*x = FindDuplicatesResponse{}
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindDuplicatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindDuplicatesResponse) ProtoMessage() {}
+
+func (x *FindDuplicatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindDuplicatesResponse.ProtoReflect.Descriptor instead.
+func (*FindDuplicatesResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_resolution_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FindDuplicatesResponse) GetClusters() []*DuplicateCluster {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+type MergeEntitiesRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EntityType string                 `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	// Key of the entity the others are merged into
+	SurvivorKey string `protobuf:"bytes,2,opt,name=survivor_key,json=survivorKey,proto3" json:"survivor_key,omitempty"`
+	// Keys of the entities merged into the survivor and removed
+	MergedKeys []string `protobuf:"bytes,3,rep,name=merged_keys,json=mergedKeys,proto3" json:"merged_keys,omitempty"`
+	// How conflicting fields are resolved. "survivor" (default) keeps the
+	// survivor's values, "merged" prefers the merged entities in the given order.
+	// Aliases, tags, attributes and ACL lists are always combined.
+	ConflictRule string `protobuf:"bytes,4,opt,name=conflict_rule,json=conflictRule,proto3" json:"conflict_rule,omitempty"`
+	// Pins a field to the value of one entity, keyed by field name with the
+	// key of the entity whose value is kept
+	FieldSources  map[string]string `protobuf:"bytes,5,rep,name=field_sources,json=fieldSources,proto3" json:"field_sources,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeEntitiesRequest) Reset() {
+	*x = MergeEntitiesRequest{}
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeEntitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeEntitiesRequest) ProtoMessage() {}
+
+func (x *MergeEntitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeEntitiesRequest.ProtoReflect.Descriptor instead.
+func (*MergeEntitiesRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_resolution_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MergeEntitiesRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *MergeEntitiesRequest) GetSurvivorKey() string {
+	if x != nil {
+		return x.SurvivorKey
+	}
+	return ""
+}
+
+func (x *MergeEntitiesRequest) GetMergedKeys() []string {
+	if x != nil {
+		return x.MergedKeys
+	}
+	return nil
+}
+
+func (x *MergeEntitiesRequest) GetConflictRule() string {
+	if x != nil {
+		return x.ConflictRule
+	}
+	return ""
+}
+
+func (x *MergeEntitiesRequest) GetFieldSources() map[string]string {
+	if x != nil {
+		return x.FieldSources
+	}
+	return nil
+}
+
+type MergeEntitiesResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Entity *v1.Entity             `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	// Relationships moved over to the survivor
+	RelationshipsRewired int64 `protobuf:"varint,2,opt,name=relationships_rewired,json=relationshipsRewired,proto3" json:"relationships_rewired,omitempty"`
+	// Relationships removed as self references or parallel duplicates
+	RelationshipsRemoved int64 `protobuf:"varint,3,opt,name=relationships_removed,json=relationshipsRemoved,proto3" json:"relationships_removed,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *MergeEntitiesResponse) Reset() {
+	*x = MergeEntitiesResponse{}
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeEntitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeEntitiesResponse) ProtoMessage() {}
+
+func (x *MergeEntitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeEntitiesResponse.ProtoReflect.Descriptor instead.
+func (*MergeEntitiesResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_resolution_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MergeEntitiesResponse) GetEntity() *v1.Entity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+func (x *MergeEntitiesResponse) GetRelationshipsRewired() int64 {
+	if x != nil {
+		return x.RelationshipsRewired
+	}
+	return 0
+}
+
+func (x *MergeEntitiesResponse) GetRelationshipsRemoved() int64 {
+	if x != nil {
+		return x.RelationshipsRemoved
+	}
+	return 0
+}
+
+type SplitEntityRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EntityType string                 `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	// Key of the entity to split
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// Fields copied from the entity to the new entity
+	Fields []string `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
+	// Aliases moved from the entity to the new entity
+	Aliases []string `protobuf:"bytes,4,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// Values of the new entity, applied over the copied fields
+	Entity *v1.Entity `protobuf:"bytes,5,opt,name=entity,proto3" json:"entity,omitempty"`
+	// _ids of the relationships moved from the entity to the new entity
+	RelationshipIds []string `protobuf:"bytes,6,rep,name=relationship_ids,json=relationshipIds,proto3" json:"relationship_ids,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SplitEntityRequest) Reset() {
+	*x = SplitEntityRequest{}
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitEntityRequest) ProtoMessage() {}
+
+func (x *SplitEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitEntityRequest.ProtoReflect.Descriptor instead.
+func (*SplitEntityRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_resolution_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SplitEntityRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *SplitEntityRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SplitEntityRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *SplitEntityRequest) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *SplitEntityRequest) GetEntity() *v1.Entity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+func (x *SplitEntityRequest) GetRelationshipIds() []string {
+	if x != nil {
+		return x.RelationshipIds
+	}
+	return nil
+}
+
+type SplitEntityResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The entity after the split
+	Entity *v1.Entity `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	// The entity created by the split
+	SplitEntity        *v1.Entity `protobuf:"bytes,2,opt,name=split_entity,json=splitEntity,proto3" json:"split_entity,omitempty"`
+	RelationshipsMoved int64      `protobuf:"varint,3,opt,name=relationships_moved,json=relationshipsMoved,proto3" json:"relationships_moved,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SplitEntityResponse) Reset() {
+	*x = SplitEntityResponse{}
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitEntityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitEntityResponse) ProtoMessage() {}
+
+func (x *SplitEntityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_resolution_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitEntityResponse.ProtoReflect.Descriptor instead.
+func (*SplitEntityResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_resolution_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SplitEntityResponse) GetEntity() *v1.Entity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+func (x *SplitEntityResponse) GetSplitEntity() *v1.Entity {
+	if x != nil {
+		return x.SplitEntity
+	}
+	return nil
+}
+
+func (x *SplitEntityResponse) GetRelationshipsMoved() int64 {
+	if x != nil {
+		return x.RelationshipsMoved
+	}
+	return 0
+}
+
+var File_dapi_v1_resolution_service_proto protoreflect.FileDescriptor
+
+const file_dapi_v1_resolution_service_proto_rawDesc = "" +
+	"\n" +
+	" dapi/v1/resolution_service.proto\x12\adapi.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x14model/v1/osint.proto\"\xac\x02\n" +
+	"\rDuplicatePair\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x1f\n" +
+	"\ventity_type\x18\x03 \x01(\tR\n" +
+	"entityType\x12\x19\n" +
+	"\bentity_a\x18\x04 \x01(\tR\aentityA\x12\x19\n" +
+	"\bentity_b\x18\x05 \x01(\tR\aentityB\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x01R\x05score\x12\x1d\n" +
+	"\n" +
+	"name_score\x18\a \x01(\x01R\tnameScore\x12'\n" +
+	"\x0fembedding_score\x18\b \x01(\x01R\x0eembeddingScore\x12%\n" +
+	"\x0eneighbor_score\x18\t \x01(\x01R\rneighborScore\x12\x1d\n" +
+	"\n" +
+	"scanned_at\x18\n" +
+	" \x01(\x03R\tscannedAt\"\x84\x01\n" +
+	"\x10DuplicateCluster\x12,\n" +
+	"\bentities\x18\x01 \x03(\v2\x10.model.v1.EntityR\bentities\x12,\n" +
+	"\x05pairs\x18\x02 \x03(\v2\x16.dapi.v1.DuplicatePairR\x05pairs\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\"\xa0\x01\n" +
+	"\x15FindDuplicatesRequest\x12\x1f\n" +
+	"\ventity_type\x18\x01 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x02 \x01(\tR\bentityId\x12\x1b\n" +
+	"\tmin_score\x18\x03 \x01(\x01R\bminScore\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06rescan\x18\x05 \x01(\bR\x06rescan\"O\n" +
+	"\x16FindDuplicatesResponse\x125\n" +
+	"\bclusters\x18\x01 \x03(\v2\x19.dapi.v1.DuplicateClusterR\bclusters\"\xb7\x02\n" +
+	"\x14MergeEntitiesRequest\x12\x1f\n" +
+	"\ventity_type\x18\x01 \x01(\tR\n" +
+	"entityType\x12!\n" +
+	"\fsurvivor_key\x18\x02 \x01(\tR\vsurvivorKey\x12\x1f\n" +
+	"\vmerged_keys\x18\x03 \x03(\tR\n" +
+	"mergedKeys\x12#\n" +
+	"\rconflict_rule\x18\x04 \x01(\tR\fconflictRule\x12T\n" +
+	"\rfield_sources\x18\x05 \x03(\v2/.dapi.v1.MergeEntitiesRequest.FieldSourcesEntryR\ffieldSources\x1a?\n" +
+	"\x11FieldSourcesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xab\x01\n" +
+	"\x15MergeEntitiesResponse\x12(\n" +
+	"\x06entity\x18\x01 \x01(\v2\x10.model.v1.EntityR\x06entity\x123\n" +
+	"\x15relationships_rewired\x18\x02 \x01(\x03R\x14relationshipsRewired\x123\n" +
+	"\x15relationships_removed\x18\x03 \x01(\x03R\x14relationshipsRemoved\"\xce\x01\n" +
+	"\x12SplitEntityRequest\x12\x1f\n" +
+	"\ventity_type\x18\x01 \x01(\tR\n" +
+	"entityType\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x16\n" +
+	"\x06fields\x18\x03 \x03(\tR\x06fields\x12\x18\n" +
+	"\aaliases\x18\x04 \x03(\tR\aaliases\x12(\n" +
+	"\x06entity\x18\x05 \x01(\v2\x10.model.v1.EntityR\x06entity\x12)\n" +
+	"\x10relationship_ids\x18\x06 \x03(\tR\x0frelationshipIds\"\xa5\x01\n" +
+	"\x13SplitEntityResponse\x12(\n" +
+	"\x06entity\x18\x01 \x01(\v2\x10.model.v1.EntityR\x06entity\x123\n" +
+	"\fsplit_entity\x18\x02 \x01(\v2\x10.model.v1.EntityR\vsplitEntity\x12/\n" +
+	"\x13relationships_moved\x18\x03 \x01(\x03R\x12relationshipsMoved2\xe8\x02\n" +
+	"\x11ResolutionService\x12w\n" +
+	"\x0eFindDuplicates\x12\x1e.dapi.v1.FindDuplicatesRequest\x1a\x1f.dapi.v1.FindDuplicatesResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/resolution/duplicates\x12o\n" +
+	"\rMergeEntities\x12\x1d.dapi.v1.MergeEntitiesRequest\x1a\x1e.dapi.v1.MergeEntitiesResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/resolution/merge\x12i\n" +
+	"\vSplitEntity\x12\x1b.dapi.v1.SplitEntityRequest\x1a\x1c.dapi.v1.SplitEntityResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/resolution/splitB.Z,github.com/omnsight/omndapi/gen/dapi/v1;dapib\x06proto3"
+
+var (
+	file_dapi_v1_resolution_service_proto_rawDescOnce sync.Once
+	file_dapi_v1_resolution_service_proto_rawDescData []byte
+)
+
+func file_dapi_v1_resolution_service_proto_rawDescGZIP() []byte {
+	file_dapi_v1_resolution_service_proto_rawDescOnce.Do(func() {
+		file_dapi_v1_resolution_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_dapi_v1_resolution_service_proto_rawDesc), len(file_dapi_v1_resolution_service_proto_rawDesc)))
+	})
+	return file_dapi_v1_resolution_service_proto_rawDescData
+}
+
+var file_dapi_v1_resolution_service_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_dapi_v1_resolution_service_proto_goTypes = []any{
+	(*DuplicatePair)(nil),          // 0: dapi.v1.DuplicatePair
+	(*DuplicateCluster)(nil),       // 1: dapi.v1.DuplicateCluster
+	(*FindDuplicatesRequest)(nil),  // 2: dapi.v1.FindDuplicatesRequest
+	(*FindDuplicatesResponse)(nil), // 3: dapi.v1.FindDuplicatesResponse
+	(*MergeEntitiesRequest)(nil),   // 4: dapi.v1.MergeEntitiesRequest
+	(*MergeEntitiesResponse)(nil),  // 5: dapi.v1.MergeEntitiesResponse
+	(*SplitEntityRequest)(nil),     // 6: dapi.v1.SplitEntityRequest
+	(*SplitEntityResponse)(nil),    // 7: dapi.v1.SplitEntityResponse
+	nil,                            // 8: dapi.v1.MergeEntitiesRequest.FieldSourcesEntry
+	(*v1.Entity)(nil),              // 9: model.v1.Entity
+}
+var file_dapi_v1_resolution_service_proto_depIdxs = []int32{
+	9,  // 0: dapi.v1.DuplicateCluster.entities:type_name -> model.v1.Entity
+	0,  // 1: dapi.v1.DuplicateCluster.pairs:type_name -> dapi.v1.DuplicatePair
+	1,  // 2: dapi.v1.FindDuplicatesResponse.clusters:type_name -> dapi.v1.DuplicateCluster
+	8,  // 3: dapi.v1.MergeEntitiesRequest.field_sources:type_name -> dapi.v1.MergeEntitiesRequest.FieldSourcesEntry
+	9,  // 4: dapi.v1.MergeEntitiesResponse.entity:type_name -> model.v1.Entity
+	9,  // 5: dapi.v1.SplitEntityRequest.entity:type_name -> model.v1.Entity
+	9,  // 6: dapi.v1.SplitEntityResponse.entity:type_name -> model.v1.Entity
+	9,  // 7: dapi.v1.SplitEntityResponse.split_entity:type_name -> model.v1.Entity
+	2,  // 8: dapi.v1.ResolutionService.FindDuplicates:input_type -> dapi.v1.FindDuplicatesRequest
+	4,  // 9: dapi.v1.ResolutionService.MergeEntities:input_type -> dapi.v1.MergeEntitiesRequest
+	6,  // 10: dapi.v1.ResolutionService.SplitEntity:input_type -> dapi.v1.SplitEntityRequest
+	3,  // 11: dapi.v1.ResolutionService.FindDuplicates:output_type -> dapi.v1.FindDuplicatesResponse
+	5,  // 12: dapi.v1.ResolutionService.MergeEntities:output_type -> dapi.v1.MergeEntitiesResponse
+	7,  // 13: dapi.v1.ResolutionService.SplitEntity:output_type -> dapi.v1.SplitEntityResponse
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_dapi_v1_resolution_service_proto_init() }
+func file_dapi_v1_resolution_service_proto_init() {
+	if File_dapi_v1_resolution_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dapi_v1_resolution_service_proto_rawDesc), len(file_dapi_v1_resolution_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_dapi_v1_resolution_service_proto_goTypes,
+		DependencyIndexes: file_dapi_v1_resolution_service_proto_depIdxs,
+		MessageInfos:      file_dapi_v1_resolution_service_proto_msgTypes,
+	}.Build()
+	File_dapi_v1_resolution_service_proto = out.File
+	file_dapi_v1_resolution_service_proto_goTypes = nil
+	file_dapi_v1_resolution_service_proto_depIdxs = nil
+}
@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: dapi/v1/resolution_service.proto
+
+package dapi
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ResolutionService_FindDuplicates_FullMethodName = "/dapi.v1.ResolutionService/FindDuplicates"
+	ResolutionService_MergeEntities_FullMethodName  = "/dapi.v1.ResolutionService/MergeEntities"
+	ResolutionService_SplitEntity_FullMethodName    = "/dapi.v1.ResolutionService/SplitEntity"
+)
+
+// ResolutionServiceClient is the client API for ResolutionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ResolutionService helps analysts find and resolve duplicate entities
+type ResolutionServiceClient interface {
+	// FindDuplicates returns clusters of likely duplicate entities found by the
+	// background duplicate scan
+	FindDuplicates(ctx context.Context, in *FindDuplicatesRequest, opts ...grpc.CallOption) (*FindDuplicatesResponse, error)
+	// MergeEntities merges entities of the same type into a survivor, moves
+	// their relationships to it and leaves tombstones redirecting their keys
+	MergeEntities(ctx context.Context, in *MergeEntitiesRequest, opts ...grpc.CallOption) (*MergeEntitiesResponse, error)
+	// SplitEntity undoes a wrong merge by creating a new entity from part of an
+	// entity and moving some of its relationships to the new entity
+	SplitEntity(ctx context.Context, in *SplitEntityRequest, opts ...grpc.CallOption) (*SplitEntityResponse, error)
+}
+
+type resolutionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewResolutionServiceClient(cc grpc.ClientConnInterface) ResolutionServiceClient {
+	return &resolutionServiceClient{cc}
+}
+
+func (c *resolutionServiceClient) FindDuplicates(ctx context.Context, in *FindDuplicatesRequest, opts ...grpc.CallOption) (*FindDuplicatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindDuplicatesResponse)
+	err := c.cc.Invoke(ctx, ResolutionService_FindDuplicates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resolutionServiceClient) MergeEntities(ctx context.Context, in *MergeEntitiesRequest, opts ...grpc.CallOption) (*MergeEntitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeEntitiesResponse)
+	err := c.cc.Invoke(ctx, ResolutionService_MergeEntities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resolutionServiceClient) SplitEntity(ctx context.Context, in *SplitEntityRequest, opts ...grpc.CallOption) (*SplitEntityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SplitEntityResponse)
+	err := c.cc.Invoke(ctx, ResolutionService_SplitEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ResolutionServiceServer is the server API for ResolutionService service.
+// All implementations must embed UnimplementedResolutionServiceServer
+// for forward compatibility.
+//
+// ResolutionService helps analysts find and resolve duplicate entities
+type ResolutionServiceServer interface {
+	// FindDuplicates returns clusters of likely duplicate entities found by the
+	// background duplicate scan
+	FindDuplicates(context.Context, *FindDuplicatesRequest) (*FindDuplicatesResponse, error)
+	// MergeEntities merges entities of the same type into a survivor, moves
+	// their relationships to it and leaves tombstones redirecting their keys
+	MergeEntities(context.Context, *MergeEntitiesRequest) (*MergeEntitiesResponse, error)
+	// SplitEntity undoes a wrong merge by creating a new entity from part of an
+	// entity and moving some of its relationships to the new entity
+	SplitEntity(context.Context, *SplitEntityRequest) (*SplitEntityResponse, error)
+	mustEmbedUnimplementedResolutionServiceServer()
+}
+
+// UnimplementedResolutionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedResolutionServiceServer struct{}
+
+func (UnimplementedResolutionServiceServer) FindDuplicates(context.Context, *FindDuplicatesRequest) (*FindDuplicatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FindDuplicates not implemented")
+}
+func (UnimplementedResolutionServiceServer) MergeEntities(context.Context, *MergeEntitiesRequest) (*MergeEntitiesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MergeEntities not implemented")
+}
+func (UnimplementedResolutionServiceServer) SplitEntity(context.Context, *SplitEntityRequest) (*SplitEntityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SplitEntity not implemented")
+}
+func (UnimplementedResolutionServiceServer) mustEmbedUnimplementedResolutionServiceServer() {}
+func (UnimplementedResolutionServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeResolutionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ResolutionServiceServer will
+// result in compilation errors.
+type UnsafeResolutionServiceServer interface {
+	mustEmbedUnimplementedResolutionServiceServer()
+}
+
+func RegisterResolutionServiceServer(s grpc.ServiceRegistrar, srv ResolutionServiceServer) {
+	// If the following call panics, it indicates UnimplementedResolutionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ResolutionService_ServiceDesc, srv)
+}
+
+func _ResolutionService_FindDuplicates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindDuplicatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResolutionServiceServer).FindDuplicates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResolutionService_FindDuplicates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResolutionServiceServer).FindDuplicates(ctx, req.(*FindDuplicatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResolutionService_MergeEntities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeEntitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResolutionServiceServer).MergeEntities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResolutionService_MergeEntities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResolutionServiceServer).MergeEntities(ctx, req.(*MergeEntitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResolutionService_SplitEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SplitEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResolutionServiceServer).SplitEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResolutionService_SplitEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResolutionServiceServer).SplitEntity(ctx, req.(*SplitEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ResolutionService_ServiceDesc is the grpc.ServiceDesc for ResolutionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ResolutionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dapi.v1.ResolutionService",
+	HandlerType: (*ResolutionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FindDuplicates",
+			Handler:    _ResolutionService_FindDuplicates_Handler,
+		},
+		{
+			MethodName: "MergeEntities",
+			Handler:    _ResolutionService_MergeEntities_Handler,
+		},
+		{
+			MethodName: "SplitEntity",
+			Handler:    _ResolutionService_SplitEntity_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapi/v1/resolution_service.proto",
+}
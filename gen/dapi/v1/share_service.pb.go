@@ -0,0 +1,514 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: dapi/v1/share_service.proto
+
+package dapi
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Grant struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Common data
+	// @gotags: json:"_id,omitempty"
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"_id,omitempty"`
+	// @gotags: json:"_key,omitempty"
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"_key,omitempty"`
+	// @gotags: json:"_rev,omitempty"
+	Rev   string `protobuf:"bytes,3,opt,name=rev,proto3" json:"_rev,omitempty"`
+	Owner string `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Main Data
+	// User ID or role receiving read access. Leave empty to create a share link.
+	Principal string `protobuf:"bytes,5,opt,name=principal,proto3" json:"principal,omitempty"`
+	// Entity _id the grant applies to
+	Target string `protobuf:"bytes,6,opt,name=target,proto3" json:"target,omitempty"`
+	// Number of hops around the target covered by the grant, 0 for the target only
+	Depth int32 `protobuf:"varint,7,opt,name=depth,proto3" json:"depth,omitempty"`
+	// Only set on share links, sent back by clients in the x-share-token header
+	Token string `protobuf:"bytes,8,opt,name=token,proto3" json:"token,omitempty"`
+	// Time data
+	CreatedAt     int64 `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     int64 `protobuf:"varint,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Grant) Reset() {
+	*x = Grant{}
+	mi := &file_dapi_v1_share_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Grant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Grant) ProtoMessage() {}
+
+func (x *Grant) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_share_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Grant.ProtoReflect.Descriptor instead.
+func (*Grant) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_share_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Grant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Grant) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Grant) GetRev() string {
+	if x != nil {
+		return x.Rev
+	}
+	return ""
+}
+
+func (x *Grant) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Grant) GetPrincipal() string {
+	if x != nil {
+		return x.Principal
+	}
+	return ""
+}
+
+func (x *Grant) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Grant) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *Grant) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Grant) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Grant) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type CreateGrantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grant         *Grant                 `protobuf:"bytes,1,opt,name=grant,proto3" json:"grant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGrantRequest) Reset() {
+	*x = CreateGrantRequest{}
+	mi := &file_dapi_v1_share_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGrantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGrantRequest) ProtoMessage() {}
+
+func (x *CreateGrantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_share_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGrantRequest.ProtoReflect.Descriptor instead.
+func (*CreateGrantRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_share_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateGrantRequest) GetGrant() *Grant {
+	if x != nil {
+		return x.Grant
+	}
+	return nil
+}
+
+type CreateGrantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grant         *Grant                 `protobuf:"bytes,1,opt,name=grant,proto3" json:"grant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGrantResponse) Reset() {
+	*x = CreateGrantResponse{}
+	mi := &file_dapi_v1_share_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGrantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGrantResponse) ProtoMessage() {}
+
+func (x *CreateGrantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_share_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGrantResponse.ProtoReflect.Descriptor instead.
+func (*CreateGrantResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_share_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateGrantResponse) GetGrant() *Grant {
+	if x != nil {
+		return x.Grant
+	}
+	return nil
+}
+
+type DeleteGrantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGrantRequest) Reset() {
+	*x = DeleteGrantRequest{}
+	mi := &file_dapi_v1_share_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGrantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGrantRequest) ProtoMessage() {}
+
+func (x *DeleteGrantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_share_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGrantRequest.ProtoReflect.Descriptor instead.
+func (*DeleteGrantRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_share_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeleteGrantRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type DeleteGrantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGrantResponse) Reset() {
+	*x = DeleteGrantResponse{}
+	mi := &file_dapi_v1_share_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGrantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGrantResponse) ProtoMessage() {}
+
+func (x *DeleteGrantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_share_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGrantResponse.ProtoReflect.Descriptor instead.
+func (*DeleteGrantResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_share_service_proto_rawDescGZIP(), []int{4}
+}
+
+type ListActiveGrantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Principal     string                 `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActiveGrantsRequest) Reset() {
+	*x = ListActiveGrantsRequest{}
+	mi := &file_dapi_v1_share_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActiveGrantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActiveGrantsRequest) ProtoMessage() {}
+
+func (x *ListActiveGrantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_share_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActiveGrantsRequest.ProtoReflect.Descriptor instead.
+func (*ListActiveGrantsRequest) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_share_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListActiveGrantsRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ListActiveGrantsRequest) GetPrincipal() string {
+	if x != nil {
+		return x.Principal
+	}
+	return ""
+}
+
+type ListActiveGrantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grants        []*Grant               `protobuf:"bytes,1,rep,name=grants,proto3" json:"grants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListActiveGrantsResponse) Reset() {
+	*x = ListActiveGrantsResponse{}
+	mi := &file_dapi_v1_share_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListActiveGrantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListActiveGrantsResponse) ProtoMessage() {}
+
+func (x *ListActiveGrantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dapi_v1_share_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListActiveGrantsResponse.ProtoReflect.Descriptor instead.
+func (*ListActiveGrantsResponse) Descriptor() ([]byte, []int) {
+	return file_dapi_v1_share_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListActiveGrantsResponse) GetGrants() []*Grant {
+	if x != nil {
+		return x.Grants
+	}
+	return nil
+}
+
+var File_dapi_v1_share_service_proto protoreflect.FileDescriptor
+
+const file_dapi_v1_share_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1bdapi/v1/share_service.proto\x12\adapi.v1\x1a\x1cgoogle/api/annotations.proto\"\xf1\x01\n" +
+	"\x05Grant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x10\n" +
+	"\x03rev\x18\x03 \x01(\tR\x03rev\x12\x14\n" +
+	"\x05owner\x18\x04 \x01(\tR\x05owner\x12\x1c\n" +
+	"\tprincipal\x18\x05 \x01(\tR\tprincipal\x12\x16\n" +
+	"\x06target\x18\x06 \x01(\tR\x06target\x12\x14\n" +
+	"\x05depth\x18\a \x01(\x05R\x05depth\x12\x14\n" +
+	"\x05token\x18\b \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\x03R\texpiresAt\":\n" +
+	"\x12CreateGrantRequest\x12$\n" +
+	"\x05grant\x18\x01 \x01(\v2\x0e.dapi.v1.GrantR\x05grant\";\n" +
+	"\x13CreateGrantResponse\x12$\n" +
+	"\x05grant\x18\x01 \x01(\v2\x0e.dapi.v1.GrantR\x05grant\"&\n" +
+	"\x12DeleteGrantRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"\x15\n" +
+	"\x13DeleteGrantResponse\"O\n" +
+	"\x17ListActiveGrantsRequest\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x12\x1c\n" +
+	"\tprincipal\x18\x02 \x01(\tR\tprincipal\"B\n" +
+	"\x18ListActiveGrantsResponse\x12&\n" +
+	"\x06grants\x18\x01 \x03(\v2\x0e.dapi.v1.GrantR\x06grants2\xc4\x02\n" +
+	"\fShareService\x12c\n" +
+	"\vCreateGrant\x12\x1b.dapi.v1.CreateGrantRequest\x1a\x1c.dapi.v1.CreateGrantResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x05grant\"\n" +
+	"/v1/grants\x12b\n" +
+	"\vDeleteGrant\x12\x1b.dapi.v1.DeleteGrantRequest\x1a\x1c.dapi.v1.DeleteGrantResponse\"\x18\x82\xd3\xe4\x93\x02\x12*\x10/v1/grants/{key}\x12k\n" +
+	"\x10ListActiveGrants\x12 .dapi.v1.ListActiveGrantsRequest\x1a!.dapi.v1.ListActiveGrantsResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/grantsB.Z,github.com/omnsight/omndapi/gen/dapi/v1;dapib\x06proto3"
+
+var (
+	file_dapi_v1_share_service_proto_rawDescOnce sync.Once
+	file_dapi_v1_share_service_proto_rawDescData []byte
+)
+
+func file_dapi_v1_share_service_proto_rawDescGZIP() []byte {
+	file_dapi_v1_share_service_proto_rawDescOnce.Do(func() {
+		file_dapi_v1_share_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_dapi_v1_share_service_proto_rawDesc), len(file_dapi_v1_share_service_proto_rawDesc)))
+	})
+	return file_dapi_v1_share_service_proto_rawDescData
+}
+
+var file_dapi_v1_share_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_dapi_v1_share_service_proto_goTypes = []any{
+	(*Grant)(nil),                    // 0: dapi.v1.Grant
+	(*CreateGrantRequest)(nil),       // 1: dapi.v1.CreateGrantRequest
+	(*CreateGrantResponse)(nil),      // 2: dapi.v1.CreateGrantResponse
+	(*DeleteGrantRequest)(nil),       // 3: dapi.v1.DeleteGrantRequest
+	(*DeleteGrantResponse)(nil),      // 4: dapi.v1.DeleteGrantResponse
+	(*ListActiveGrantsRequest)(nil),  // 5: dapi.v1.ListActiveGrantsRequest
+	(*ListActiveGrantsResponse)(nil), // 6: dapi.v1.ListActiveGrantsResponse
+}
+var file_dapi_v1_share_service_proto_depIdxs = []int32{
+	0, // 0: dapi.v1.CreateGrantRequest.grant:type_name -> dapi.v1.Grant
+	0, // 1: dapi.v1.CreateGrantResponse.grant:type_name -> dapi.v1.Grant
+	0, // 2: dapi.v1.ListActiveGrantsResponse.grants:type_name -> dapi.v1.Grant
+	1, // 3: dapi.v1.ShareService.CreateGrant:input_type -> dapi.v1.CreateGrantRequest
+	3, // 4: dapi.v1.ShareService.DeleteGrant:input_type -> dapi.v1.DeleteGrantRequest
+	5, // 5: dapi.v1.ShareService.ListActiveGrants:input_type -> dapi.v1.ListActiveGrantsRequest
+	2, // 6: dapi.v1.ShareService.CreateGrant:output_type -> dapi.v1.CreateGrantResponse
+	4, // 7: dapi.v1.ShareService.DeleteGrant:output_type -> dapi.v1.DeleteGrantResponse
+	6, // 8: dapi.v1.ShareService.ListActiveGrants:output_type -> dapi.v1.ListActiveGrantsResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_dapi_v1_share_service_proto_init() }
+func file_dapi_v1_share_service_proto_init() {
+	if File_dapi_v1_share_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dapi_v1_share_service_proto_rawDesc), len(file_dapi_v1_share_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_dapi_v1_share_service_proto_goTypes,
+		DependencyIndexes: file_dapi_v1_share_service_proto_depIdxs,
+		MessageInfos:      file_dapi_v1_share_service_proto_msgTypes,
+	}.Build()
+	File_dapi_v1_share_service_proto = out.File
+	file_dapi_v1_share_service_proto_goTypes = nil
+	file_dapi_v1_share_service_proto_depIdxs = nil
+}
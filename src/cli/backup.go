@@ -0,0 +1,474 @@
+package cli
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/src/entity_service/collections"
+	"github.com/omnsight/omndapi/src/migrations"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+)
+
+const (
+	// Version of the archive layout, restore refuses newer versions
+	BackupFormatVersion = 1
+
+	backupManifestFile = "manifest.json"
+	// Documents written per import request on restore
+	restoreBatchSize = 1000
+)
+
+// Kinds of the collections of a backup
+const (
+	backupVertexCollection   = "vertex"
+	backupEdgeCollection     = "edge"
+	backupDocumentCollection = "document"
+)
+
+// backupManifest describes a backup archive. It is a zip file holding the
+// manifest and one JSON Lines file per collection.
+type backupManifest struct {
+	Version     int                 `json:"version"`
+	CreatedAt   time.Time           `json:"created_at"`
+	Database    string              `json:"database"`
+	Graph       string              `json:"graph"`
+	Collections []*backupCollection `json:"collections"`
+}
+
+type backupCollection struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	File string `json:"file"`
+	// Number of documents and SHA-256 checksum of the file
+	Count  int64  `json:"count"`
+	SHA256 string `json:"sha256"`
+	// Vertex collections connected by an edge collection of the graph
+	From []string `json:"from,omitempty"`
+	To   []string `json:"to,omitempty"`
+}
+
+// =====================================================
+// Backup
+// =====================================================
+
+// RunBackup writes the collections of the osint graph, the other collections
+// of the database and the graph definition to a compressed archive. The
+// collections are read in one transaction, so the archive is a consistent
+// snapshot.
+func RunBackup(args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	database := databaseFlag(flags)
+	output := flags.String("o", "", "archive to write, defaults to omndapi-DATABASE-TIME.zip")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: omndapi backup [flags]")
+		fmt.Fprintln(flags.Output(), "\nWrites every collection of the database to a zip archive.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := connectDatabase(*database)
+	if err != nil {
+		return err
+	}
+	manifest, err := listBackupCollections(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	path := *output
+	if path == "" {
+		path = fmt.Sprintf("omndapi-%s-%s.zip", manifest.Database, manifest.CreatedAt.Format("20060102T150405Z"))
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	// Remove partial archives
+	written := false
+	defer func() {
+		if !written {
+			file.Close()
+			os.Remove(path)
+		}
+	}()
+
+	archive := zip.NewWriter(file)
+	var names []string
+	for _, collection := range manifest.Collections {
+		names = append(names, collection.Name)
+	}
+	err = pipeline.NewWorker(client).RunReadTransaction(ctx, names, func(ctx context.Context) error {
+		for _, collection := range manifest.Collections {
+			if err := backupCollectionFile(ctx, client, archive, collection); err != nil {
+				return fmt.Errorf("failed to back up %s: %w", collection.Name, err)
+			}
+			fmt.Printf("%s: %d documents\n", collection.Name, collection.Count)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The manifest is written last, once the checksums are known
+	writer, err := archive.Create(backupManifestFile)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return err
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	written = true
+
+	fmt.Printf("Backed up %d collections to %s\n", len(manifest.Collections), path)
+	return nil
+}
+
+// listBackupCollections lists the vertex and edge collections of the osint
+// graph, the other non-system collections of the database and the applied
+// migrations, so a restored database does not migrate its documents again.
+func listBackupCollections(ctx context.Context, client *utils.ArangoDBClient) (*backupManifest, error) {
+	manifest := &backupManifest{
+		Version:   BackupFormatVersion,
+		CreatedAt: time.Now().UTC(),
+		Database:  client.DB.Name(),
+		Graph:     client.OsintGraph.Name(),
+	}
+	add := func(name string, kind string) *backupCollection {
+		collection := &backupCollection{Name: name, Kind: kind, File: "collections/" + name + ".jsonl"}
+		manifest.Collections = append(manifest.Collections, collection)
+		return collection
+	}
+
+	vertexCollections, err := client.OsintGraph.VertexCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, col := range vertexCollections {
+		add(col.Name(), backupVertexCollection)
+	}
+	edgeCollections, constraints, err := client.OsintGraph.EdgeCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i, col := range edgeCollections {
+		collection := add(col.Name(), backupEdgeCollection)
+		collection.From, collection.To = constraints[i].From, constraints[i].To
+	}
+
+	all, err := client.DB.Collections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, col := range all {
+		inGraph := slices.ContainsFunc(manifest.Collections, func(c *backupCollection) bool { return c.Name == col.Name() })
+		if !inGraph && (!strings.HasPrefix(col.Name(), "_") || col.Name() == migrations.Collection) {
+			add(col.Name(), backupDocumentCollection)
+		}
+	}
+	return manifest, nil
+}
+
+// backupCollectionFile writes every document of a collection to the archive,
+// one JSON object per line, and records the count and checksum.
+func backupCollectionFile(ctx context.Context, client *utils.ArangoDBClient, archive *zip.Writer, collection *backupCollection) error {
+	writer, err := archive.Create(collection.File)
+	if err != nil {
+		return err
+	}
+	hash := sha256.New()
+	output := bufio.NewWriter(io.MultiWriter(writer, hash))
+
+	// _id is derived from the collection and _rev is assigned on restore. The
+	// migration lock belongs to the replica holding it.
+	filter := ""
+	bindVars := map[string]interface{}{
+		"@collection": collection.Name,
+	}
+	if collection.Name == migrations.Collection {
+		filter = "FILTER d._key != @lock"
+		bindVars["lock"] = migrations.LockKey
+	}
+	cursor, err := client.DB.Query(ctx, fmt.Sprintf(`FOR d IN @@collection %s RETURN UNSET(d, "_id", "_rev")`, filter), bindVars)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	for {
+		var document json.RawMessage
+		if _, err := cursor.ReadDocument(ctx, &document); driver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return err
+		}
+		output.Write(document)
+		if err := output.WriteByte('\n'); err != nil {
+			return err
+		}
+		collection.Count++
+	}
+	if err := output.Flush(); err != nil {
+		return err
+	}
+	collection.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return nil
+}
+
+// =====================================================
+// Restore
+// =====================================================
+
+// RunRestore restores an archive written by RunBackup into a database
+// without documents. Collections and indexes are created as by the server.
+func RunRestore(args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	database := databaseFlag(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: omndapi restore [flags] ARCHIVE")
+		fmt.Fprintln(flags.Output(), "\nRestores a backup archive into an empty database, which is created if needed.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected exactly one archive to restore")
+	}
+
+	archive, err := zip.OpenReader(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	// =====================================================
+	// Check the archive before touching the database
+	// =====================================================
+	manifest, err := readBackupManifest(&archive.Reader)
+	if err != nil {
+		return err
+	}
+	for _, collection := range manifest.Collections {
+		if err := verifyBackupFile(&archive.Reader, collection); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	client, err := connectDatabase(*database)
+	if err != nil {
+		return err
+	}
+	if manifest.Graph != client.OsintGraph.Name() {
+		return fmt.Errorf("archive holds graph %q, expected %q", manifest.Graph, client.OsintGraph.Name())
+	}
+	for _, collection := range manifest.Collections {
+		// A server started on the empty database may have applied migrations
+		if collection.Name == migrations.Collection {
+			continue
+		}
+		exists, err := client.DB.CollectionExists(ctx, collection.Name)
+		if err != nil || !exists {
+			continue
+		}
+		col, err := client.DB.Collection(ctx, collection.Name)
+		if err != nil {
+			return err
+		}
+		if count, err := col.Count(ctx); err != nil {
+			return err
+		} else if count > 0 {
+			return fmt.Errorf("database %s is not empty: %s holds %d documents", client.DB.Name(), collection.Name, count)
+		}
+	}
+
+	// =====================================================
+	// Create collections and load documents
+	// =====================================================
+	// Schemas are enforced once the documents are loaded, documents written
+	// before a schema existed may not match it
+	loader := pipeline.NewWorker(client)
+	if err := loader.SetSchemaLevel(string(driver.CollectionSchemaLevelNone)); err != nil {
+		return err
+	}
+	if err := collections.RegisterAll(ctx, client, loader); err != nil {
+		return fmt.Errorf("failed to create collections: %w", err)
+	}
+
+	// Edges are loaded once the vertices they connect exist
+	slices.SortStableFunc(manifest.Collections, func(a, b *backupCollection) int {
+		return boolToInt(a.Kind == backupEdgeCollection) - boolToInt(b.Kind == backupEdgeCollection)
+	})
+	for _, collection := range manifest.Collections {
+		var col driver.Collection
+		switch collection.Kind {
+		case backupVertexCollection:
+			col, err = client.GetCreateCollection(ctx, collection.Name, driver.CreateVertexCollectionOptions{})
+		case backupEdgeCollection:
+			col, err = client.GetCreateEdgeCollection(ctx, collection.Name, driver.VertexConstraints{
+				From: collection.From,
+				To:   collection.To,
+			}, driver.CreateEdgeCollectionOptions{})
+		default:
+			var options *driver.CreateCollectionOptions
+			if strings.HasPrefix(collection.Name, "_") {
+				options = &driver.CreateCollectionOptions{IsSystem: true}
+			}
+			col, err = client.GetCreateDocumentCollection(ctx, collection.Name, options)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", collection.Name, err)
+		}
+		// The migrations applied to the documents of the archive replace those
+		// applied to the empty database
+		if collection.Name == migrations.Collection {
+			if _, err := client.DB.Query(ctx, `FOR d IN @@collection FILTER d._key != @lock REMOVE d IN @@collection`, map[string]interface{}{
+				"@collection": collection.Name,
+				"lock":        migrations.LockKey,
+			}); err != nil {
+				return fmt.Errorf("failed to clear %s: %w", collection.Name, err)
+			}
+		}
+		if err := restoreCollectionFile(ctx, &archive.Reader, col, collection); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", collection.Name, err)
+		}
+		fmt.Printf("%s: %d documents\n", collection.Name, collection.Count)
+	}
+
+	worker := pipeline.NewWorker(client)
+	if err := collections.RegisterAll(ctx, client, worker); err != nil {
+		return fmt.Errorf("failed to install schemas: %w", err)
+	}
+	for _, collection := range manifest.Collections {
+		if collection.Kind != backupEdgeCollection {
+			continue
+		}
+		col, _, err := client.OsintGraph.EdgeCollection(ctx, collection.Name)
+		if err != nil {
+			return err
+		}
+		if err := worker.ApplyRelationSchema(ctx, col); err != nil {
+			return fmt.Errorf("failed to install schema on %s: %w", collection.Name, err)
+		}
+	}
+
+	fmt.Printf("Restored %d collections into %s\n", len(manifest.Collections), client.DB.Name())
+	return nil
+}
+
+func readBackupManifest(archive *zip.Reader) (*backupManifest, error) {
+	file, err := archive.Open(backupManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	defer file.Close()
+
+	var manifest backupManifest
+	if err := json.NewDecoder(file).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid backup manifest: %w", err)
+	}
+	if manifest.Version < 1 || manifest.Version > BackupFormatVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", manifest.Version)
+	}
+	return &manifest, nil
+}
+
+// verifyBackupFile checks the checksum and document count of a collection file.
+func verifyBackupFile(archive *zip.Reader, collection *backupCollection) error {
+	file, err := archive.Open(collection.File)
+	if err != nil {
+		return fmt.Errorf("archive is missing %s: %w", collection.File, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	scanner := newBackupScanner(io.TeeReader(file, hash))
+	var count int64
+	for scanner.Scan() {
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != collection.SHA256 || count != collection.Count {
+		return fmt.Errorf("%s is corrupt: checksum or document count does not match the manifest", collection.File)
+	}
+	return nil
+}
+
+func restoreCollectionFile(ctx context.Context, archive *zip.Reader, col driver.Collection, collection *backupCollection) error {
+	file, err := archive.Open(collection.File)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var batch []json.RawMessage
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		_, err := col.ImportDocuments(ctx, batch, &driver.ImportDocumentOptions{
+			OnDuplicate: driver.ImportOnDuplicateError,
+			Complete:    true,
+		})
+		batch = nil
+		return err
+	}
+
+	scanner := newBackupScanner(file)
+	for scanner.Scan() {
+		// The scanner reuses its buffer
+		batch = append(batch, json.RawMessage(slices.Clone(scanner.Bytes())))
+		if len(batch) >= restoreBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// newBackupScanner reads the lines of a collection file, which may hold
+// documents larger than the default token size.
+func newBackupScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	return scanner
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+const testDocuments = `{"_key":"1","name":"a"}
+{"_key":"2","name":"b"}
+`
+
+// testArchive returns a zip archive holding the given files.
+func testArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for name, content := range files {
+		writer, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writer.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func openTestArchive(t *testing.T, data []byte) *zip.Reader {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reader
+}
+
+func testCollection() *backupCollection {
+	sum := sha256.Sum256([]byte(testDocuments))
+	return &backupCollection{
+		Name:   "person",
+		Kind:   backupVertexCollection,
+		File:   "collections/person.jsonl",
+		Count:  2,
+		SHA256: hex.EncodeToString(sum[:]),
+	}
+}
+
+func TestReadBackupManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		wantErr  string
+		wantName string
+	}{
+		{"valid", map[string]string{backupManifestFile: `{"version":1,"graph":"osint","collections":[{"name":"person"}]}`}, "", "person"},
+		{"missing manifest", map[string]string{"collections/person.jsonl": testDocuments}, "not a backup archive", ""},
+		{"truncated manifest", map[string]string{backupManifestFile: `{"version":1,"collections":[`}, "invalid backup manifest", ""},
+		{"newer version", map[string]string{backupManifestFile: `{"version":2}`}, "unsupported backup version 2", ""},
+		{"no version", map[string]string{backupManifestFile: `{}`}, "unsupported backup version 0", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest, err := readBackupManifest(openTestArchive(t, testArchive(t, tt.files)))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if manifest.Graph != "osint" || len(manifest.Collections) != 1 || manifest.Collections[0].Name != tt.wantName {
+				t.Errorf("got manifest %+v", manifest)
+			}
+		})
+	}
+}
+
+func TestVerifyBackupFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		modify  func(collection *backupCollection)
+		wantErr string
+	}{
+		{"valid", testDocuments, nil, ""},
+		{"changed document", strings.Replace(testDocuments, `"b"`, `"c"`, 1), nil, "is corrupt"},
+		{"uncounted document", testDocuments + `{"_key":"3"}` + "\n", func(c *backupCollection) {
+			sum := sha256.Sum256([]byte(testDocuments + `{"_key":"3"}` + "\n"))
+			c.SHA256 = hex.EncodeToString(sum[:])
+		}, "is corrupt"},
+		{"missing file", testDocuments, func(c *backupCollection) { c.File = "collections/event.jsonl" }, "archive is missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collection := testCollection()
+			if tt.modify != nil {
+				tt.modify(collection)
+			}
+			archive := openTestArchive(t, testArchive(t, map[string]string{"collections/person.jsonl": tt.content}))
+			err := verifyBackupFile(archive, collection)
+			if tt.wantErr == "" && err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Damaged bytes in a compressed file fail decompression or the zip checksum.
+func TestVerifyBackupFileCorruptedZip(t *testing.T) {
+	content := strings.Repeat(testDocuments, 100)
+	sum := sha256.Sum256([]byte(content))
+	collection := testCollection()
+	collection.Count, collection.SHA256 = 200, hex.EncodeToString(sum[:])
+
+	data := testArchive(t, map[string]string{"collections/person.jsonl": content})
+	if err := verifyBackupFile(openTestArchive(t, data), collection); err != nil {
+		t.Fatalf("intact archive: %v", err)
+	}
+
+	// The compressed data follows the 30 byte local header and the file name
+	offset := 30 + len(collection.File)
+	for i := offset; i < offset+8; i++ {
+		data[i] ^= 0xff
+	}
+	if err := verifyBackupFile(openTestArchive(t, data), collection); err == nil {
+		t.Error("accepted a corrupted archive")
+	}
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/omnsight/omndapi/src/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// Run runs the subcommand with the given name instead of the server.
+func Run(name string, args []string) error {
+	var err error
+	switch name {
+	case "import":
+		err = RunImport(args)
+	case "backup":
+		err = RunBackup(args)
+	case "restore":
+		err = RunRestore(args)
+	case "migrate":
+		err = RunMigrate(args)
+	case "schema":
+		err = RunSchema(args)
+	default:
+		return fmt.Errorf("unknown command %q", name)
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	return err
+}
+
+// clientFlags registers the flags used to reach a running server.
+func clientFlags(flags *flag.FlagSet) (addr *string, token *string) {
+	addr = flags.String("addr", "localhost:"+os.Getenv(utils.GrpcPort), "gRPC address of the server")
+	token = flags.String("token", os.Getenv(utils.ApiToken), "bearer token of the calling user, defaults to $"+utils.ApiToken)
+	return addr, token
+}
+
+// dial connects to the server and returns a context carrying the token.
+func dial(ctx context.Context, addr string, token string) (*grpc.ClientConn, context.Context, error) {
+	if token == "" {
+		return nil, nil, fmt.Errorf("missing token, set -token or %s", utils.ApiToken)
+	}
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	return conn, metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token), nil
+}
+
+// databaseFlag registers the flag selecting the database, which overrides
+// the database of the server configuration.
+func databaseFlag(flags *flag.FlagSet) *string {
+	return flags.String("database", os.Getenv(utils.ArangoDB), "ArangoDB database, defaults to $"+utils.ArangoDB)
+}
+
+func connectDatabase(database string) (*utils.ArangoDBClient, error) {
+	if database != "" {
+		os.Setenv(utils.ArangoDB, database)
+	}
+	return utils.NewArangoDBClient()
+}
@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+)
+
+// Size of the file chunks streamed to the server
+const importChunkSize = 64 * 1024
+
+// RunImport streams a JSON Lines or CSV file to the ImportEntities RPC of a
+// running server and prints the records that failed.
+func RunImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	addr, token := clientFlags(flags)
+	format := flags.String("format", "", "jsonl or csv, guessed from the file extension by default")
+	entityType := flags.String("type", "", "entity type of the rows of a CSV file")
+	mappingFile := flags.String("mapping", "", `JSON file mapping entity types to {"column": "field path"} objects, for CSV files`)
+	batchSize := flags.Int("batch-size", pipeline.DefaultImportBatchSize, "records written per transaction")
+	upsert := flags.Bool("upsert", false, "update entities matching the natural key instead of creating duplicates")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: omndapi import [flags] FILE")
+		fmt.Fprintln(flags.Output(), "\nImports entities and relationships from FILE, or standard input if FILE is -.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected exactly one file to import")
+	}
+	path := flags.Arg(0)
+
+	// =====================================================
+	// Build import options
+	// =====================================================
+	options := &dapi.ImportOptions{
+		Format:     *format,
+		EntityType: *entityType,
+		BatchSize:  int32(*batchSize),
+		Upsert:     *upsert,
+	}
+	if options.Format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".jsonl", ".ndjson":
+			options.Format = pipeline.ImportFormatJSONL
+		case ".csv":
+			options.Format = pipeline.ImportFormatCSV
+		default:
+			return fmt.Errorf("cannot guess the format of %s, set -format", path)
+		}
+	}
+	if *mappingFile != "" {
+		data, err := os.ReadFile(*mappingFile)
+		if err != nil {
+			return err
+		}
+		var mappings map[string]map[string]string
+		if err := json.Unmarshal(data, &mappings); err != nil {
+			return fmt.Errorf("invalid mapping file: %w", err)
+		}
+		// A mapping file for a single entity type implies the type
+		if options.EntityType == "" && len(mappings) == 1 {
+			for entityType := range mappings {
+				options.EntityType = entityType
+			}
+		}
+		columns, ok := mappings[options.EntityType]
+		if !ok {
+			return fmt.Errorf("mapping file has no columns for entity type %q", options.EntityType)
+		}
+		options.Columns = columns
+	}
+
+	var input io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	// =====================================================
+	// Stream the file
+	// =====================================================
+	conn, ctx, err := dial(context.Background(), *addr, *token)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := dapi.NewEntityServiceClient(conn).ImportEntities(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&dapi.ImportEntitiesRequest{Options: options}); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	buffer := make([]byte, importChunkSize)
+	for {
+		n, err := input.Read(buffer)
+		if n > 0 {
+			// The server ended the stream early, its error is returned by CloseAndRecv
+			if err := stream.Send(&dapi.ImportEntitiesRequest{Data: buffer[:n]}); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+
+	// =====================================================
+	// Report
+	// =====================================================
+	fmt.Printf("Imported %d entities and %d relationships, %d records failed\n", resp.GetEntitiesImported(), resp.GetRelationshipsImported(), resp.GetRecordsFailed())
+	for _, importError := range resp.GetErrors() {
+		fmt.Printf("line %d: %s\n", importError.GetLine(), importError.GetMessage())
+	}
+	if omitted := resp.GetRecordsFailed() - int64(len(resp.GetErrors())); omitted > 0 {
+		fmt.Printf("... and %d more\n", omitted)
+	}
+	if resp.GetRecordsFailed() > 0 {
+		return fmt.Errorf("%d records failed", resp.GetRecordsFailed())
+	}
+	return nil
+}
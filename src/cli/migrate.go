@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/omnsight/omndapi/src/migrations"
+)
+
+// RunMigrate applies, reverts or lists the schema migrations of the database.
+func RunMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	database := databaseFlag(flags)
+	target := flags.Int("to", 0, "version to migrate up to, defaults to the latest")
+	steps := flags.Int("steps", 1, "number of migrations to revert")
+	lockTimeout := flags.Duration("lock-timeout", migrations.DefaultLockTimeout, "how long to wait for another migration to finish")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: omndapi migrate [flags] up|down|status")
+		fmt.Fprintln(flags.Output(), "\nApplies, reverts or lists the schema migrations. The server applies pending migrations on startup.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected one of up, down or status")
+	}
+
+	ctx := context.Background()
+	client, err := connectDatabase(*database)
+	if err != nil {
+		return err
+	}
+	migrator, err := migrations.NewMigrator(ctx, client)
+	if err != nil {
+		return err
+	}
+	migrator.LockTimeout = *lockTimeout
+
+	switch flags.Arg(0) {
+	case "up":
+		applied, err := migrator.Up(ctx, *target)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migrations %v\n", len(applied), applied)
+	case "down":
+		if *steps < 1 {
+			return fmt.Errorf("steps must be positive")
+		}
+		reverted, err := migrator.Down(ctx, *steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Reverted %d migrations %v\n", len(reverted), reverted)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt > 0 {
+				state = "applied " + time.UnixMilli(status.AppliedAt).UTC().Format(time.RFC3339)
+			}
+			if status.Unknown {
+				state += ", unknown to this release"
+			}
+			fmt.Printf("%4d  %-55s %s\n", status.Version, status.Name, state)
+		}
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown migrate command %q", flags.Arg(0))
+	}
+	return nil
+}
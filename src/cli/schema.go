@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/omnsight/omndapi/src/entity_service/collections"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// RunSchema reports the stored documents of the entity and edge collections
+// that do not match the schemas of this release. With -apply it installs the
+// schemas first.
+func RunSchema(args []string) error {
+	flags := flag.NewFlagSet("schema", flag.ContinueOnError)
+	database := databaseFlag(flags)
+	apply := flags.Bool("apply", false, "install the schemas with -level before reporting")
+	level := flags.String("level", os.Getenv(utils.SchemaLevel), "schema level installed by -apply: none, new, moderate or strict, defaults to $"+utils.SchemaLevel)
+	limit := flags.Int("limit", 10, "violating documents listed per collection")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: omndapi schema [flags]")
+		fmt.Fprintln(flags.Output(), "\nReports documents violating the collection schemas, and installs the schemas with -apply.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := connectDatabase(*database)
+	if err != nil {
+		return err
+	}
+	worker := pipeline.NewWorker(client)
+	if *apply {
+		if *level != "" {
+			if err := worker.SetSchemaLevel(*level); err != nil {
+				return err
+			}
+		}
+		if err := collections.RegisterAll(ctx, client, worker); err != nil {
+			return fmt.Errorf("failed to install schemas: %w", err)
+		}
+	}
+	// Schema of the documents of each collection
+	var names []string
+	messages := map[string]proto.Message{}
+	for _, name := range []string{"event", "source", "website", "person", "organization"} {
+		if exists, err := client.DB.CollectionExists(ctx, name); err != nil {
+			return err
+		} else if !exists {
+			continue
+		}
+		entity, err := worker.CreateEntityStruct(name)
+		if err != nil {
+			return err
+		}
+		names = append(names, name)
+		messages[name] = entity.(proto.Message)
+	}
+	edgeCollections, _, err := client.OsintGraph.EdgeCollections(ctx)
+	if err != nil {
+		return err
+	}
+	for _, col := range edgeCollections {
+		if *apply {
+			if err := worker.ApplyRelationSchema(ctx, col); err != nil {
+				return fmt.Errorf("failed to install schema on %s: %w", col.Name(), err)
+			}
+		}
+		names = append(names, col.Name())
+		messages[col.Name()] = &model.Relation{}
+	}
+
+	var total int64
+	for _, name := range names {
+		count, violations, err := worker.SchemaViolations(ctx, name, messages[name], *limit)
+		if err != nil {
+			return fmt.Errorf("failed to validate %s: %w", name, err)
+		}
+		if count == 0 {
+			continue
+		}
+		fmt.Printf("%s: %d documents violate the schema\n", name, count)
+		for _, violation := range violations {
+			fmt.Printf("  %s/%s: %s\n", name, violation.Key, violation.Message)
+		}
+		total += count
+	}
+	if *apply {
+		fmt.Printf("Installed schemas on %d collections, %d documents violate them\n", len(names), total)
+	} else {
+		fmt.Printf("Validated %d collections, %d documents violate the schemas\n", len(names), total)
+	}
+	return nil
+}
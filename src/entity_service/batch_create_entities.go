@@ -0,0 +1,112 @@
+package entityservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Maximum number of entities created by one batch
+const MaxBatchSize = 500
+
+func (s *EntityService) BatchCreateEntities(ctx context.Context, req *dapi.BatchCreateEntitiesRequest) (resp *dapi.BatchCreateEntitiesResponse, err error) {
+	// =====================================================
+	// Get Common Data
+	// =====================================================
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.Infof("[%s, %v] requests to create %d entities (upsert: %v)", userId, userRoles, len(req.GetEntities()), req.GetUpsert())
+
+	col, err := s.Pipeline.GetCollection(req.GetEntityType())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid entity type: %v", err)
+	}
+
+	if len(req.GetEntities()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "no entities to create")
+	}
+	if len(req.GetEntities()) > MaxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d entities can be created at once", MaxBatchSize)
+	}
+	if !req.GetUpsert() {
+		if err := s.Pipeline.CheckCreatePermission(userRoles); err != nil {
+			return nil, err
+		}
+	}
+
+	inputEntities := make([]pipeline.ConcereteEntityCommon, len(req.GetEntities()))
+	var violations pipeline.FieldViolations
+	for i, entity := range req.GetEntities() {
+		inputEntity, err := s.Pipeline.ExtractInputEntity(&dapi.CreateEntityRequest{EntityType: req.GetEntityType(), Entity: entity})
+		if err != nil {
+			return nil, pipeline.PrefixStatus(err, fmt.Sprintf("entity %d: ", i))
+		}
+		if inputEntity == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "entity %d: entity content missing", i)
+		}
+		inputEntities[i] = inputEntity
+		violations = append(violations, s.Pipeline.EntityViolations(inputEntity, false).Prefix(fmt.Sprintf("entities[%d]", i))...)
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+
+	// Retried calls with the same Idempotency-Key replay the first response
+	replay := &dapi.BatchCreateEntitiesResponse{}
+	if replayed, err := s.Pipeline.BeginIdempotentRequest(ctx, userId, "BatchCreateEntities", req, replay); err != nil {
+		return nil, err
+	} else if replayed {
+		return replay, nil
+	}
+	defer func() {
+		s.Pipeline.FinishIdempotentRequest(ctx, userId, "BatchCreateEntities", resp, err)
+	}()
+
+	prepared := make([]*preparedEntity, len(inputEntities))
+	for i, inputEntity := range inputEntities {
+		if prepared[i], err = s.prepareEntity(ctx, inputEntity); err != nil {
+			return nil, err
+		}
+	}
+
+	// =====================================================
+	// Write into db, all entities or none
+	// =====================================================
+	runTransaction := s.Pipeline.RunTransaction
+	if req.GetUpsert() {
+		runTransaction = s.Pipeline.RunExclusiveTransaction
+	}
+
+	entities := make([]*model.Entity, len(inputEntities))
+	created := make([]bool, len(inputEntities))
+	err = runTransaction(ctx, []string{req.GetEntityType()}, func(ctx context.Context) error {
+		for i, preparedEntity := range prepared {
+			var err error
+			if req.GetUpsert() {
+				entities[i], created[i], err = s.upsertEntity(ctx, col, req.GetEntityType(), preparedEntity, userId, userRoles)
+			} else {
+				entities[i], err = s.createEntity(ctx, col, req.GetEntityType(), preparedEntity, userId, userRoles)
+				created[i] = true
+			}
+			if err != nil {
+				return pipeline.PrefixStatus(err, fmt.Sprintf("entity %d: ", i))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dapi.BatchCreateEntitiesResponse{Entities: entities, Created: created}, nil
+}
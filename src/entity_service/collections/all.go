@@ -0,0 +1,29 @@
+package collections
+
+import (
+	"context"
+
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+)
+
+// RegisterAll creates every collection used by the services with its indexes.
+func RegisterAll(ctx context.Context, client *utils.ArangoDBClient, p *pipeline.Worker) error {
+	for _, register := range []func(context.Context, *utils.ArangoDBClient, *pipeline.Worker) error{
+		RegisterEvent,
+		RegisterSource,
+		RegisterWebsite,
+		RegisterPerson,
+		RegisterOrganization,
+		RegisterRelationType,
+		RegisterGrant,
+		RegisterTombstone,
+		RegisterDuplicateCandidate,
+		RegisterIdempotencyKey,
+	} {
+		if err := register(ctx, client, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package collections
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+)
+
+func RegisterDuplicateCandidate(ctx context.Context, client *utils.ArangoDBClient, p *pipeline.Worker) error {
+	col, err := client.GetCreateDocumentCollection(ctx, pipeline.DuplicateCandidateCollection, nil)
+	if err != nil {
+		return err
+	}
+	// Index for candidate lookups by entity type, best first
+	if _, _, err := col.EnsurePersistentIndex(ctx, []string{"entity_type", "score"}, &driver.EnsurePersistentIndexOptions{
+		Name: "idx_duplicate_candidate_type_score",
+	}); err != nil {
+		return err
+	}
+	// Index for removing the candidates of previous scans
+	if _, _, err := col.EnsurePersistentIndex(ctx, []string{"entity_type", "scanned_at"}, &driver.EnsurePersistentIndexOptions{
+		Name: "idx_duplicate_candidate_type_scanned_at",
+	}); err != nil {
+		return err
+	}
+	p.RegisterDuplicateCollection(col)
+	return nil
+}
@@ -19,6 +19,16 @@ func RegisterEvent(ctx context.Context, client *utils.ArangoDBClient, p *pipelin
 	}); err != nil {
 		return err
 	}
+	// Index for location queries
+	if _, _, err := col.EnsureGeoIndex(ctx, []string{pipeline.GeoField}, &driver.EnsureGeoIndexOptions{
+		Name:    "idx_event_geo",
+		GeoJSON: true,
+	}); err != nil {
+		return err
+	}
+	if err := p.ApplyEntitySchema(ctx, "event", col); err != nil {
+		return err
+	}
 	p.RegisterCollection("event", col)
 	return nil
 }
@@ -0,0 +1,30 @@
+package collections
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+)
+
+func RegisterGrant(ctx context.Context, client *utils.ArangoDBClient, p *pipeline.Worker) error {
+	col, err := client.GetCreateDocumentCollection(ctx, pipeline.GrantCollection, nil)
+	if err != nil {
+		return err
+	}
+	// Index for grant lookups by principal
+	if _, _, err := col.EnsurePersistentIndex(ctx, []string{"principal", "expires_at"}, &driver.EnsurePersistentIndexOptions{
+		Name: pipeline.GrantPrincipalIndex,
+	}); err != nil {
+		return err
+	}
+	// Index for the expired grant sweeper
+	if _, _, err := col.EnsurePersistentIndex(ctx, []string{"expires_at"}, &driver.EnsurePersistentIndexOptions{
+		Name: "idx_grant_expires_at",
+	}); err != nil {
+		return err
+	}
+	p.RegisterGrantCollection(col)
+	return nil
+}
@@ -0,0 +1,24 @@
+package collections
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+)
+
+func RegisterIdempotencyKey(ctx context.Context, client *utils.ArangoDBClient, p *pipeline.Worker) error {
+	col, err := client.GetCreateDocumentCollection(ctx, pipeline.IdempotencyCollection, nil)
+	if err != nil {
+		return err
+	}
+	// Stored responses expire once retries are no longer expected
+	if _, _, err := col.EnsureTTLIndex(ctx, "created_at", int(pipeline.IdempotencyKeyTTL.Seconds()), &driver.EnsureTTLIndexOptions{
+		Name: "idx_idempotency_key_created_at",
+	}); err != nil {
+		return err
+	}
+	p.RegisterIdempotencyCollection(col)
+	return nil
+}
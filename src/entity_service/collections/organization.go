@@ -19,6 +19,9 @@ func RegisterOrganization(ctx context.Context, client *utils.ArangoDBClient, p *
 	}); err != nil {
 		return err
 	}
+	if err := p.ApplyEntitySchema(ctx, "organization", col); err != nil {
+		return err
+	}
 	p.RegisterCollection("organization", col)
 	return nil
 }
@@ -19,6 +19,9 @@ func RegisterPerson(ctx context.Context, client *utils.ArangoDBClient, p *pipeli
 	}); err != nil {
 		return err
 	}
+	if err := p.ApplyEntitySchema(ctx, "person", col); err != nil {
+		return err
+	}
 	p.RegisterCollection("person", col)
 	return nil
 }
@@ -0,0 +1,18 @@
+package collections
+
+import (
+	"context"
+
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+)
+
+func RegisterRelationType(ctx context.Context, client *utils.ArangoDBClient, p *pipeline.Worker) error {
+	// Relation types are keyed by name, no extra index needed
+	col, err := client.GetCreateDocumentCollection(ctx, pipeline.RelationTypeCollection, nil)
+	if err != nil {
+		return err
+	}
+	p.RegisterRelationTypeCollection(col)
+	return nil
+}
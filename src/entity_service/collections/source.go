@@ -19,6 +19,9 @@ func RegisterSource(ctx context.Context, client *utils.ArangoDBClient, p *pipeli
 	}); err != nil {
 		return err
 	}
+	if err := p.ApplyEntitySchema(ctx, "source", col); err != nil {
+		return err
+	}
 	p.RegisterCollection("source", col)
 	return nil
 }
@@ -0,0 +1,24 @@
+package collections
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+)
+
+func RegisterTombstone(ctx context.Context, client *utils.ArangoDBClient, p *pipeline.Worker) error {
+	col, err := client.GetCreateDocumentCollection(ctx, pipeline.TombstoneCollection, nil)
+	if err != nil {
+		return err
+	}
+	// Index for redirecting tombstones when their survivor is merged again
+	if _, _, err := col.EnsurePersistentIndex(ctx, []string{"survivor_id"}, &driver.EnsurePersistentIndexOptions{
+		Name: "idx_tombstone_survivor_id",
+	}); err != nil {
+		return err
+	}
+	p.RegisterTombstoneCollection(col)
+	return nil
+}
@@ -20,6 +20,9 @@ func RegisterWebsite(ctx context.Context, client *utils.ArangoDBClient, p *pipel
 	}); err != nil {
 		return err
 	}
+	if err := p.ApplyEntitySchema(ctx, "website", col); err != nil {
+		return err
+	}
 	p.RegisterCollection("website", col)
 	return nil
 }
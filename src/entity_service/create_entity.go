@@ -3,13 +3,17 @@ package entityservice
 import (
 	"context"
 
+	"github.com/arangodb/go-driver"
 	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
 	"github.com/omnsight/omndapi/src/utils"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
-func (s *EntityService) CreateEntity(ctx context.Context, req *dapi.CreateEntityRequest) (*dapi.CreateEntityResponse, error) {
+func (s *EntityService) CreateEntity(ctx context.Context, req *dapi.CreateEntityRequest) (resp *dapi.CreateEntityResponse, err error) {
 	// =====================================================
 	// Get Common Data
 	// =====================================================
@@ -38,20 +42,85 @@ func (s *EntityService) CreateEntity(ctx context.Context, req *dapi.CreateEntity
 	if inputEntity == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "entity content missing")
 	}
+	if err := s.Pipeline.ValidateEntity(inputEntity, false); err != nil {
+		return nil, err
+	}
 
-	// =====================================================
-	// Process and clean up input data
-	// =====================================================
-	if err := s.Pipeline.SetPermissions(inputEntity, userId, true); err != nil {
+	// Retried calls with the same Idempotency-Key replay the first response
+	replay := &dapi.CreateEntityResponse{}
+	if replayed, err := s.Pipeline.BeginIdempotentRequest(ctx, userId, "CreateEntity", req, replay); err != nil {
+		return nil, err
+	} else if replayed {
+		return replay, nil
+	}
+	defer func() {
+		s.Pipeline.FinishIdempotentRequest(ctx, userId, "CreateEntity", resp, err)
+	}()
+
+	prepared, err := s.prepareEntity(ctx, inputEntity)
+	if err != nil {
+		return nil, err
+	}
+	responseEntity, err := s.createEntity(ctx, col, req.GetEntityType(), prepared, userId, userRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dapi.CreateEntityResponse{Entity: responseEntity}, nil
+}
+
+// preparedEntity is an input entity normalized and embedded before it is
+// written, so that writes made in a transaction call no outside service and
+// retried writes do not repeat the calls.
+type preparedEntity struct {
+	entity    pipeline.ConcereteEntityCommon
+	raw       pipeline.RawValues
+	embedding []float32
+}
+
+// prepareEntity normalizes inputEntity in place and computes its embedding.
+// Normalization runs ahead of SetPermissions in dataMap, unlike updates,
+// because the embedding must be computed from the normalized text before a
+// transaction starts. Normalizers do not touch the owner and ACL fields.
+func (s *EntityService) prepareEntity(ctx context.Context, inputEntity pipeline.ConcereteEntityCommon) (*preparedEntity, error) {
+	raw := s.Pipeline.NormalizeEntity(inputEntity)
+	embedding, err := s.Pipeline.GetEmbedding(ctx, inputEntity)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set additional fields: %v", err)
+	}
+	return &preparedEntity{entity: inputEntity, raw: raw, embedding: embedding}, nil
+}
+
+// dataMap returns the document written for the prepared entity by the user,
+// either as a new entity or over an existing one.
+func (s *EntityService) dataMap(prepared *preparedEntity, userId string, isCreate bool) (map[string]interface{}, error) {
+	// The prepared entity is left untouched for retried writes
+	entity := proto.Clone(prepared.entity.(proto.Message)).(pipeline.ConcereteEntityCommon)
+	if err := s.Pipeline.SetPermissions(entity, userId, isCreate); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to set permissions: %v", err)
 	}
 
-	dataMap, err := s.Pipeline.SetAdditionalFields(ctx, inputEntity)
+	dataMap, err := s.Pipeline.EntityDataMap(entity, prepared.embedding)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to set additional fields: %v", err)
 	}
+	prepared.raw.AddTo(dataMap, isCreate)
+	s.Pipeline.SetAuditFields(dataMap, userId, isCreate)
+	return dataMap, nil
+}
+
+// createEntity inserts the prepared entity owned by the user into the collection.
+func (s *EntityService) createEntity(ctx context.Context, col driver.Collection, entityType string, prepared *preparedEntity, userId string, userRoles []string) (*model.Entity, error) {
+	if err := s.Pipeline.CheckCreatePermission(userRoles); err != nil {
+		return nil, err
+	}
 
-	createdStruct, err := s.Pipeline.CreateEntityStruct(req.GetEntityType())
+	dataMap, err := s.dataMap(prepared, userId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	createdStruct, err := s.Pipeline.CreateEntityStruct(entityType)
 	if err != nil {
 		return nil, err
 	}
@@ -68,10 +137,5 @@ func (s *EntityService) CreateEntity(ctx context.Context, req *dapi.CreateEntity
 	// Convert back to response entity
 	// =====================================================
 	s.Pipeline.SetEntityMeta(createdStruct, meta.ID.String(), meta.Key, meta.Rev)
-	responseEntity, err := s.Pipeline.WrapEntityResponse(createdStruct)
-	if err != nil {
-		return nil, err
-	}
-
-	return &dapi.CreateEntityResponse{Entity: responseEntity}, nil
+	return s.Pipeline.WrapEntityResponse(createdStruct)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/omnsight/omndapi/gen/dapi/v1"
 	"github.com/omnsight/omndapi/src/utils"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -35,6 +36,31 @@ func (s *EntityService) GetEntity(ctx context.Context, req *dapi.GetEntityReques
 	}
 
 	meta, err := s.Pipeline.ReadDocument(ctx, col, req.GetKey(), targetStruct)
+	var redirectedFrom string
+	if status.Code(err) == codes.NotFound {
+		// Merged entities redirect to the entity they were merged into
+		requestedId := req.GetEntityType() + "/" + req.GetKey()
+		survivorId, found, resolveErr := s.Pipeline.ResolveTombstone(ctx, requestedId)
+		if resolveErr != nil {
+			logger.WithFields(logrus.Fields{
+				"error": resolveErr,
+				"id":    requestedId,
+			}).Error("failed to resolve tombstone")
+			return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+		}
+		if found {
+			_, survivorKey, parseErr := s.DBClient.ParseDocID(survivorId)
+			if parseErr != nil {
+				logger.WithFields(logrus.Fields{
+					"error": parseErr,
+					"id":    survivorId,
+				}).Error("failed to parse survivor id")
+				return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+			}
+			meta, err = s.Pipeline.ReadDocument(ctx, col, survivorKey, targetStruct)
+			redirectedFrom = requestedId
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +68,7 @@ func (s *EntityService) GetEntity(ctx context.Context, req *dapi.GetEntityReques
 	// =====================================================
 	// Check permission
 	// =====================================================
-	if err := s.Pipeline.CheckReadPermission(targetStruct, userId, userRoles); err != nil {
+	if err := s.Pipeline.CheckReadPermission(ctx, targetStruct, userId, userRoles); err != nil {
 		return nil, err
 	}
 
@@ -55,5 +81,5 @@ func (s *EntityService) GetEntity(ctx context.Context, req *dapi.GetEntityReques
 		return nil, err
 	}
 
-	return &dapi.GetEntityResponse{Entity: responseEntity}, nil
+	return &dapi.GetEntityResponse{Entity: responseEntity, RedirectedFrom: redirectedFrom}, nil
 }
@@ -0,0 +1,244 @@
+package entityservice
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// importWrite is a record of an import file that passed validation and
+// waits to be written with its batch.
+type importWrite struct {
+	record     *pipeline.ImportRecord
+	collection string
+	relation   bool
+	write      func(ctx context.Context) error
+}
+
+func (s *EntityService) ImportEntities(stream grpc.ClientStreamingServer[dapi.ImportEntitiesRequest, dapi.ImportEntitiesResponse]) error {
+	ctx := stream.Context()
+
+	// =====================================================
+	// Get Common Data
+	// =====================================================
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.Infof("[%s, %v] requests to import entities", userId, userRoles)
+
+	first, err := stream.Recv()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	options := first.GetOptions()
+	if options == nil {
+		return status.Errorf(codes.InvalidArgument, "import options missing")
+	}
+	batchSize := int(options.GetBatchSize())
+	if batchSize == 0 {
+		batchSize = pipeline.DefaultImportBatchSize
+	}
+	if batchSize < 0 || batchSize > pipeline.MaxImportBatchSize {
+		return status.Errorf(codes.InvalidArgument, "batch size must be between 1 and %d", pipeline.MaxImportBatchSize)
+	}
+
+	// Feed the streamed chunks of the file to the record reader
+	reader, writer := io.Pipe()
+	defer reader.Close()
+	go func() {
+		req := first
+		for {
+			if len(req.GetData()) > 0 {
+				if _, err := writer.Write(req.GetData()); err != nil {
+					return
+				}
+			}
+			next, err := stream.Recv()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+				writer.CloseWithError(err)
+				return
+			}
+			req = next
+		}
+	}()
+
+	resp, err := s.importRecords(ctx, func(fn func(record *pipeline.ImportRecord) error) error {
+		return s.Pipeline.ReadImportRecords(reader, options, fn)
+	}, batchSize, options.GetUpsert(), userId, userRoles)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(resp)
+}
+
+// importRecords validates the records passed by read and writes them in
+// batches. Invalid records are reported in the response and skipped.
+func (s *EntityService) importRecords(ctx context.Context, read func(fn func(record *pipeline.ImportRecord) error) error, batchSize int, upsert bool, userId string, userRoles []string) (*dapi.ImportEntitiesResponse, error) {
+	resp := &dapi.ImportEntitiesResponse{}
+	fail := func(record *pipeline.ImportRecord, err error) {
+		resp.RecordsFailed++
+		if len(resp.Errors) < pipeline.MaxImportErrors {
+			st := status.Convert(err)
+			resp.Errors = append(resp.Errors, &dapi.ImportError{
+				Line:     int64(record.Line),
+				Message:  st.Message(),
+				ObjectId: record.ObjectId,
+				Code:     int32(st.Code()),
+				Details:  st.Proto().GetDetails(),
+			})
+		}
+	}
+
+	var batch []*importWrite
+	err := read(func(record *pipeline.ImportRecord) error {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		if record.Err != nil {
+			fail(record, record.Err)
+			return nil
+		}
+
+		write, err := s.prepareImport(ctx, record, upsert, userId, userRoles)
+		if err != nil {
+			fail(record, err)
+			return nil
+		}
+		if batch = append(batch, write); len(batch) >= batchSize {
+			s.commitImport(ctx, batch, upsert, resp, fail)
+			batch = nil
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.commitImport(ctx, batch, upsert, resp, fail)
+
+	utils.GetLogger(ctx).Infof("imported %d entities and %d relationships, %d records failed", resp.EntitiesImported, resp.RelationshipsImported, resp.RecordsFailed)
+	return resp, nil
+}
+
+// prepareImport validates a record and returns how to write it.
+func (s *EntityService) prepareImport(ctx context.Context, record *pipeline.ImportRecord, upsert bool, userId string, userRoles []string) (*importWrite, error) {
+	if record.Relation != nil {
+		if err := s.Pipeline.CheckCreatePermission(userRoles); err != nil {
+			return nil, err
+		}
+		col, err := s.Pipeline.RelationshipCollection(ctx, record.Relation)
+		if err != nil {
+			return nil, err
+		}
+		return &importWrite{
+			record:     record,
+			collection: col.Name(),
+			relation:   true,
+			write: func(ctx context.Context) error {
+				// Endpoints may be imported by the same batch
+				if err := s.Pipeline.CheckRelationshipEndpoints(ctx, record.Relation, userId, userRoles); err != nil {
+					return err
+				}
+				// Writing modifies the input, which is reused if the batch is retried
+				relation := proto.Clone(record.Relation).(*model.Relation)
+				_, err := s.Pipeline.CreateRelationship(ctx, col, relation, userId)
+				return err
+			},
+		}, nil
+	}
+
+	col, err := s.Pipeline.GetCollection(record.EntityType)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid entity type: %v", err)
+	}
+	inputEntity, err := s.Pipeline.ExtractInputEntity(&dapi.CreateEntityRequest{EntityType: record.EntityType, Entity: record.Entity})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Pipeline.EntityViolations(inputEntity, false).Err(); err != nil {
+		return nil, err
+	}
+	// Normalize and embed outside of the batch transaction
+	prepared, err := s.prepareEntity(ctx, inputEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &importWrite{
+		record:     record,
+		collection: record.EntityType,
+		write: func(ctx context.Context) error {
+			var err error
+			if upsert {
+				_, _, err = s.upsertEntity(ctx, col, record.EntityType, prepared, userId, userRoles)
+			} else {
+				_, err = s.createEntity(ctx, col, record.EntityType, prepared, userId, userRoles)
+			}
+			return err
+		},
+	}, nil
+}
+
+// commitImport writes a batch of records in one transaction. A failing record
+// rolls the transaction back, it is reported and the batch is retried without it.
+func (s *EntityService) commitImport(ctx context.Context, batch []*importWrite, upsert bool, resp *dapi.ImportEntitiesResponse, fail func(record *pipeline.ImportRecord, err error)) {
+	runTransaction := s.Pipeline.RunTransaction
+	if upsert {
+		runTransaction = s.Pipeline.RunExclusiveTransaction
+	}
+
+	for len(batch) > 0 {
+		var collections []string
+		for _, write := range batch {
+			if !slices.Contains(collections, write.collection) {
+				collections = append(collections, write.collection)
+			}
+		}
+
+		failed := -1
+		err := runTransaction(ctx, collections, func(ctx context.Context) error {
+			for i, write := range batch {
+				if err := write.write(ctx); err != nil {
+					failed = i
+					return err
+				}
+			}
+			return nil
+		})
+		if err == nil {
+			for _, write := range batch {
+				if write.relation {
+					resp.RelationshipsImported++
+				} else {
+					resp.EntitiesImported++
+				}
+			}
+			return
+		}
+
+		// The transaction itself failed, none of the records was written
+		if failed < 0 {
+			for _, write := range batch {
+				fail(write.record, err)
+			}
+			return
+		}
+		fail(batch[failed].record, err)
+		batch = slices.Delete(batch, failed, failed+1)
+	}
+}
@@ -0,0 +1,44 @@
+package entityservice
+
+import (
+	"context"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *EntityService) ImportStix(ctx context.Context, req *dapi.ImportStixRequest) (*dapi.ImportEntitiesResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.WithFields(logrus.Fields{
+		"objects": len(req.GetBundle().GetFields()["objects"].GetListValue().GetValues()),
+	}).Infof("[%s, %v] requests to import STIX bundle", userId, userRoles)
+
+	if req.GetBundle() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "bundle missing")
+	}
+	batchSize := int(req.GetBatchSize())
+	if batchSize == 0 {
+		batchSize = pipeline.DefaultImportBatchSize
+	}
+	if batchSize < 0 || batchSize > pipeline.MaxImportBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "batch size must be between 1 and %d", pipeline.MaxImportBatchSize)
+	}
+	if req.GetReferenceRelation() != "" {
+		if _, err := s.Pipeline.NormalizeRelationName(req.GetReferenceRelation()); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.importRecords(ctx, func(fn func(record *pipeline.ImportRecord) error) error {
+		return s.Pipeline.ReadStixBundle(req.GetBundle().AsMap(), req.GetReferenceRelation(), fn)
+	}, batchSize, false, userId, userRoles)
+}
@@ -21,8 +21,15 @@ func (s *EntityService) ListEntitiesFromEvent(ctx context.Context, req *dapi.Lis
 	logger := utils.GetLogger(ctx)
 	logger.Infof("[%s, %v] requests to list entities from event", userId, userRoles)
 
+	aggregation, err := s.Pipeline.CheckConfidenceOptions(req.GetMinConfidence(), req.GetConfidenceAggregation())
+	if err != nil {
+		return nil, err
+	}
+
+	followed := `(` + pipeline.ConfidenceFilter("e") + ` AND ` + pipeline.ValidityFilter("e") + `)`
+
 	// AQL query to find start events, filter them, traverse, and get relations
-	query := `
+	query := pipeline.GrantedIdsQuery + `
 		LET start_events = (
 			@startNode != "" ? (
 				FOR e IN event FILTER e._id == @startNode RETURN e
@@ -36,7 +43,8 @@ func (s *EntityService) ListEntitiesFromEvent(ctx context.Context, req *dapi.Lis
 
 		LET filtered_events = (
 			FOR e IN start_events
-			FILTER (@countryCode == "" OR e.location.countryCode == @countryCode OR e.location.country_code == @countryCode)
+			FILTER (@countryCode == "" OR e.location.country_code == @countryCode)
+			FILTER ` + pipeline.GeoFilter("e") + `
 			FILTER (@tag == "" 
 				OR @tag IN e.tags 
 				OR (IS_DOCUMENT(e.attributes) AND LENGTH(
@@ -48,16 +56,15 @@ func (s *EntityService) ListEntitiesFromEvent(ctx context.Context, req *dapi.Lis
 			RETURN e
 		)
 
-		// Traversal to find all connected entities within depth
+		// Traversal to find all connected entities within depth, not following
+		// relationships below the requested confidence or outside the validity window
 		LET traversed_nodes = (
 			FOR start_node IN filtered_events
-				FOR v IN 0..@depth ANY start_node GRAPH @graphName
+				FOR v, e IN 0..@depth ANY start_node GRAPH @graphName
+				PRUNE e != null AND NOT ` + followed + `
 				OPTIONS {uniqueVertices: 'global', bfs: true}
-				FILTER (
-					v.owner == @userId OR 
-					@userId IN v.read OR 
-					LENGTH(INTERSECTION(@userRoles, v.read)) > 0
-				)
+				FILTER e == null OR ` + followed + `
+				FILTER ` + pipeline.ReadFilter("v") + `
 				RETURN DISTINCT v
 		)
 
@@ -67,6 +74,7 @@ func (s *EntityService) ListEntitiesFromEvent(ctx context.Context, req *dapi.Lis
 			FOR id IN traversed_nodes[*]._id
 				FOR v, e IN 1..1 ANY id GRAPH @graphName
 				FILTER v._id IN traversed_nodes[*]._id
+				FILTER ` + followed + `
 				RETURN DISTINCT e
 		)
 
@@ -76,20 +84,42 @@ func (s *EntityService) ListEntitiesFromEvent(ctx context.Context, req *dapi.Lis
 				FOR doc IN traversed_nodes
 				RETURN { type: PARSE_IDENTIFIER(doc._id).collection, data: doc }
 			), 
-			relations: relations 
+			relations: relations,
+			start_ids: (
+				FOR e IN filtered_events
+				FILTER ` + pipeline.ReadFilter("e") + `
+				RETURN e._id
+			)
 		}
 	`
 
 	bindVars := map[string]interface{}{
-		"startNode":   req.GetStartNode(),
-		"startTime":   req.GetStartTime(),
-		"endTime":     req.GetEndTime(),
-		"countryCode": req.GetCountryCode(),
-		"tag":         req.GetTag(),
-		"depth":       req.GetDepth(),
-		"graphName":   s.DBClient.OsintGraph.Name(),
-		"userId":      userId,
-		"userRoles":   userRoles,
+		"startNode":     req.GetStartNode(),
+		"startTime":     req.GetStartTime(),
+		"endTime":       req.GetEndTime(),
+		"countryCode":   req.GetCountryCode(),
+		"tag":           s.Pipeline.NormalizeTagFilter(req.GetTag()),
+		"depth":         req.GetDepth(),
+		"minConfidence": req.GetMinConfidence(),
+		"graphName":     s.DBClient.OsintGraph.Name(),
+		"userId":        userId,
+		"userRoles":     userRoles,
+	}
+	s.Pipeline.AddGrantBindVars(ctx, bindVars, userId, userRoles)
+	if err := s.Pipeline.AddValidityBindVars(bindVars, req.GetAsOf(), req.GetWindowStart(), req.GetWindowEnd()); err != nil {
+		return nil, err
+	}
+	if err := s.Pipeline.AddGeoBindVars(bindVars, pipeline.GeoArea{
+		NearLatitude:  req.GetNearLatitude(),
+		NearLongitude: req.GetNearLongitude(),
+		RadiusMeters:  req.GetRadiusMeters(),
+		MinLatitude:   req.GetMinLatitude(),
+		MinLongitude:  req.GetMinLongitude(),
+		MaxLatitude:   req.GetMaxLatitude(),
+		MaxLongitude:  req.GetMaxLongitude(),
+		Polygon:       req.GetPolygon(),
+	}); err != nil {
+		return nil, err
 	}
 
 	cursor, err := s.DBClient.DB.Query(ctx, query, bindVars)
@@ -103,7 +133,10 @@ func (s *EntityService) ListEntitiesFromEvent(ctx context.Context, req *dapi.Lis
 	}
 	defer cursor.Close()
 
-	var result pipeline.QueryResult
+	var result struct {
+		pipeline.QueryResult
+		StartIds []string `json:"start_ids"`
+	}
 	_, err = cursor.ReadDocument(ctx, &result)
 	if err != nil {
 		if driver.IsNoMoreDocuments(err) {
@@ -118,7 +151,8 @@ func (s *EntityService) ListEntitiesFromEvent(ctx context.Context, req *dapi.Lis
 	pbEntities, pbRelations := s.Pipeline.ProcessEntities(ctx, result.Entities, result.Relations)
 
 	return &dapi.ListEntitiesFromEventResponse{
-		Entities:  pbEntities,
-		Relations: pbRelations,
+		Entities:       pbEntities,
+		Relations:      pbRelations,
+		PathConfidence: pipeline.PathConfidences(result.StartIds, pbRelations, "ANY", int(req.GetDepth()), aggregation, nil),
 	}, nil
 }
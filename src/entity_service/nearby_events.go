@@ -0,0 +1,113 @@
+package entityservice
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *EntityService) NearbyEvents(ctx context.Context, req *dapi.NearbyEventsRequest) (*dapi.NearbyEventsResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.WithFields(logrus.Fields{
+		"latitude":  req.GetLatitude(),
+		"longitude": req.GetLongitude(),
+		"radius":    req.GetRadiusMeters(),
+	}).Infof("[%s, %v] requests to list nearby events", userId, userRoles)
+
+	// =====================================================
+	// Validate request
+	// =====================================================
+	if !(req.GetRadiusMeters() > 0) {
+		return nil, status.Errorf(codes.InvalidArgument, "radius must be positive")
+	}
+
+	if err := pipeline.CheckCoordinates("center", req.GetLatitude(), req.GetLongitude()); err != nil {
+		return nil, err
+	}
+
+	bindVars := map[string]interface{}{
+		"center":    []float64{req.GetLongitude(), req.GetLatitude()},
+		"radius":    req.GetRadiusMeters(),
+		"startTime": req.GetStartTime(),
+		"endTime":   req.GetEndTime(),
+		"userId":    userId,
+		"userRoles": userRoles,
+	}
+	s.Pipeline.AddGrantBindVars(ctx, bindVars, userId, userRoles)
+	s.Pipeline.AddPageBindVars(bindVars, 0, req.GetLimit())
+
+	// =====================================================
+	// Query events by distance
+	// =====================================================
+	// The distance filter and sort are written against the event collection
+	// so the geo index serves them
+	query := pipeline.GrantedIdsQuery + `
+		FOR e IN event
+		FILTER GEO_DISTANCE(@center, e.` + pipeline.GeoField + `) <= @radius
+		FILTER e.` + pipeline.GeoField + ` != null
+		FILTER e.happened_at >= @startTime AND (@endTime == 0 OR e.happened_at <= @endTime)
+		FILTER ` + pipeline.ReadFilter("e") + `
+		SORT GEO_DISTANCE(@center, e.` + pipeline.GeoField + `) ASC
+		LIMIT @offset, @limit
+		RETURN {
+			type: "event",
+			data: e,
+			distance: GEO_DISTANCE(@center, e.` + pipeline.GeoField + `)
+		}
+	`
+
+	cursor, err := s.DBClient.DB.Query(ctx, query, bindVars)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"query": query,
+			"vars":  bindVars,
+		}).Error("failed to execute AQL query")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	defer cursor.Close()
+
+	// =====================================================
+	// Return response
+	// =====================================================
+	var events []*dapi.NearbyEvent
+	for {
+		var result struct {
+			pipeline.EntityResult
+			Distance float64 `json:"distance"`
+		}
+		if _, err := cursor.ReadDocument(ctx, &result); driver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			logger.WithFields(logrus.Fields{
+				"error": err,
+			}).Error("failed to read query result")
+			return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+		}
+
+		entity, _, err := s.Pipeline.DecodeEntity(result.EntityResult)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"error": err,
+			}).Error("failed to unmarshal entity data")
+			continue
+		}
+		events = append(events, &dapi.NearbyEvent{
+			Event:          entity.GetEvent(),
+			DistanceMeters: result.Distance,
+		})
+	}
+
+	return &dapi.NearbyEventsResponse{Events: events}, nil
+}
@@ -19,7 +19,7 @@ type EntityService struct {
 func NewEntityService(client *utils.ArangoDBClient) (*EntityService, error) {
 	service := &EntityService{
 		DBClient: client,
-		Pipeline: pipeline.NewWorker(),
+		Pipeline: pipeline.NewWorker(client),
 	}
 
 	ctx := context.Background()
@@ -41,6 +41,15 @@ func NewEntityService(client *utils.ArangoDBClient) (*EntityService, error) {
 	if err := collections.RegisterOrganization(ctx, client, service.Pipeline); err != nil {
 		return nil, err
 	}
+	if err := collections.RegisterGrant(ctx, client, service.Pipeline); err != nil {
+		return nil, err
+	}
+	if err := collections.RegisterTombstone(ctx, client, service.Pipeline); err != nil {
+		return nil, err
+	}
+	if err := collections.RegisterIdempotencyKey(ctx, client, service.Pipeline); err != nil {
+		return nil, err
+	}
 
 	return service, nil
 }
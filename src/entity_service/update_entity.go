@@ -38,6 +38,9 @@ func (s *EntityService) UpdateEntity(ctx context.Context, req *dapi.UpdateEntity
 	if inputEntity == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "entity content missing")
 	}
+	if err := s.Pipeline.ValidateEntity(inputEntity, true); err != nil {
+		return nil, err
+	}
 
 	// =====================================================
 	// Check Permission
@@ -58,10 +61,14 @@ func (s *EntityService) UpdateEntity(ctx context.Context, req *dapi.UpdateEntity
 		return nil, status.Errorf(codes.Internal, "failed to set permissions: %v", err)
 	}
 
+	raw := s.Pipeline.NormalizeEntity(inputEntity)
+
 	dataMap, err := s.Pipeline.SetAdditionalFields(ctx, inputEntity)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to set additional fields: %v", err)
 	}
+	raw.AddTo(dataMap, false)
+	s.Pipeline.SetAuditFields(dataMap, userId, false)
 
 	updatedStruct, err := s.Pipeline.CreateEntityStruct(req.GetEntityType())
 	if err != nil {
@@ -0,0 +1,149 @@
+package entityservice
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *EntityService) UpsertEntity(ctx context.Context, req *dapi.UpsertEntityRequest) (resp *dapi.UpsertEntityResponse, err error) {
+	// =====================================================
+	// Get Common Data
+	// =====================================================
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.Infof("[%s, %v] requests to upsert entity", userId, userRoles)
+
+	col, err := s.Pipeline.GetCollection(req.GetEntityType())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid entity type: %v", err)
+	}
+
+	inputEntity, err := s.Pipeline.ExtractInputEntity(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if inputEntity == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "entity content missing")
+	}
+	if err := s.Pipeline.ValidateEntity(inputEntity, false); err != nil {
+		return nil, err
+	}
+
+	// Retried calls with the same Idempotency-Key replay the first response
+	replay := &dapi.UpsertEntityResponse{}
+	if replayed, err := s.Pipeline.BeginIdempotentRequest(ctx, userId, "UpsertEntity", req, replay); err != nil {
+		return nil, err
+	} else if replayed {
+		return replay, nil
+	}
+	defer func() {
+		s.Pipeline.FinishIdempotentRequest(ctx, userId, "UpsertEntity", resp, err)
+	}()
+
+	prepared, err := s.prepareEntity(ctx, inputEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	// =====================================================
+	// Write into db
+	// =====================================================
+	var entity *model.Entity
+	var created bool
+	err = s.Pipeline.RunExclusiveTransaction(ctx, []string{req.GetEntityType()}, func(ctx context.Context) error {
+		entity, created, err = s.upsertEntity(ctx, col, req.GetEntityType(), prepared, userId, userRoles)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dapi.UpsertEntityResponse{Entity: entity, Created: created}, nil
+}
+
+// upsertEntity updates the entity matching the natural key of the prepared
+// entity, or creates it if there is none. It must run in a transaction locking
+// the collection exclusively so that concurrent upserts cannot both create it.
+func (s *EntityService) upsertEntity(ctx context.Context, col driver.Collection, entityType string, prepared *preparedEntity, userId string, userRoles []string) (*model.Entity, bool, error) {
+	logger := utils.GetLogger(ctx)
+
+	// Natural keys are stored normalized, as the prepared entity is
+	data, err := json.Marshal(prepared.entity)
+	if err != nil {
+		return nil, false, status.Errorf(codes.Internal, "failed to marshal entity data")
+	}
+	var inputMap map[string]interface{}
+	if err := json.Unmarshal(data, &inputMap); err != nil {
+		return nil, false, status.Errorf(codes.Internal, "failed to unmarshal entity data")
+	}
+
+	naturalKey, err := s.Pipeline.NaturalKey(entityType, inputMap)
+	if err != nil {
+		return nil, false, err
+	}
+	key, err := s.Pipeline.FindByNaturalKey(ctx, entityType, naturalKey)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":       err,
+			"entity_type": entityType,
+			"natural_key": naturalKey,
+		}).Error("failed to look up natural key")
+		return nil, false, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	if key == "" {
+		entity, err := s.createEntity(ctx, col, entityType, prepared, userId, userRoles)
+		return entity, true, err
+	}
+
+	// =====================================================
+	// Update the matching entity
+	// =====================================================
+	existingStruct, err := s.Pipeline.CreateEntityStruct(entityType)
+	if err != nil {
+		return nil, false, err
+	}
+	if _, err := s.Pipeline.ReadDocument(ctx, col, key, existingStruct); err != nil {
+		return nil, false, err
+	}
+	if err := s.Pipeline.CheckWritePermission(existingStruct, userId, userRoles); err != nil {
+		return nil, false, err
+	}
+
+	dataMap, err := s.dataMap(prepared, userId, false)
+	if err != nil {
+		return nil, false, err
+	}
+	delete(dataMap, "_id")
+	delete(dataMap, "_key")
+	delete(dataMap, "_rev")
+
+	updatedStruct, err := s.Pipeline.CreateEntityStruct(entityType)
+	if err != nil {
+		return nil, false, err
+	}
+	meta, err := s.Pipeline.UpdateDocument(ctx, col, key, dataMap, updatedStruct)
+	if err != nil {
+		return nil, false, err
+	}
+
+	s.Pipeline.SetEntityMeta(updatedStruct, meta.ID.String(), meta.Key, meta.Rev)
+	responseEntity, err := s.Pipeline.WrapEntityResponse(updatedStruct)
+	if err != nil {
+		return nil, false, err
+	}
+	return responseEntity, false, nil
+}
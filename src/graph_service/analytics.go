@@ -0,0 +1,388 @@
+package graphservice
+
+import (
+	"math"
+	"slices"
+)
+
+const (
+	pageRankDamping    = 0.85
+	pageRankIterations = 100
+	pageRankTolerance  = 1e-9
+	// Label propagation normally converges in a handful of rounds, stop
+	// oscillating graphs after this many
+	labelPropagationRounds = 100
+)
+
+// subgraph is an in-memory view of a materialized subgraph. Multiple
+// relationships between the same entities are kept as parallel edges.
+type subgraph struct {
+	ids   []string
+	index map[string]int
+	out   [][]int
+	in    [][]int
+	// Symmetric edge weights, a self-loop on i is stored as weight 2 in adj[i][i]
+	adj []map[int]float64
+}
+
+func newSubgraph(ids []string) *subgraph {
+	g := &subgraph{
+		ids:   ids,
+		index: make(map[string]int, len(ids)),
+		out:   make([][]int, len(ids)),
+		in:    make([][]int, len(ids)),
+		adj:   make([]map[int]float64, len(ids)),
+	}
+	for i, id := range ids {
+		g.index[id] = i
+		g.adj[i] = make(map[int]float64)
+	}
+	return g
+}
+
+// addEdge adds a relationship between two entities of the subgraph and
+// ignores relationships to entities outside of it.
+func (g *subgraph) addEdge(from string, to string) {
+	u, okFrom := g.index[from]
+	v, okTo := g.index[to]
+	if !okFrom || !okTo {
+		return
+	}
+	g.out[u] = append(g.out[u], v)
+	g.in[v] = append(g.in[v], u)
+	g.adj[u][v]++
+	g.adj[v][u]++
+}
+
+// neighbors returns the distinct neighbours of i in index order.
+func (g *subgraph) neighbors(i int) []int {
+	var result []int
+	for j := range g.adj[i] {
+		if j != i {
+			result = append(result, j)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+func (g *subgraph) degree() []int {
+	degrees := make([]int, len(g.ids))
+	for i := range g.ids {
+		degrees[i] = len(g.out[i]) + len(g.in[i])
+	}
+	return degrees
+}
+
+// betweenness computes normalized betweenness centrality with Brandes'
+// algorithm, treating relationships as undirected.
+func (g *subgraph) betweenness() []float64 {
+	n := len(g.ids)
+	scores := make([]float64, n)
+	neighbors := make([][]int, n)
+	for i := range neighbors {
+		neighbors[i] = g.neighbors(i)
+	}
+
+	for s := 0; s < n; s++ {
+		var stack []int
+		predecessors := make([][]int, n)
+		paths := make([]float64, n)
+		distance := make([]int, n)
+		for i := range distance {
+			distance[i] = -1
+		}
+		paths[s] = 1
+		distance[s] = 0
+
+		queue := []int{s}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			stack = append(stack, v)
+			for _, w := range neighbors[v] {
+				if distance[w] < 0 {
+					distance[w] = distance[v] + 1
+					queue = append(queue, w)
+				}
+				if distance[w] == distance[v]+1 {
+					paths[w] += paths[v]
+					predecessors[w] = append(predecessors[w], v)
+				}
+			}
+		}
+
+		dependency := make([]float64, n)
+		for len(stack) > 0 {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, v := range predecessors[w] {
+				dependency[v] += paths[v] / paths[w] * (1 + dependency[w])
+			}
+			if w != s {
+				scores[w] += dependency[w]
+			}
+		}
+	}
+
+	// Every undirected path was counted from both of its ends
+	if n > 2 {
+		scale := 1 / float64((n-1)*(n-2))
+		for i := range scores {
+			scores[i] *= scale
+		}
+	} else {
+		clear(scores)
+	}
+	return scores
+}
+
+// pageRank computes PageRank over the directed relationships. Entities without
+// outgoing relationships spread their rank evenly over the subgraph.
+func (g *subgraph) pageRank() []float64 {
+	n := len(g.ids)
+	if n == 0 {
+		return nil
+	}
+	ranks := make([]float64, n)
+	for i := range ranks {
+		ranks[i] = 1 / float64(n)
+	}
+
+	for iteration := 0; iteration < pageRankIterations; iteration++ {
+		dangling := 0.0
+		for i := range ranks {
+			if len(g.out[i]) == 0 {
+				dangling += ranks[i]
+			}
+		}
+
+		next := make([]float64, n)
+		base := (1-pageRankDamping)/float64(n) + pageRankDamping*dangling/float64(n)
+		for i := range next {
+			next[i] = base
+		}
+		for i, targets := range g.out {
+			share := pageRankDamping * ranks[i] / float64(len(targets))
+			for _, j := range targets {
+				next[j] += share
+			}
+		}
+
+		delta := 0.0
+		for i := range ranks {
+			delta += math.Abs(next[i] - ranks[i])
+		}
+		ranks = next
+		if delta < pageRankTolerance*float64(n) {
+			break
+		}
+	}
+	return ranks
+}
+
+// louvain detects communities by greedily moving entities between communities
+// to maximize modularity and then repeating on the graph of communities.
+func (g *subgraph) louvain() []int {
+	n := len(g.ids)
+	communities := make([]int, n)
+	for i := range communities {
+		communities[i] = i
+	}
+
+	adj := g.adj
+	for {
+		levelCommunities, moved := louvainLevel(adj)
+		if !moved {
+			break
+		}
+
+		count := 0
+		for _, c := range levelCommunities {
+			count = max(count, c+1)
+		}
+		for i, c := range communities {
+			communities[i] = levelCommunities[c]
+		}
+		if count == len(adj) {
+			break
+		}
+
+		// Collapse every community into a single node
+		aggregated := make([]map[int]float64, count)
+		for i := range aggregated {
+			aggregated[i] = make(map[int]float64)
+		}
+		for i, weights := range adj {
+			for j, w := range weights {
+				aggregated[levelCommunities[i]][levelCommunities[j]] += w
+			}
+		}
+		adj = aggregated
+	}
+	return communities
+}
+
+// louvainLevel runs the local moving phase of the Louvain method on one level
+// and returns the communities numbered from 0, and whether any node moved.
+func louvainLevel(adj []map[int]float64) ([]int, bool) {
+	n := len(adj)
+	degrees := make([]float64, n)
+	total := 0.0
+	for i, weights := range adj {
+		for _, w := range weights {
+			degrees[i] += w
+		}
+		total += degrees[i]
+	}
+
+	communities := make([]int, n)
+	for i := range communities {
+		communities[i] = i
+	}
+	if total == 0 {
+		return communities, false
+	}
+
+	communityDegrees := slices.Clone(degrees)
+	movedAny := false
+	for {
+		moved := false
+		for i := 0; i < n; i++ {
+			current := communities[i]
+			communityDegrees[current] -= degrees[i]
+
+			links := make(map[int]float64)
+			for j, w := range adj[i] {
+				if j != i {
+					links[communities[j]] += w
+				}
+			}
+			candidates := make([]int, 0, len(links))
+			for c := range links {
+				candidates = append(candidates, c)
+			}
+			slices.Sort(candidates)
+
+			best := current
+			bestGain := links[current] - communityDegrees[current]*degrees[i]/total
+			for _, c := range candidates {
+				gain := links[c] - communityDegrees[c]*degrees[i]/total
+				if gain > bestGain+1e-12 {
+					best = c
+					bestGain = gain
+				}
+			}
+
+			communityDegrees[best] += degrees[i]
+			if best != current {
+				communities[i] = best
+				moved = true
+				movedAny = true
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+	return renumberCommunities(communities), movedAny
+}
+
+// labelPropagation detects communities by repeatedly giving every entity the
+// label shared by most of its neighbours.
+func (g *subgraph) labelPropagation() []int {
+	n := len(g.ids)
+	labels := make([]int, n)
+	for i := range labels {
+		labels[i] = i
+	}
+
+	// Visit low degree entities first so that hubs adopt the label of their
+	// densest neighbourhood instead of flooding the subgraph with their own
+	degrees := g.degree()
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return degrees[a] - degrees[b]
+	})
+
+	for round := 0; round < labelPropagationRounds; round++ {
+		changed := false
+		for _, i := range order {
+			counts := make(map[int]float64)
+			for j, w := range g.adj[i] {
+				if j != i {
+					counts[labels[j]] += w
+				}
+			}
+			if len(counts) == 0 {
+				continue
+			}
+
+			// Keep the current label on ties, otherwise prefer the smallest label
+			maxCount := 0.0
+			for _, count := range counts {
+				maxCount = max(maxCount, count)
+			}
+			if counts[labels[i]] == maxCount {
+				continue
+			}
+			best := -1
+			for label, count := range counts {
+				if count == maxCount && (best < 0 || label < best) {
+					best = label
+				}
+			}
+			if best != labels[i] {
+				labels[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return renumberCommunities(labels)
+}
+
+// modularity measures how well the communities partition the subgraph.
+func (g *subgraph) modularity(communities []int) float64 {
+	total := 0.0
+	communityDegrees := make(map[int]float64)
+	internal := make(map[int]float64)
+	for i, weights := range g.adj {
+		for j, w := range weights {
+			total += w
+			communityDegrees[communities[i]] += w
+			if communities[i] == communities[j] {
+				internal[communities[i]] += w
+			}
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+
+	q := 0.0
+	for c, degree := range communityDegrees {
+		q += internal[c]/total - (degree/total)*(degree/total)
+	}
+	return q
+}
+
+// renumberCommunities numbers communities from 0 in order of first appearance.
+func renumberCommunities(communities []int) []int {
+	numbers := make(map[int]int)
+	result := make([]int, len(communities))
+	for i, c := range communities {
+		number, ok := numbers[c]
+		if !ok {
+			number = len(numbers)
+			numbers[c] = number
+		}
+		result[i] = number
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+package graphservice
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+const scoreTolerance = 1e-6
+
+// testGraph builds a subgraph of the entities "0".."n-1" with the given
+// directed relationships.
+func testGraph(n int, edges [][2]int) *subgraph {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = string(rune('0' + i))
+	}
+	g := newSubgraph(ids)
+	for _, edge := range edges {
+		g.addEdge(ids[edge[0]], ids[edge[1]])
+	}
+	return g
+}
+
+var (
+	// 0 -> 1 -> 2 -> 3
+	pathEdges = [][2]int{{0, 1}, {1, 2}, {2, 3}}
+	// Leaves 1..4 point at the hub 0
+	starEdges = [][2]int{{1, 0}, {2, 0}, {3, 0}, {4, 0}}
+	// Cliques 0..3 and 4..7 joined by the relationship 3 -> 4
+	twoCliqueEdges = [][2]int{
+		{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3},
+		{4, 5}, {4, 6}, {4, 7}, {5, 6}, {5, 7}, {6, 7},
+		{3, 4},
+	}
+)
+
+func assertScores(t *testing.T, got []float64, want []float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d scores, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > scoreTolerance {
+			t.Fatalf("got scores %v, want %v", got, want)
+		}
+	}
+}
+
+func TestBetweenness(t *testing.T) {
+	tests := []struct {
+		name  string
+		n     int
+		edges [][2]int
+		want  []float64
+	}{
+		{"path", 4, pathEdges, []float64{0, 2.0 / 3, 2.0 / 3, 0}},
+		{"star", 5, starEdges, []float64{1, 0, 0, 0, 0}},
+		// 3 and 4 each lie on the paths of 12 of the 21 pairs of other entities
+		{"two cliques", 8, twoCliqueEdges, []float64{0, 0, 0, 12.0 / 21, 12.0 / 21, 0, 0, 0}},
+		{"single relationship", 2, [][2]int{{0, 1}}, []float64{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertScores(t, testGraph(tt.n, tt.edges).betweenness(), tt.want)
+		})
+	}
+}
+
+func TestPageRank(t *testing.T) {
+	tests := []struct {
+		name  string
+		n     int
+		edges [][2]int
+		want  []float64
+	}{
+		{"cycle", 3, [][2]int{{0, 1}, {1, 2}, {2, 0}}, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}},
+		// The dangling end spreads its rank over both entities
+		{"single relationship", 2, [][2]int{{0, 1}}, []float64{0.5 / 1.425, 1 - 0.5/1.425}},
+		{"star", 5, starEdges, []float64{11.0 / 21, 5.0 / 42, 5.0 / 42, 5.0 / 42, 5.0 / 42}},
+		{"no relationships", 2, nil, []float64{0.5, 0.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranks := testGraph(tt.n, tt.edges).pageRank()
+			assertScores(t, ranks, tt.want)
+		})
+	}
+}
+
+func TestCommunities(t *testing.T) {
+	tests := []struct {
+		name  string
+		n     int
+		edges [][2]int
+		want  []int
+		// Modularity of the expected communities
+		modularity float64
+	}{
+		{"two cliques", 8, twoCliqueEdges, []int{0, 0, 0, 0, 1, 1, 1, 1}, 2 * (12.0/26 - 0.25)},
+		{"star", 5, starEdges, []int{0, 0, 0, 0, 0}, 0},
+		{"disconnected pairs", 4, [][2]int{{0, 1}, {2, 3}}, []int{0, 0, 1, 1}, 0.5},
+		{"isolated entities", 3, nil, []int{0, 1, 2}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := testGraph(tt.n, tt.edges)
+			if got := g.louvain(); !slices.Equal(renumberCommunities(got), tt.want) {
+				t.Errorf("louvain got %v, want %v", got, tt.want)
+			}
+			if got := g.labelPropagation(); !slices.Equal(got, tt.want) {
+				t.Errorf("label propagation got %v, want %v", got, tt.want)
+			}
+			if got := g.modularity(tt.want); math.Abs(got-tt.modularity) > scoreTolerance {
+				t.Errorf("modularity got %v, want %v", got, tt.modularity)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package graphservice
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	DefaultAnalyzeDepth = 2
+	MaxAnalyzeDepth     = 4
+	// Betweenness is quadratic in the subgraph size, keep the budget lower
+	// than for plain expansions
+	DefaultAnalyzeBudget = 1000
+	MaxAnalyzeBudget     = 3000
+)
+
+type analyzeResult struct {
+	pipeline.QueryResult
+	EntityIds []string `json:"entity_ids"`
+	StartIds  []string `json:"start_ids"`
+	Truncated bool     `json:"truncated"`
+}
+
+func (s *GraphService) AnalyzeSubgraph(ctx context.Context, req *dapi.AnalyzeSubgraphRequest) (*dapi.AnalyzeSubgraphResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.WithFields(logrus.Fields{
+		"entity_ids": req.GetEntityIds(),
+	}).Infof("[%s, %v] requests to analyze subgraph", userId, userRoles)
+
+	// =====================================================
+	// Validate request
+	// =====================================================
+	if len(req.GetEntityIds()) > MaxStartNodes {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d entity ids are allowed", MaxStartNodes)
+	}
+	for _, id := range req.GetEntityIds() {
+		if _, err := s.Pipeline.ResolveEndpoint(ctx, id, userId, userRoles); err != nil {
+			return nil, err
+		}
+	}
+
+	depth := req.GetDepth()
+	if depth <= 0 {
+		depth = DefaultAnalyzeDepth
+	}
+	if depth > MaxAnalyzeDepth {
+		return nil, status.Errorf(codes.InvalidArgument, "depth must not exceed %d", MaxAnalyzeDepth)
+	}
+
+	nodeBudget := req.GetNodeBudget()
+	if nodeBudget <= 0 {
+		nodeBudget = DefaultAnalyzeBudget
+	}
+	if nodeBudget > MaxAnalyzeBudget {
+		return nil, status.Errorf(codes.InvalidArgument, "node budget must not exceed %d", MaxAnalyzeBudget)
+	}
+
+	aggregation, err := s.Pipeline.CheckConfidenceOptions(req.GetMinConfidence(), req.GetConfidenceAggregation())
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.GetCommunityAlgorithm() {
+	case "", "louvain", "label_propagation":
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid community algorithm: %s", req.GetCommunityAlgorithm())
+	}
+
+	// =====================================================
+	// Materialize subgraph
+	// =====================================================
+	blocked := `(
+					(e != null AND NOT ` + pipeline.ConfidenceFilter("e") + `) OR
+					(e != null AND NOT ` + pipeline.ValidityFilter("e") + `) OR
+					(e != null AND NOT ` + pipeline.ReadFilter("e") + `) OR
+					NOT ` + pipeline.ReadFilter("v") + `
+				)`
+
+	query := pipeline.GrantedIdsQuery + `
+		LET start_nodes = LENGTH(@entityIds) > 0 ? @entityIds : (
+			FOR e IN event
+			FILTER e.happened_at >= @startTime AND e.happened_at <= @endTime
+			FILTER (@countryCode == "" OR e.location.country_code == @countryCode)
+			FILTER (@tag == ""
+				OR @tag IN e.tags
+				OR (IS_DOCUMENT(e.attributes) AND LENGTH(
+					FOR lang IN ATTRIBUTES(e.attributes)
+					FILTER IS_LIST(e.attributes[lang].Tags) AND @tag IN e.attributes[lang].Tags
+					RETURN 1
+				) > 0)
+			)
+			SORT e.happened_at DESC
+			RETURN e._id
+		)
+
+		LET candidates = (
+			FOR start_node IN start_nodes
+				FOR v, e, p IN 0..@depth ANY start_node GRAPH @graphName
+				PRUNE ` + blocked + `
+				OPTIONS {uniqueVertices: 'global', order: 'bfs'}
+				FILTER NOT ` + blocked + `
+				RETURN { v: v, depth: LENGTH(p.edges) }
+		)
+
+		LET ranked_nodes = (
+			FOR c IN candidates
+			COLLECT id = c.v._id INTO group = c
+			LET depth = MIN(group[*].depth)
+			SORT depth ASC
+			RETURN group[0].v
+		)
+		LET traversed_nodes = SLICE(ranked_nodes, 0, @nodeBudget)
+
+		LET relations = (
+			FOR id IN traversed_nodes[*]._id
+				FOR v, e IN 1..1 ANY id GRAPH @graphName
+				FILTER v._id IN traversed_nodes[*]._id
+				FILTER ` + pipeline.ReadFilter("e") + `
+				FILTER ` + pipeline.ConfidenceFilter("e") + `
+				FILTER ` + pipeline.ValidityFilter("e") + `
+				RETURN DISTINCT e
+		)
+
+		RETURN {
+			entities: (
+				FOR doc IN traversed_nodes
+				RETURN { type: PARSE_IDENTIFIER(doc._id).collection, data: doc }
+			),
+			entity_ids: traversed_nodes[*]._id,
+			start_ids: INTERSECTION(start_nodes, traversed_nodes[*]._id),
+			relations: relations,
+			truncated: LENGTH(ranked_nodes) > @nodeBudget
+		}
+	`
+
+	entityIds := req.GetEntityIds()
+	if entityIds == nil {
+		entityIds = []string{}
+	}
+	bindVars := map[string]interface{}{
+		"entityIds":     entityIds,
+		"startTime":     req.GetStartTime(),
+		"endTime":       req.GetEndTime(),
+		"countryCode":   req.GetCountryCode(),
+		"tag":           s.Pipeline.NormalizeTagFilter(req.GetTag()),
+		"depth":         depth,
+		"nodeBudget":    nodeBudget,
+		"minConfidence": req.GetMinConfidence(),
+		"userId":        userId,
+		"userRoles":     userRoles,
+	}
+	s.Pipeline.AddGrantBindVars(ctx, bindVars, userId, userRoles)
+	if err := s.Pipeline.AddValidityBindVars(bindVars, req.GetAsOf(), req.GetWindowStart(), req.GetWindowEnd()); err != nil {
+		return nil, err
+	}
+
+	cursor, err := s.DBClient.DB.Query(ctx, query, bindVars)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"query": query,
+			"vars":  bindVars,
+		}).Error("failed to execute AQL query")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	defer cursor.Close()
+
+	var result analyzeResult
+	_, err = cursor.ReadDocument(ctx, &result)
+	if err != nil {
+		if driver.IsNoMoreDocuments(err) {
+			return &dapi.AnalyzeSubgraphResponse{}, nil
+		}
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to read query result")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	pbEntities, pbRelations := s.Pipeline.ProcessEntities(ctx, result.Entities, result.Relations)
+
+	// =====================================================
+	// Compute scores
+	// =====================================================
+	graph := newSubgraph(result.EntityIds)
+	for _, relation := range pbRelations {
+		graph.addEdge(relation.GetFrom(), relation.GetTo())
+	}
+
+	var communities []int
+	if req.GetCommunityAlgorithm() == "label_propagation" {
+		communities = graph.labelPropagation()
+	} else {
+		communities = graph.louvain()
+	}
+
+	degrees := graph.degree()
+	betweenness := graph.betweenness()
+	pageRanks := graph.pageRank()
+
+	var scores []*dapi.EntityScore
+	communityCount := 0
+	for i, id := range graph.ids {
+		scores = append(scores, &dapi.EntityScore{
+			EntityId:    id,
+			Degree:      int32(degrees[i]),
+			Betweenness: betweenness[i],
+			Pagerank:    pageRanks[i],
+			Community:   int32(communities[i]),
+		})
+		communityCount = max(communityCount, communities[i]+1)
+	}
+
+	return &dapi.AnalyzeSubgraphResponse{
+		Entities:       pbEntities,
+		Relations:      pbRelations,
+		Scores:         scores,
+		CommunityCount: int32(communityCount),
+		Modularity:     graph.modularity(communities),
+		Truncated:      result.Truncated,
+		PathConfidence: pipeline.PathConfidences(result.StartIds, pbRelations, "ANY", int(depth), aggregation, nil),
+	}, nil
+}
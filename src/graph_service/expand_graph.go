@@ -0,0 +1,203 @@
+package graphservice
+
+import (
+	"context"
+	"slices"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	MaxStartNodes      = 100
+	DefaultExpandDepth = 1
+	MaxExpandDepth     = 5
+	DefaultNodeBudget  = 500
+	MaxNodeBudget      = 5000
+)
+
+type expandResult struct {
+	pipeline.QueryResult
+	Truncated bool `json:"truncated"`
+}
+
+func (s *GraphService) ExpandGraph(ctx context.Context, req *dapi.ExpandGraphRequest) (*dapi.ExpandGraphResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.WithFields(logrus.Fields{
+		"start_nodes": req.GetStartNodes(),
+	}).Infof("[%s, %v] requests to expand graph", userId, userRoles)
+
+	// =====================================================
+	// Validate request
+	// =====================================================
+	if len(req.GetStartNodes()) == 0 || len(req.GetStartNodes()) > MaxStartNodes {
+		return nil, status.Errorf(codes.InvalidArgument, "between 1 and %d start nodes are required", MaxStartNodes)
+	}
+	for _, id := range req.GetStartNodes() {
+		if _, err := s.Pipeline.ResolveEndpoint(ctx, id, userId, userRoles); err != nil {
+			return nil, err
+		}
+	}
+
+	direction, err := s.Pipeline.TraversalDirection(req.GetDirection())
+	if err != nil {
+		return nil, err
+	}
+
+	depth := req.GetDepth()
+	if depth <= 0 {
+		depth = DefaultExpandDepth
+	}
+	if depth > MaxExpandDepth {
+		return nil, status.Errorf(codes.InvalidArgument, "depth must not exceed %d", MaxExpandDepth)
+	}
+
+	nodeBudget := req.GetNodeBudget()
+	if nodeBudget <= 0 {
+		nodeBudget = DefaultNodeBudget
+	}
+	if nodeBudget > MaxNodeBudget {
+		return nil, status.Errorf(codes.InvalidArgument, "node budget must not exceed %d", MaxNodeBudget)
+	}
+
+	hopRelations := make([][]string, len(req.GetHopFilters()))
+	for i, hop := range req.GetHopFilters() {
+		hopRelations[i] = []string{}
+		for _, name := range hop.GetRelations() {
+			relationName, err := s.Pipeline.NormalizeRelationName(name)
+			if err != nil {
+				return nil, err
+			}
+			hopRelations[i] = append(hopRelations[i], relationName)
+		}
+	}
+
+	aggregation, err := s.Pipeline.CheckConfidenceOptions(req.GetMinConfidence(), req.GetConfidenceAggregation())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entityType := range req.GetVertexTypes() {
+		if !slices.Contains(pipeline.EntityTypes, entityType) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s is not an entity collection", entityType)
+		}
+	}
+
+	// =====================================================
+	// Expand graph
+	// =====================================================
+	// Traversals stop at relations the hop filters, the confidence threshold or
+	// the validity window reject and at anything the caller cannot read, so hidden entities never
+	// connect visible ones.
+	blocked := `(
+					(e != null AND LENGTH(@hopRelations[LENGTH(p.edges) - 1]) > 0 AND e.name NOT IN @hopRelations[LENGTH(p.edges) - 1]) OR
+					(e != null AND NOT ` + pipeline.ConfidenceFilter("e") + `) OR
+					(e != null AND NOT ` + pipeline.ValidityFilter("e") + `) OR
+					(e != null AND NOT ` + pipeline.ReadFilter("e") + `) OR
+					NOT ` + pipeline.ReadFilter("v") + `
+				)`
+
+	query := pipeline.GrantedIdsQuery + `
+		LET candidates = (
+			FOR start_node IN @startNodes
+				FOR v, e, p IN 0..@depth ` + direction + ` start_node GRAPH @graphName
+				PRUNE ` + blocked + `
+				OPTIONS {uniqueVertices: 'global', order: 'bfs'}
+				FILTER NOT ` + blocked + `
+				FILTER (LENGTH(@vertexTypes) == 0 OR PARSE_IDENTIFIER(v._id).collection IN @vertexTypes OR v._id IN @startNodes)
+				RETURN { v: v, depth: LENGTH(p.edges) }
+		)
+
+		// Keep the entities closest to any start node within the budget
+		LET ranked_nodes = (
+			FOR c IN candidates
+			COLLECT id = c.v._id INTO group = c
+			LET depth = MIN(group[*].depth)
+			SORT depth ASC
+			RETURN group[0].v
+		)
+		LET traversed_nodes = SLICE(ranked_nodes, 0, @nodeBudget)
+
+		LET relations = (
+			FOR id IN traversed_nodes[*]._id
+				FOR v, e IN 1..1 ANY id GRAPH @graphName
+				FILTER v._id IN traversed_nodes[*]._id
+				FILTER ` + pipeline.ReadFilter("e") + `
+				FILTER ` + pipeline.ConfidenceFilter("e") + `
+				FILTER ` + pipeline.ValidityFilter("e") + `
+				RETURN DISTINCT e
+		)
+
+		RETURN {
+			entities: (
+				FOR doc IN traversed_nodes
+				RETURN { type: PARSE_IDENTIFIER(doc._id).collection, data: doc }
+			),
+			relations: relations,
+			truncated: LENGTH(ranked_nodes) > @nodeBudget
+		}
+	`
+
+	vertexTypes := req.GetVertexTypes()
+	if vertexTypes == nil {
+		vertexTypes = []string{}
+	}
+	bindVars := map[string]interface{}{
+		"startNodes":    req.GetStartNodes(),
+		"depth":         depth,
+		"hopRelations":  hopRelations,
+		"vertexTypes":   vertexTypes,
+		"nodeBudget":    nodeBudget,
+		"minConfidence": req.GetMinConfidence(),
+		"userId":        userId,
+		"userRoles":     userRoles,
+	}
+	s.Pipeline.AddGrantBindVars(ctx, bindVars, userId, userRoles)
+	if err := s.Pipeline.AddValidityBindVars(bindVars, req.GetAsOf(), req.GetWindowStart(), req.GetWindowEnd()); err != nil {
+		return nil, err
+	}
+
+	cursor, err := s.DBClient.DB.Query(ctx, query, bindVars)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"query": query,
+			"vars":  bindVars,
+		}).Error("failed to execute AQL query")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	defer cursor.Close()
+
+	var result expandResult
+	_, err = cursor.ReadDocument(ctx, &result)
+	if err != nil {
+		if driver.IsNoMoreDocuments(err) {
+			return &dapi.ExpandGraphResponse{}, nil
+		}
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to read query result")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	pbEntities, pbRelations := s.Pipeline.ProcessEntities(ctx, result.Entities, result.Relations)
+
+	return &dapi.ExpandGraphResponse{
+		Entities:  pbEntities,
+		Relations: pbRelations,
+		Truncated: result.Truncated,
+		PathConfidence: pipeline.PathConfidences(
+			req.GetStartNodes(), pbRelations, direction, int(depth), aggregation, hopRelations,
+		),
+	}, nil
+}
@@ -0,0 +1,444 @@
+package graphservice
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	ExportFormatGraphML   = "graphml"
+	ExportFormatGEXF      = "gexf"
+	ExportFormatCytoscape = "cytoscape"
+)
+
+// Entity fields that are not exported as node attributes. The id identifies
+// the node and the permissions are internal.
+var exportSkippedFields = []string{"id", "key", "rev", "owner", "read", "write"}
+
+// Fields used as the label of a node, in order of preference
+var exportLabelFields = []string{"name", "title", "url"}
+
+// Node attributes added to the entity fields
+const (
+	exportEntityTypeAttr = "entity_type"
+	exportLabelAttr      = "label"
+)
+
+// exportAttr is a node or edge attribute with the type of its values.
+type exportAttr struct {
+	name string
+	// One of "string", "long", "double" or "boolean"
+	kind string
+}
+
+type exportNode struct {
+	id    string
+	label string
+	attrs map[string]interface{}
+}
+
+type exportEdge struct {
+	id         string
+	source     string
+	target     string
+	name       string
+	confidence int32
+	label      string
+}
+
+// exportGraph is a subgraph flattened for serialization: the fields of each
+// entity become node attributes, nested fields are named by their dotted path
+// and list values are joined with pipeline.CSVListSeparator.
+type exportGraph struct {
+	nodes     []*exportNode
+	edges     []*exportEdge
+	nodeAttrs []exportAttr
+}
+
+var exportEdgeAttrs = []exportAttr{
+	{name: "name", kind: "string"},
+	{name: "confidence", kind: "long"},
+	{name: "label", kind: "string"},
+}
+
+func newExportGraph(entities []*model.Entity, relations []*model.Relation) *exportGraph {
+	g := &exportGraph{}
+	kinds := map[string]string{}
+
+	for _, entity := range entities {
+		oneof := entity.ProtoReflect().WhichOneof(entity.ProtoReflect().Descriptor().Oneofs().ByName("entity"))
+		if oneof == nil {
+			continue
+		}
+		message := entity.ProtoReflect().Get(oneof).Message()
+
+		node := &exportNode{attrs: map[string]interface{}{}}
+		flattenExportMessage(node.attrs, "", message)
+		for _, name := range exportSkippedFields {
+			delete(node.attrs, name)
+		}
+		node.attrs[exportEntityTypeAttr] = string(oneof.Name())
+
+		if id := message.Descriptor().Fields().ByName("id"); id != nil {
+			node.id = message.Get(id).String()
+		}
+		node.label = node.id
+		for _, name := range exportLabelFields {
+			if value, ok := node.attrs[name].(string); ok && value != "" {
+				node.label = value
+				break
+			}
+		}
+		node.attrs[exportLabelAttr] = node.label
+
+		// An attribute whose values have different types is exported as text
+		for name, value := range node.attrs {
+			kind := exportKind(value)
+			if previous, ok := kinds[name]; ok && previous != kind {
+				kind = "string"
+			}
+			kinds[name] = kind
+		}
+		g.nodes = append(g.nodes, node)
+	}
+
+	for name, kind := range kinds {
+		g.nodeAttrs = append(g.nodeAttrs, exportAttr{name: name, kind: kind})
+	}
+	sort.Slice(g.nodeAttrs, func(i, j int) bool { return g.nodeAttrs[i].name < g.nodeAttrs[j].name })
+
+	for _, relation := range relations {
+		g.edges = append(g.edges, &exportEdge{
+			id:         relation.GetId(),
+			source:     relation.GetFrom(),
+			target:     relation.GetTo(),
+			name:       relation.GetName(),
+			confidence: relation.GetConfidence(),
+			label:      relation.GetLabel(),
+		})
+	}
+	return g
+}
+
+// flattenExportMessage adds the set fields of a message to attrs, prefixing
+// their names with the path of the message.
+func flattenExportMessage(attrs map[string]interface{}, prefix string, message protoreflect.Message) {
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		name := prefix + string(field.Name())
+		switch {
+		case field.IsMap():
+			// Entities have no map fields besides free-form attributes
+		case field.IsList():
+			var items []string
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				items = append(items, fmt.Sprint(exportScalar(field, list.Get(i))))
+			}
+			attrs[name] = strings.Join(items, pipeline.CSVListSeparator)
+		case field.Kind() == protoreflect.MessageKind:
+			if structValue, ok := value.Message().Interface().(*structpb.Struct); ok {
+				flattenExportValue(attrs, name, structValue.AsMap())
+			} else {
+				flattenExportMessage(attrs, name+".", value.Message())
+			}
+		default:
+			attrs[name] = exportScalar(field, value)
+		}
+		return true
+	})
+}
+
+// flattenExportValue adds a free-form attribute value to attrs.
+func flattenExportValue(attrs map[string]interface{}, name string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			flattenExportValue(attrs, name+"."+key, nested)
+		}
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			if text, ok := item.(string); ok {
+				items = append(items, text)
+			} else {
+				data, _ := json.Marshal(item)
+				items = append(items, string(data))
+			}
+		}
+		attrs[name] = strings.Join(items, pipeline.CSVListSeparator)
+	case nil:
+	default:
+		attrs[name] = v
+	}
+}
+
+func exportScalar(field protoreflect.FieldDescriptor, value protoreflect.Value) interface{} {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return value.Bool()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return value.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return int64(value.Uint())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return value.Float()
+	case protoreflect.EnumKind:
+		if enum := field.Enum().Values().ByNumber(value.Enum()); enum != nil {
+			return string(enum.Name())
+		}
+		return strconv.Itoa(int(value.Enum()))
+	default:
+		return value.String()
+	}
+}
+
+func exportKind(value interface{}) string {
+	switch value.(type) {
+	case bool:
+		return "boolean"
+	case int64:
+		return "long"
+	case float64:
+		return "double"
+	default:
+		return "string"
+	}
+}
+
+func exportText(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// =====================================================
+// GraphML
+// =====================================================
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	Id       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	Id   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Id     string        `xml:"id,attr,omitempty"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func (g *exportGraph) graphML() ([]byte, error) {
+	doc := graphMLDocument{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Graph: graphMLGraph{EdgeDefault: "directed"},
+	}
+	for i, attr := range g.nodeAttrs {
+		doc.Keys = append(doc.Keys, graphMLKey{Id: fmt.Sprintf("n%d", i), For: "node", AttrName: attr.name, AttrType: attr.kind})
+	}
+	for i, attr := range exportEdgeAttrs {
+		doc.Keys = append(doc.Keys, graphMLKey{Id: fmt.Sprintf("e%d", i), For: "edge", AttrName: attr.name, AttrType: attr.kind})
+	}
+
+	for _, node := range g.nodes {
+		element := graphMLNode{Id: node.id}
+		for i, attr := range g.nodeAttrs {
+			if value, ok := node.attrs[attr.name]; ok {
+				element.Data = append(element.Data, graphMLData{Key: fmt.Sprintf("n%d", i), Value: exportText(value)})
+			}
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, element)
+	}
+	for _, edge := range g.edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Id:     edge.id,
+			Source: edge.source,
+			Target: edge.target,
+			Data: []graphMLData{
+				{Key: "e0", Value: edge.name},
+				{Key: "e1", Value: strconv.Itoa(int(edge.confidence))},
+				{Key: "e2", Value: edge.label},
+			},
+		})
+	}
+	return marshalExportXML(doc)
+}
+
+// =====================================================
+// GEXF
+// =====================================================
+
+type gexfDocument struct {
+	XMLName xml.Name  `xml:"gexf"`
+	Xmlns   string    `xml:"xmlns,attr"`
+	Version string    `xml:"version,attr"`
+	Graph   gexfGraph `xml:"graph"`
+}
+
+type gexfGraph struct {
+	DefaultEdgeType string           `xml:"defaultedgetype,attr"`
+	Attributes      []gexfAttributes `xml:"attributes"`
+	Nodes           []gexfNode       `xml:"nodes>node"`
+	Edges           []gexfEdge       `xml:"edges>edge"`
+}
+
+type gexfAttributes struct {
+	Class      string          `xml:"class,attr"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttribute struct {
+	Id    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfNode struct {
+	Id        string         `xml:"id,attr"`
+	Label     string         `xml:"label,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfEdge struct {
+	Id        string         `xml:"id,attr"`
+	Source    string         `xml:"source,attr"`
+	Target    string         `xml:"target,attr"`
+	Label     string         `xml:"label,attr,omitempty"`
+	Weight    int32          `xml:"weight,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfAttValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// The relation confidence is also the weight of the edge, which Gephi uses
+// for layouts and metrics
+func (g *exportGraph) gexf() ([]byte, error) {
+	doc := gexfDocument{
+		Xmlns:   "http://gexf.net/1.3",
+		Version: "1.3",
+		Graph:   gexfGraph{DefaultEdgeType: "directed"},
+	}
+
+	nodeAttrs := gexfAttributes{Class: "node"}
+	for i, attr := range g.nodeAttrs {
+		nodeAttrs.Attributes = append(nodeAttrs.Attributes, gexfAttribute{Id: fmt.Sprintf("n%d", i), Title: attr.name, Type: attr.kind})
+	}
+	edgeAttrs := gexfAttributes{Class: "edge"}
+	for i, attr := range exportEdgeAttrs {
+		edgeAttrs.Attributes = append(edgeAttrs.Attributes, gexfAttribute{Id: fmt.Sprintf("e%d", i), Title: attr.name, Type: attr.kind})
+	}
+	doc.Graph.Attributes = []gexfAttributes{nodeAttrs, edgeAttrs}
+
+	for _, node := range g.nodes {
+		element := gexfNode{Id: node.id, Label: node.label}
+		for i, attr := range g.nodeAttrs {
+			if value, ok := node.attrs[attr.name]; ok {
+				element.AttValues = append(element.AttValues, gexfAttValue{For: fmt.Sprintf("n%d", i), Value: exportText(value)})
+			}
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, element)
+	}
+	for _, edge := range g.edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, gexfEdge{
+			Id:     edge.id,
+			Source: edge.source,
+			Target: edge.target,
+			Label:  edge.name,
+			Weight: edge.confidence,
+			AttValues: []gexfAttValue{
+				{For: "e0", Value: edge.name},
+				{For: "e1", Value: strconv.Itoa(int(edge.confidence))},
+				{For: "e2", Value: edge.label},
+			},
+		})
+	}
+	return marshalExportXML(doc)
+}
+
+func marshalExportXML(doc interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buffer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	buffer.WriteString("\n")
+	return buffer.Bytes(), nil
+}
+
+// =====================================================
+// Cytoscape.js
+// =====================================================
+
+// cytoscape returns the elements JSON accepted by cy.add and cytoscape({elements}).
+func (g *exportGraph) cytoscape() ([]byte, error) {
+	nodes := []map[string]interface{}{}
+	for _, node := range g.nodes {
+		data := map[string]interface{}{"id": node.id}
+		for name, value := range node.attrs {
+			data[name] = value
+		}
+		nodes = append(nodes, map[string]interface{}{"data": data})
+	}
+
+	edges := []map[string]interface{}{}
+	for _, edge := range g.edges {
+		edges = append(edges, map[string]interface{}{"data": map[string]interface{}{
+			"id":         edge.id,
+			"source":     edge.source,
+			"target":     edge.target,
+			"name":       edge.name,
+			"confidence": edge.confidence,
+			"label":      edge.label,
+		}})
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"elements": map[string]interface{}{
+			"nodes": nodes,
+			"edges": edges,
+		},
+	})
+}
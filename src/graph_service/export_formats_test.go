@@ -0,0 +1,93 @@
+package graphservice
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"slices"
+	"testing"
+)
+
+// testExportGraph has labels and attribute values that need escaping.
+func testExportGraph() *exportGraph {
+	return &exportGraph{
+		nodes: []*exportNode{
+			{id: "person/1", label: "A & B", attrs: map[string]interface{}{"label": "A & B", "age": int64(40)}},
+			{id: "organization/2", label: `<Acme "Ltd">`, attrs: map[string]interface{}{"label": `<Acme "Ltd">`}},
+		},
+		edges: []*exportEdge{
+			{id: "person_member_organization/3", source: "person/1", target: "organization/2", name: "member", confidence: 80, label: "board"},
+		},
+		nodeAttrs: []exportAttr{{name: "age", kind: "long"}, {name: "label", kind: "string"}},
+	}
+}
+
+func TestGraphMLParsesBack(t *testing.T) {
+	data, err := testExportGraph().graphML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc graphMLDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid GraphML: %v\n%s", err, data)
+	}
+
+	if len(doc.Keys) != 5 {
+		t.Errorf("got %d keys, want 5", len(doc.Keys))
+	}
+	nodes := doc.Graph.Nodes
+	if len(nodes) != 2 || nodes[0].Id != "person/1" || nodes[1].Id != "organization/2" {
+		t.Fatalf("got nodes %+v", nodes)
+	}
+	if want := []graphMLData{{Key: "n0", Value: "40"}, {Key: "n1", Value: "A & B"}}; !slices.Equal(nodes[0].Data, want) {
+		t.Errorf("got node data %+v, want %+v", nodes[0].Data, want)
+	}
+	if want := []graphMLData{{Key: "n1", Value: `<Acme "Ltd">`}}; !slices.Equal(nodes[1].Data, want) {
+		t.Errorf("got node data %+v, want %+v", nodes[1].Data, want)
+	}
+
+	edges := doc.Graph.Edges
+	if len(edges) != 1 || edges[0].Source != "person/1" || edges[0].Target != "organization/2" {
+		t.Fatalf("got edges %+v", edges)
+	}
+	if want := []graphMLData{{Key: "e0", Value: "member"}, {Key: "e1", Value: "80"}, {Key: "e2", Value: "board"}}; !slices.Equal(edges[0].Data, want) {
+		t.Errorf("got edge data %+v, want %+v", edges[0].Data, want)
+	}
+}
+
+func TestCytoscapeParsesBack(t *testing.T) {
+	data, err := testExportGraph().cytoscape()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Elements struct {
+			Nodes []struct {
+				Data map[string]interface{} `json:"data"`
+			} `json:"nodes"`
+			Edges []struct {
+				Data map[string]interface{} `json:"data"`
+			} `json:"edges"`
+		} `json:"elements"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid Cytoscape JSON: %v\n%s", err, data)
+	}
+
+	nodes := doc.Elements.Nodes
+	if len(nodes) != 2 || nodes[0].Data["id"] != "person/1" || nodes[0].Data["label"] != "A & B" || nodes[0].Data["age"] != float64(40) {
+		t.Fatalf("got nodes %+v", nodes)
+	}
+	if nodes[1].Data["id"] != "organization/2" || nodes[1].Data["label"] != `<Acme "Ltd">` {
+		t.Errorf("got node %+v", nodes[1])
+	}
+
+	edges := doc.Elements.Edges
+	if len(edges) != 1 {
+		t.Fatalf("got edges %+v", edges)
+	}
+	edge := edges[0].Data
+	if edge["id"] != "person_member_organization/3" || edge["source"] != "person/1" || edge["target"] != "organization/2" ||
+		edge["name"] != "member" || edge["confidence"] != float64(80) || edge["label"] != "board" {
+		t.Errorf("got edge %+v", edge)
+	}
+}
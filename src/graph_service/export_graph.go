@@ -0,0 +1,268 @@
+package graphservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Content type and file extension of each export format
+var exportFormats = map[string]struct {
+	contentType string
+	extension   string
+}{
+	ExportFormatGraphML:   {contentType: "application/graphml+xml", extension: "graphml"},
+	ExportFormatGEXF:      {contentType: "application/gexf+xml", extension: "gexf"},
+	ExportFormatCytoscape: {contentType: "application/json", extension: "cyjs"},
+}
+
+func (s *GraphService) ExportGraph(ctx context.Context, req *dapi.ExportGraphRequest) (*httpbody.HttpBody, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.WithFields(logrus.Fields{
+		"format":   req.GetFormat(),
+		"root_ids": req.GetRootIds(),
+	}).Infof("[%s, %v] requests to export graph", userId, userRoles)
+
+	// =====================================================
+	// Validate request
+	// =====================================================
+	format := req.GetFormat()
+	if format == "" {
+		format = ExportFormatGraphML
+	}
+	exportFormat, ok := exportFormats[format]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid format: %s", req.GetFormat())
+	}
+
+	pbEntities, pbRelations, truncated, err := s.exportSubgraph(ctx, exportSelection{
+		rootIds:       req.GetRootIds(),
+		startNode:     req.GetStartNode(),
+		startTime:     req.GetStartTime(),
+		endTime:       req.GetEndTime(),
+		countryCode:   req.GetCountryCode(),
+		tag:           s.Pipeline.NormalizeTagFilter(req.GetTag()),
+		depth:         req.GetDepth(),
+		nodeBudget:    req.GetNodeBudget(),
+		minConfidence: req.GetMinConfidence(),
+		asOf:          req.GetAsOf(),
+		windowStart:   req.GetWindowStart(),
+		windowEnd:     req.GetWindowEnd(),
+	}, userId, userRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	// =====================================================
+	// Serialize
+	// =====================================================
+	graph := newExportGraph(pbEntities, pbRelations)
+	var data []byte
+	switch format {
+	case ExportFormatGEXF:
+		data, err = graph.gexf()
+	case ExportFormatCytoscape:
+		data, err = graph.cytoscape()
+	default:
+		data, err = graph.graphML()
+	}
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":  err,
+			"format": format,
+		}).Error("failed to serialize graph")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	// Let browsers save the export as a file, the gateway forwards this header
+	header := metadata.Pairs(
+		utils.ContentDispositionHeader, fmt.Sprintf(`attachment; filename="graph.%s"`, exportFormat.extension),
+		utils.GraphTruncatedHeader, fmt.Sprint(truncated),
+	)
+	if err := grpc.SetHeader(ctx, header); err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Warn("failed to set export headers")
+	}
+
+	return &httpbody.HttpBody{
+		ContentType: exportFormat.contentType,
+		Data:        data,
+	}, nil
+}
+
+// exportSelection selects the subgraph of an export: the entities around the
+// root ids, or around the events matching the event filters as in
+// ListEntitiesFromEvent.
+type exportSelection struct {
+	rootIds       []string
+	startNode     string
+	startTime     int64
+	endTime       int64
+	countryCode   string
+	tag           string
+	depth         int32
+	nodeBudget    int32
+	minConfidence int32
+	asOf          int64
+	windowStart   int64
+	windowEnd     int64
+}
+
+// exportSubgraph returns the entities and relations of a selection readable
+// by the user, and whether the node budget cut the subgraph short.
+func (s *GraphService) exportSubgraph(ctx context.Context, sel exportSelection, userId string, userRoles []string) ([]*model.Entity, []*model.Relation, bool, error) {
+	logger := utils.GetLogger(ctx)
+
+	rootIds := sel.rootIds
+	if len(rootIds) > MaxStartNodes {
+		return nil, nil, false, status.Errorf(codes.InvalidArgument, "at most %d root ids are allowed", MaxStartNodes)
+	}
+	if len(rootIds) == 0 && sel.startNode != "" {
+		rootIds = []string{sel.startNode}
+	}
+	for _, id := range rootIds {
+		if _, err := s.Pipeline.ResolveEndpoint(ctx, id, userId, userRoles); err != nil {
+			return nil, nil, false, err
+		}
+	}
+	if rootIds == nil {
+		rootIds = []string{}
+	}
+
+	if sel.depth < 0 || sel.depth > MaxExpandDepth {
+		return nil, nil, false, status.Errorf(codes.InvalidArgument, "depth must be between 0 and %d", MaxExpandDepth)
+	}
+
+	nodeBudget := sel.nodeBudget
+	if nodeBudget <= 0 {
+		nodeBudget = DefaultNodeBudget
+	}
+	if nodeBudget > MaxNodeBudget {
+		return nil, nil, false, status.Errorf(codes.InvalidArgument, "node budget must not exceed %d", MaxNodeBudget)
+	}
+
+	if _, err := s.Pipeline.CheckConfidenceOptions(sel.minConfidence, ""); err != nil {
+		return nil, nil, false, err
+	}
+
+	// =====================================================
+	// Materialize subgraph
+	// =====================================================
+	blocked := `(
+					(e != null AND NOT ` + pipeline.ConfidenceFilter("e") + `) OR
+					(e != null AND NOT ` + pipeline.ValidityFilter("e") + `) OR
+					(e != null AND NOT ` + pipeline.ReadFilter("e") + `) OR
+					NOT ` + pipeline.ReadFilter("v") + `
+				)`
+
+	query := pipeline.GrantedIdsQuery + `
+		LET start_nodes = LENGTH(@rootIds) > 0 ? @rootIds : (
+			FOR e IN event
+			FILTER e.happened_at >= @startTime AND e.happened_at <= @endTime
+			FILTER (@countryCode == "" OR e.location.country_code == @countryCode)
+			FILTER (@tag == ""
+				OR @tag IN e.tags
+				OR (IS_DOCUMENT(e.attributes) AND LENGTH(
+					FOR lang IN ATTRIBUTES(e.attributes)
+					FILTER IS_LIST(e.attributes[lang].Tags) AND @tag IN e.attributes[lang].Tags
+					RETURN 1
+				) > 0)
+			)
+			SORT e.happened_at DESC
+			RETURN e._id
+		)
+
+		LET candidates = (
+			FOR start_node IN start_nodes
+				FOR v, e, p IN 0..@depth ANY start_node GRAPH @graphName
+				PRUNE ` + blocked + `
+				OPTIONS {uniqueVertices: 'global', order: 'bfs'}
+				FILTER NOT ` + blocked + `
+				RETURN { v: v, depth: LENGTH(p.edges) }
+		)
+
+		LET ranked_nodes = (
+			FOR c IN candidates
+			COLLECT id = c.v._id INTO group = c
+			LET depth = MIN(group[*].depth)
+			SORT depth ASC
+			RETURN group[0].v
+		)
+		LET traversed_nodes = SLICE(ranked_nodes, 0, @nodeBudget)
+
+		LET relations = (
+			FOR id IN traversed_nodes[*]._id
+				FOR v, e IN 1..1 ANY id GRAPH @graphName
+				FILTER v._id IN traversed_nodes[*]._id
+				FILTER ` + pipeline.ReadFilter("e") + `
+				FILTER ` + pipeline.ConfidenceFilter("e") + `
+				FILTER ` + pipeline.ValidityFilter("e") + `
+				RETURN DISTINCT e
+		)
+
+		RETURN {
+			entities: (
+				FOR doc IN traversed_nodes
+				RETURN { type: PARSE_IDENTIFIER(doc._id).collection, data: doc }
+			),
+			relations: relations,
+			truncated: LENGTH(ranked_nodes) > @nodeBudget
+		}
+	`
+
+	bindVars := map[string]interface{}{
+		"rootIds":       rootIds,
+		"startTime":     sel.startTime,
+		"endTime":       sel.endTime,
+		"countryCode":   sel.countryCode,
+		"tag":           sel.tag,
+		"depth":         sel.depth,
+		"nodeBudget":    nodeBudget,
+		"minConfidence": sel.minConfidence,
+		"userId":        userId,
+		"userRoles":     userRoles,
+	}
+	s.Pipeline.AddGrantBindVars(ctx, bindVars, userId, userRoles)
+	if err := s.Pipeline.AddValidityBindVars(bindVars, sel.asOf, sel.windowStart, sel.windowEnd); err != nil {
+		return nil, nil, false, err
+	}
+
+	cursor, err := s.DBClient.DB.Query(ctx, query, bindVars)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"query": query,
+			"vars":  bindVars,
+		}).Error("failed to execute AQL query")
+		return nil, nil, false, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	defer cursor.Close()
+
+	var result expandResult
+	if _, err := cursor.ReadDocument(ctx, &result); err != nil && !driver.IsNoMoreDocuments(err) {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to read query result")
+		return nil, nil, false, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	pbEntities, pbRelations := s.Pipeline.ProcessEntities(ctx, result.Entities, result.Relations)
+	return pbEntities, pbRelations, result.Truncated, nil
+}
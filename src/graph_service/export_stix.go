@@ -0,0 +1,59 @@
+package graphservice
+
+import (
+	"context"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func (s *GraphService) ExportStix(ctx context.Context, req *dapi.ExportStixRequest) (*dapi.ExportStixResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.WithFields(logrus.Fields{
+		"root_ids": req.GetRootIds(),
+	}).Infof("[%s, %v] requests to export STIX bundle", userId, userRoles)
+
+	pbEntities, pbRelations, truncated, err := s.exportSubgraph(ctx, exportSelection{
+		rootIds:       req.GetRootIds(),
+		startNode:     req.GetStartNode(),
+		startTime:     req.GetStartTime(),
+		endTime:       req.GetEndTime(),
+		countryCode:   req.GetCountryCode(),
+		tag:           req.GetTag(),
+		depth:         req.GetDepth(),
+		nodeBudget:    req.GetNodeBudget(),
+		minConfidence: req.GetMinConfidence(),
+		asOf:          req.GetAsOf(),
+		windowStart:   req.GetWindowStart(),
+		windowEnd:     req.GetWindowEnd(),
+	}, userId, userRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := s.Pipeline.StixBundle(pbEntities, pbRelations)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to map entities to STIX")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	pbBundle, err := structpb.NewStruct(bundle)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to convert STIX bundle")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	return &dapi.ExportStixResponse{Bundle: pbBundle, Truncated: truncated}, nil
+}
@@ -0,0 +1,183 @@
+package graphservice
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	DefaultPathDepth = 4
+	MaxPathDepth     = 6
+	MaxPaths         = 10
+	// Candidate paths checked against the filters before giving up on finding k
+	maxPathCandidates = 1000
+	// Path queries may enumerate many candidate paths, bound their runtime in seconds
+	pathQueryMaxRuntime = 30
+)
+
+type pathResult struct {
+	Cost      float64                 `json:"cost"`
+	Entities  []pipeline.EntityResult `json:"entities"`
+	Relations []model.Relation        `json:"relations"`
+}
+
+func (s *GraphService) FindPaths(ctx context.Context, req *dapi.FindPathsRequest) (*dapi.FindPathsResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.WithFields(logrus.Fields{
+		"from": req.GetFrom(),
+		"to":   req.GetTo(),
+	}).Infof("[%s, %v] requests to find paths", userId, userRoles)
+
+	// Both ends must be readable by the caller
+	if _, err := s.Pipeline.ResolveEndpoint(ctx, req.GetFrom(), userId, userRoles); err != nil {
+		return nil, err
+	}
+	if _, err := s.Pipeline.ResolveEndpoint(ctx, req.GetTo(), userId, userRoles); err != nil {
+		return nil, err
+	}
+
+	direction, err := s.Pipeline.TraversalDirection(req.GetDirection())
+	if err != nil {
+		return nil, err
+	}
+
+	maxDepth := req.GetMaxDepth()
+	if maxDepth <= 0 {
+		maxDepth = DefaultPathDepth
+	}
+	if maxDepth > MaxPathDepth {
+		return nil, status.Errorf(codes.InvalidArgument, "max depth must not exceed %d", MaxPathDepth)
+	}
+
+	k := req.GetK()
+	if k <= 0 {
+		k = 1
+	}
+	if k > MaxPaths {
+		return nil, status.Errorf(codes.InvalidArgument, "k must not exceed %d", MaxPaths)
+	}
+
+	if _, err := s.Pipeline.CheckConfidenceOptions(req.GetMinConfidence(), ""); err != nil {
+		return nil, err
+	}
+
+	collectionNames, err := s.Pipeline.FilterEdgeCollections(ctx, req.GetAllowedRelations(), req.GetDeniedRelations())
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, err
+		}
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to list edge collections")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	if len(collectionNames) == 0 {
+		return &dapi.FindPathsResponse{}, nil
+	}
+
+	bindVars := map[string]interface{}{
+		"from":          req.GetFrom(),
+		"to":            req.GetTo(),
+		"maxDepth":      maxDepth,
+		"k":             k,
+		"minConfidence": req.GetMinConfidence(),
+		"userId":        userId,
+		"userRoles":     userRoles,
+	}
+	s.Pipeline.AddGrantBindVars(ctx, bindVars, userId, userRoles)
+	if err := s.Pipeline.AddValidityBindVars(bindVars, req.GetAsOf(), req.GetWindowStart(), req.GetWindowEnd()); err != nil {
+		return nil, err
+	}
+	edgeCollections := pipeline.AddEdgeCollectionBindVars(bindVars, collectionNames)
+
+	// Paths are enumerated lazily in cost order: by length, or by the path
+	// weight derived from the confidence of each relationship. Enumeration stops
+	// at k paths passing the filters, or after maxPathCandidates paths when
+	// fewer readable paths exist within the max depth.
+	options := ""
+	costQuery := `LENGTH(p.edges)`
+	if req.GetWeightByConfidence() {
+		options = `OPTIONS { weightAttribute: @weightAttribute, defaultWeight: @defaultWeight }`
+		costQuery = `p.weight`
+		bindVars["weightAttribute"] = pipeline.PathWeightField
+		bindVars["defaultWeight"] = pipeline.DefaultPathWeight
+	}
+	bindVars["maxCandidates"] = maxPathCandidates
+
+	query := pipeline.GrantedIdsQuery + `
+		FOR p IN ` + direction + ` K_SHORTEST_PATHS @from TO @to ` + edgeCollections + `
+			` + options + `
+			LIMIT @maxCandidates
+			FILTER LENGTH(p.edges) <= @maxDepth
+			FILTER LENGTH(FOR v IN p.vertices FILTER NOT ` + pipeline.ReadFilter("v") + ` LIMIT 1 RETURN 1) == 0
+			FILTER LENGTH(FOR e IN p.edges FILTER NOT ` + pipeline.ReadFilter("e") + ` LIMIT 1 RETURN 1) == 0
+			FILTER LENGTH(FOR e IN p.edges FILTER NOT ` + pipeline.ValidityFilter("e") + ` LIMIT 1 RETURN 1) == 0
+			FILTER LENGTH(FOR e IN p.edges FILTER NOT ` + pipeline.ConfidenceFilter("e") + ` LIMIT 1 RETURN 1) == 0
+			LIMIT @k
+			RETURN {
+				cost: ` + costQuery + `,
+				entities: (
+					FOR v IN p.vertices
+					RETURN { type: PARSE_IDENTIFIER(v._id).collection, data: v }
+				),
+				relations: p.edges
+			}
+	`
+
+	cursor, err := s.DBClient.DB.Query(driver.WithQueryMaxRuntime(ctx, pathQueryMaxRuntime), query, bindVars)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"query": query,
+			"vars":  bindVars,
+		}).Error("failed to execute AQL query")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	defer cursor.Close()
+
+	var paths []*dapi.Path
+	for {
+		var result pathResult
+		if _, err := cursor.ReadDocument(ctx, &result); err != nil {
+			if driver.IsNoMoreDocuments(err) {
+				break
+			}
+			logger.WithFields(logrus.Fields{
+				"error": err,
+			}).Error("failed to read query result")
+			return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+		}
+
+		path := &dapi.Path{Cost: result.Cost}
+		for _, er := range result.Entities {
+			entity, _, err := s.Pipeline.DecodeEntity(er)
+			if err != nil {
+				logger.WithFields(logrus.Fields{
+					"type":  er.Type,
+					"error": err,
+				}).Error("failed to unmarshal entity data")
+				return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+			}
+			path.Entities = append(path.Entities, entity)
+		}
+		for i := range result.Relations {
+			path.Relations = append(path.Relations, &result.Relations[i])
+		}
+		paths = append(paths, path)
+	}
+
+	return &dapi.FindPathsResponse{Paths: paths}, nil
+}
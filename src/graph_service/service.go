@@ -0,0 +1,23 @@
+package graphservice
+
+import (
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+)
+
+type GraphService struct {
+	dapi.UnimplementedGraphServiceServer
+
+	DBClient *utils.ArangoDBClient
+	Pipeline *pipeline.Worker
+}
+
+func NewGraphService(client *utils.ArangoDBClient) (*GraphService, error) {
+	service := &GraphService{
+		DBClient: client,
+		Pipeline: pipeline.NewWorker(client),
+	}
+
+	return service, nil
+}
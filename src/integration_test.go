@@ -4,17 +4,28 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
 	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 const (
@@ -31,11 +42,11 @@ func getGRPCPort() string {
 	return port
 }
 
-func getMockToken() string {
+func getMockToken(username string, roles []string) string {
 	header := map[string]string{"alg": "HS256", "typ": "JWT"}
 	payload := map[string]interface{}{
-		"preferred_username": "admin",
-		"roles":              []string{"admin"},
+		"preferred_username": username,
+		"roles":              roles,
 		"exp":                time.Now().Add(time.Hour).Unix(),
 	}
 
@@ -49,7 +60,12 @@ func getMockToken() string {
 }
 
 func getAuthenticatedContext() context.Context {
-	token := getMockToken()
+	return getUserContext("admin", []string{"admin"})
+}
+
+// getUserContext authenticates as another identity, to test access it is granted.
+func getUserContext(username string, roles []string) context.Context {
+	token := getMockToken(username, roles)
 	md := metadata.New(map[string]string{
 		"authorization": "Bearer " + token,
 	})
@@ -73,6 +89,8 @@ func TestIntegration(t *testing.T) {
 
 	entityClient := dapi.NewEntityServiceClient(conn)
 	relationClient := dapi.NewRelationshipServiceClient(conn)
+	shareClient := dapi.NewShareServiceClient(conn)
+	graphClient := dapi.NewGraphServiceClient(conn)
 
 	ctx := getAuthenticatedContext()
 
@@ -90,7 +108,7 @@ func TestIntegration(t *testing.T) {
 		Location: &model.LocationData{
 			Latitude:              36.8835,
 			Longitude:             -123.43,
-			CountryCode:           "FANTASY",
+			CountryCode:           "US",
 			AdministrativeArea:    "彩虹王国",
 			SubAdministrativeArea: "魔法森林县",
 			Locality:              "糖果城堡",
@@ -123,7 +141,7 @@ func TestIntegration(t *testing.T) {
 		Location: &model.LocationData{
 			Latitude:              40.7128,
 			Longitude:             -74.0060,
-			CountryCode:           "SPACE",
+			CountryCode:           "US",
 			AdministrativeArea:    "Milky Way",
 			SubAdministrativeArea: "太阳系",
 			Locality:              "火星殖民地",
@@ -153,7 +171,7 @@ func TestIntegration(t *testing.T) {
 		Location: &model.LocationData{
 			Latitude:              -25.2744,
 			Longitude:             133.7751,
-			CountryCode:           "OCEAN",
+			CountryCode:           "AU",
 			AdministrativeArea:    "太平洋海洋",
 			SubAdministrativeArea: "马里亚纳 trench",
 			Locality:              "亚特兰蒂斯废墟",
@@ -413,7 +431,81 @@ func TestIntegration(t *testing.T) {
 	s3 := respS3.Entity
 
 	// --- 4. Connect Entities ---
+	relationTypes := []*dapi.RelationType{
+		{Name: "participant", FromTypes: []string{"event"}, ToTypes: []string{"person", "organization"}, Directed: true},
+		{Name: "hosted_by", FromTypes: []string{"event"}, ToTypes: []string{"person", "organization"}, Directed: true, InverseName: "hosts"},
+		{Name: "organized_by", FromTypes: []string{"event"}, ToTypes: []string{"person", "organization"}, Directed: true, InverseName: "organizes"},
+		{Name: "mentioned_by", FromTypes: []string{"event"}, ToTypes: []string{"website"}, Directed: true},
+		{Name: "reported_by", FromTypes: []string{"event"}, ToTypes: []string{"source"}, Directed: true},
+		{Name: "sourced_from", FromTypes: []string{"event"}, ToTypes: []string{"source"}, Directed: true},
+		{Name: "sponsor", FromTypes: []string{"event"}, ToTypes: []string{"person", "organization"}, Directed: true},
+		{Name: "has_website", FromTypes: []string{"person", "organization"}, ToTypes: []string{"website"}, Directed: true},
+		{Name: "related_event", FromTypes: []string{"event"}, ToTypes: []string{"event"}},
+		{Name: "temp_relation"},
+	}
+	for _, relationType := range relationTypes {
+		_, err = relationClient.CreateRelationType(ctx, &dapi.CreateRelationTypeRequest{RelationType: relationType})
+		if err != nil && status.Code(err) != codes.AlreadyExists {
+			t.Fatalf("Failed to create relation type %s: %v", relationType.Name, err)
+		}
+	}
+
+	// Cleared fields of a relation type are removed
+	_, err = relationClient.UpdateRelationType(ctx, &dapi.UpdateRelationTypeRequest{
+		Name:         "temp_relation",
+		RelationType: &dapi.RelationType{InverseName: "temp_inverse", Label: "临时", Description: "临时关系"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to update relation type: %v", err)
+	}
+	clearedType, err := relationClient.UpdateRelationType(ctx, &dapi.UpdateRelationTypeRequest{
+		Name:         "temp_relation",
+		RelationType: &dapi.RelationType{},
+	})
+	if err != nil {
+		t.Fatalf("Failed to clear relation type: %v", err)
+	}
+	if rt := clearedType.RelationType; rt.GetInverseName() != "" || rt.GetLabel() != "" || rt.GetDescription() != "" {
+		t.Errorf("Expected relation type fields to be cleared, got %v", rt)
+	}
+
 	_, err = relationClient.CreateRelationship(ctx, &dapi.CreateRelationshipRequest{
+		Relationship: &model.Relation{
+			From: e1.GetEvent().GetId(),
+			To:   p1.GetPerson().GetId(),
+			Name: "unregistered_relation",
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument for unknown relation type, got: %v", err)
+	}
+
+	// Relation names in other scripts belong in the label
+	_, err = relationClient.CreateRelationship(ctx, &dapi.CreateRelationshipRequest{
+		Relationship: &model.Relation{
+			From: e1.GetEvent().GetId(),
+			To:   p1.GetPerson().GetId(),
+			Name: "参与者",
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(status.Convert(err).Message(), "ASCII") {
+		t.Fatalf("Expected InvalidArgument for a non-ASCII relation name, got: %v", err)
+	}
+
+	invalidValidity, _ := structpb.NewStruct(map[string]interface{}{"valid_from": 1700000000, "valid_to": 1600000000})
+	_, err = relationClient.CreateRelationship(ctx, &dapi.CreateRelationshipRequest{
+		Relationship: &model.Relation{
+			From:       e1.GetEvent().GetId(),
+			To:         p1.GetPerson().GetId(),
+			Name:       "sponsor",
+			Attributes: invalidValidity,
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument for inverted validity interval, got: %v", err)
+	}
+
+	participant, err := relationClient.CreateRelationship(ctx, &dapi.CreateRelationshipRequest{
 		Relationship: &model.Relation{
 			From:  e1.GetEvent().GetId(),
 			To:    p1.GetPerson().GetId(),
@@ -422,11 +514,17 @@ func TestIntegration(t *testing.T) {
 			Write: []string{"admin"},
 			Name:  "participant",
 			Label: "参与者",
+			// Timestamps are managed by the server
+			CreatedAt: 1,
+			UpdatedAt: 1,
 		},
 	})
 	if err != nil {
 		t.Fatalf("Failed to create relationship e1->p1: %v", err)
 	}
+	if relation := participant.GetRelationship(); relation.GetCreatedAt() < time.Now().Add(-time.Hour).Unix() || relation.GetUpdatedAt() != relation.GetCreatedAt() {
+		t.Errorf("Expected server-managed timestamps on the relationship, got created_at %d, updated_at %d", relation.GetCreatedAt(), relation.GetUpdatedAt())
+	}
 
 	_, err = relationClient.CreateRelationship(ctx, &dapi.CreateRelationshipRequest{
 		Relationship: &model.Relation{
@@ -594,6 +692,28 @@ func TestIntegration(t *testing.T) {
 	}
 	tempRel := respRel.Relationship
 
+	_, err = relationClient.CreateRelationship(ctx, &dapi.CreateRelationshipRequest{
+		Relationship: &model.Relation{
+			From: e1.GetEvent().GetId(),
+			To:   "person/does-not-exist",
+			Name: "participant",
+		},
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected NotFound for missing endpoint, got: %v", err)
+	}
+
+	_, err = relationClient.CreateRelationship(ctx, &dapi.CreateRelationshipRequest{
+		Relationship: &model.Relation{
+			From: e1.GetEvent().GetId(),
+			To:   "grant/" + e1.GetEvent().GetKey(),
+			Name: "participant",
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument for non-entity endpoint, got: %v", err)
+	}
+
 	// --- 4.5 List Entities from Event ---
 	nowTime := time.Now()
 	startOfDay := time.Date(nowTime.Year(), nowTime.Month(), nowTime.Day(), 0, 0, 0, 0, nowTime.Location()).Unix()
@@ -615,7 +735,7 @@ func TestIntegration(t *testing.T) {
 	// Test with other query parameters
 	list2, err := entityClient.ListEntitiesFromEvent(ctx, &dapi.ListEntitiesFromEventRequest{
 		StartNode:   e1.GetEvent().GetId(),
-		CountryCode: "FANTASY",
+		CountryCode: "US",
 		Tag:         "产业",
 		Depth:       1,
 	})
@@ -640,11 +760,1092 @@ func TestIntegration(t *testing.T) {
 		t.Fatal("ListEntitiesFromEvent 3 returned 0 entities or relations")
 	}
 
+	// Relationships without a confidence are not followed once a minimum is set
+	list4, err := entityClient.ListEntitiesFromEvent(ctx, &dapi.ListEntitiesFromEventRequest{
+		StartNode:             e1.GetEvent().GetId(),
+		Depth:                 1,
+		MinConfidence:         50,
+		ConfidenceAggregation: "min",
+	})
+	if err != nil {
+		t.Fatalf("Failed to list entities with min confidence: %v", err)
+	}
+	if len(list4.Entities) != 1 || len(list4.Relations) != 0 {
+		t.Fatalf("ListEntitiesFromEvent 4 followed low confidence relations: %d entities, %d relations", len(list4.Entities), len(list4.Relations))
+	}
+	if list4.PathConfidence[e1.GetEvent().GetId()] != 1 {
+		t.Errorf("Start event should have path confidence 1: %v", list4.PathConfidence)
+	}
+
+	// Geo queries: e1 lies off California, e2 in New York
+	entityIds := func(entities []*model.Entity) []string {
+		var ids []string
+		for _, entity := range entities {
+			ids = append(ids, entity.GetEvent().GetId())
+		}
+		return ids
+	}
+	nearby, err := entityClient.NearbyEvents(ctx, &dapi.NearbyEventsRequest{
+		Latitude:     40.75,
+		Longitude:    -73.98,
+		RadiusMeters: 50000,
+	})
+	if err != nil {
+		t.Fatalf("Failed to list nearby events: %v", err)
+	}
+	foundNearby := false
+	for i, nearbyEvent := range nearby.Events {
+		if nearbyEvent.GetEvent().GetId() == e1.GetEvent().GetId() {
+			t.Errorf("NearbyEvents returned e1, %f m away", nearbyEvent.GetDistanceMeters())
+		}
+		if nearbyEvent.GetEvent().GetId() == e2.GetEvent().GetId() {
+			foundNearby = true
+			if d := nearbyEvent.GetDistanceMeters(); d <= 0 || d > 50000 {
+				t.Errorf("Expected e2 within 50 km, got %f m", d)
+			}
+		}
+		if i > 0 && nearbyEvent.GetDistanceMeters() < nearby.Events[i-1].GetDistanceMeters() {
+			t.Error("NearbyEvents should sort by distance")
+		}
+	}
+	if !foundNearby {
+		t.Errorf("NearbyEvents should return e2, got %d events", len(nearby.Events))
+	}
+
+	boxList, err := entityClient.ListEntitiesFromEvent(ctx, &dapi.ListEntitiesFromEventRequest{
+		StartTime:    startOfDay,
+		EndTime:      endOfDay,
+		MinLatitude:  36,
+		MinLongitude: -124,
+		MaxLatitude:  37,
+		MaxLongitude: -123,
+	})
+	if err != nil {
+		t.Fatalf("Failed to list entities in bounding box: %v", err)
+	}
+	if ids := entityIds(boxList.Entities); !slices.Contains(ids, e1.GetEvent().GetId()) || slices.Contains(ids, e2.GetEvent().GetId()) {
+		t.Errorf("Bounding box should select e1 only, got %v", ids)
+	}
+
+	// Boxes follow lines of latitude: a great circle between the southern
+	// corners of this box passes north of e1
+	wideBoxList, err := entityClient.ListEntitiesFromEvent(ctx, &dapi.ListEntitiesFromEventRequest{
+		StartTime:    startOfDay,
+		EndTime:      endOfDay,
+		MinLatitude:  36,
+		MinLongitude: -170,
+		MaxLatitude:  41,
+		MaxLongitude: -77,
+	})
+	if err != nil {
+		t.Fatalf("Failed to list entities in bounding box: %v", err)
+	}
+	if ids := entityIds(wideBoxList.Entities); !slices.Contains(ids, e1.GetEvent().GetId()) || slices.Contains(ids, e2.GetEvent().GetId()) {
+		t.Errorf("Wide bounding box should select e1 only, got %v", ids)
+	}
+
+	// A box from 130 east to 120 west crosses the antimeridian
+	antimeridianList, err := entityClient.ListEntitiesFromEvent(ctx, &dapi.ListEntitiesFromEventRequest{
+		StartTime:    startOfDay,
+		EndTime:      endOfDay,
+		MinLatitude:  -30,
+		MinLongitude: 130,
+		MaxLatitude:  40,
+		MaxLongitude: -120,
+	})
+	if err != nil {
+		t.Fatalf("Failed to list entities in a bounding box across the antimeridian: %v", err)
+	}
+	if ids := entityIds(antimeridianList.Entities); !slices.Contains(ids, e1.GetEvent().GetId()) || !slices.Contains(ids, e3.GetEvent().GetId()) || slices.Contains(ids, e2.GetEvent().GetId()) {
+		t.Errorf("Bounding box across the antimeridian should select e1 and e3, got %v", ids)
+	}
+
+	polygonList, err := entityClient.ListEntitiesFromEvent(ctx, &dapi.ListEntitiesFromEventRequest{
+		StartTime: startOfDay,
+		EndTime:   endOfDay,
+		Polygon:   []float64{40, -75, 40, -73, 41.5, -74},
+	})
+	if err != nil {
+		t.Fatalf("Failed to list entities in polygon: %v", err)
+	}
+	if ids := entityIds(polygonList.Entities); !slices.Contains(ids, e2.GetEvent().GetId()) || slices.Contains(ids, e1.GetEvent().GetId()) {
+		t.Errorf("Polygon should select e2 only, got %v", ids)
+	}
+
+	_, err = entityClient.ListEntitiesFromEvent(ctx, &dapi.ListEntitiesFromEventRequest{
+		StartTime:    startOfDay,
+		EndTime:      endOfDay,
+		MinLatitude:  37,
+		MinLongitude: -124,
+		MaxLatitude:  36,
+		MaxLongitude: -123,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an inverted bounding box, got %v", err)
+	}
+
+	// --- 4.6 Share Grants ---
+	partnerCtx := getUserContext("partner", []string{"analyst"})
+	getAsPartner := func(ctx context.Context, entity *model.Entity) error {
+		_, err := entityClient.GetEntity(ctx, &dapi.GetEntityRequest{
+			EntityType: "event",
+			Key:        entity.GetEvent().GetKey(),
+		})
+		return err
+	}
+	if err := getAsPartner(partnerCtx, e1); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Expected PermissionDenied reading e1 before it is shared, got: %v", err)
+	}
+
+	respGrant, err := shareClient.CreateGrant(ctx, &dapi.CreateGrantRequest{
+		Grant: &dapi.Grant{
+			Principal: "partner",
+			Target:    e1.GetEvent().GetId(),
+			Depth:     1,
+			ExpiresAt: time.Now().Add(7 * 24 * time.Hour).Unix(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create grant: %v", err)
+	}
+
+	grants, err := shareClient.ListActiveGrants(ctx, &dapi.ListActiveGrantsRequest{
+		Target: e1.GetEvent().GetId(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to list active grants: %v", err)
+	}
+	if len(grants.Grants) == 0 {
+		t.Fatal("ListActiveGrants returned 0 grants")
+	}
+
+	// The grant reaches e1 and, with depth 1, its participant p1
+	if err := getAsPartner(partnerCtx, e1); err != nil {
+		t.Errorf("Expected the partner to read the shared e1: %v", err)
+	}
+	partnerList, err := entityClient.ListEntitiesFromEvent(partnerCtx, &dapi.ListEntitiesFromEventRequest{
+		StartNode: e1.GetEvent().GetId(),
+		Depth:     1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to list entities from the shared event: %v", err)
+	}
+	sharedIds := make(map[string]bool)
+	for _, entity := range partnerList.Entities {
+		sharedIds[entity.GetEvent().GetId()+entity.GetPerson().GetId()] = true
+	}
+	if !sharedIds[e1.GetEvent().GetId()] || !sharedIds[p1.GetPerson().GetId()] {
+		t.Errorf("Expected the partner to list e1 and p1 through the grant, got %v", sharedIds)
+	}
+
+	respLink, err := shareClient.CreateGrant(ctx, &dapi.CreateGrantRequest{
+		Grant: &dapi.Grant{
+			Target:    e2.GetEvent().GetId(),
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create share link: %v", err)
+	}
+	if respLink.Grant.GetToken() == "" {
+		t.Error("share link token should be defined")
+	}
+
+	// Access ends with the grant
+	if _, err := shareClient.DeleteGrant(ctx, &dapi.DeleteGrantRequest{Key: respGrant.Grant.GetKey()}); err != nil {
+		t.Fatalf("Failed to delete grant: %v", err)
+	}
+	if err := getAsPartner(partnerCtx, e1); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied reading e1 after revocation, got: %v", err)
+	}
+
+	// The share link is presented in a header, without being the principal
+	linkCtx := metadata.AppendToOutgoingContext(partnerCtx, "x-share-token", respLink.Grant.GetToken())
+	if err := getAsPartner(linkCtx, e2); err != nil {
+		t.Errorf("Expected the share link to grant access to e2: %v", err)
+	}
+	if err := getAsPartner(partnerCtx, e2); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied reading e2 without the share link, got: %v", err)
+	}
+	if _, err := shareClient.DeleteGrant(ctx, &dapi.DeleteGrantRequest{Key: respLink.Grant.GetKey()}); err != nil {
+		t.Fatalf("Failed to delete share link: %v", err)
+	}
+	if err := getAsPartner(linkCtx, e2); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied reading e2 after the share link is revoked, got: %v", err)
+	}
+
+	respExpiring, err := shareClient.CreateGrant(ctx, &dapi.CreateGrantRequest{
+		Grant: &dapi.Grant{
+			Principal: "analyst",
+			Target:    e1.GetEvent().GetId(),
+			ExpiresAt: time.Now().Add(2 * time.Second).Unix(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create expiring grant: %v", err)
+	}
+	if err := getAsPartner(partnerCtx, e1); err != nil {
+		t.Errorf("Expected the partner role to read e1 before the grant expires: %v", err)
+	}
+	time.Sleep(3 * time.Second)
+	if err := getAsPartner(partnerCtx, e1); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied reading e1 after the grant expired, got: %v", err)
+	}
+
+	// The sweeper removes the expired grant document
+	dbClient, err := utils.NewArangoDBClient()
+	if err != nil {
+		t.Fatalf("Failed to connect to ArangoDB: %v", err)
+	}
+	swept, err := pipeline.NewWorker(dbClient).SweepExpiredGrants(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to sweep expired grants: %v", err)
+	}
+	if swept == 0 {
+		t.Error("SweepExpiredGrants removed no grants")
+	}
+	grantCol, err := dbClient.DB.Collection(context.Background(), pipeline.GrantCollection)
+	if err != nil {
+		t.Fatalf("Failed to get grant collection: %v", err)
+	}
+	if exists, err := grantCol.DocumentExists(context.Background(), respExpiring.Grant.GetKey()); err != nil || exists {
+		t.Errorf("Expected the expired grant to be swept, exists %v, error %v", exists, err)
+	}
+
+	// --- 4.7 Query Relationships ---
+	relList, err := relationClient.ListRelationships(ctx, &dapi.ListRelationshipsRequest{
+		From:  e1.GetEvent().GetId(),
+		Limit: 3,
+	})
+	if err != nil {
+		t.Fatalf("Failed to list relationships: %v", err)
+	}
+	if len(relList.Relationships) != 3 || relList.TotalCount < 6 {
+		t.Fatalf("ListRelationships returned %d relationships of %d", len(relList.Relationships), relList.TotalCount)
+	}
+
+	_, err = relationClient.ListRelationships(ctx, &dapi.ListRelationshipsRequest{Limit: 3})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument listing relationships without an endpoint, got: %v", err)
+	}
+
+	relGet, err := relationClient.GetRelationship(ctx, &dapi.GetRelationshipRequest{
+		Collection: "event_temp_relation_person",
+		Key:        tempRel.GetKey(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to get relationship: %v", err)
+	}
+	if relGet.Relationship.GetTo() != p3.GetPerson().GetId() {
+		t.Errorf("GetRelationship returned wrong relationship: %v", relGet.Relationship)
+	}
+
+	// Service collections are not relationships even though they are edges
+	_, err = relationClient.UpdateRelationship(ctx, &dapi.UpdateRelationshipRequest{
+		Collection:   "grant",
+		Key:          tempRel.GetKey(),
+		Relationship: &model.Relation{Label: "临时"},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument updating a grant, got: %v", err)
+	}
+	_, err = relationClient.DeleteRelationship(ctx, &dapi.DeleteRelationshipRequest{
+		Collection: "idempotency_key",
+		Key:        tempRel.GetKey(),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument deleting an idempotency key, got: %v", err)
+	}
+
+	neighbors, err := relationClient.ListNeighbors(ctx, &dapi.ListNeighborsRequest{
+		EntityId:  p2.GetPerson().GetId(),
+		Direction: "inbound",
+		Filter:    &dapi.RelationshipFilter{Name: "sponsor"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to list neighbors: %v", err)
+	}
+	if len(neighbors.Neighbors) != 1 || neighbors.Neighbors[0].GetEntity().GetEvent().GetId() != e2.GetEvent().GetId() {
+		t.Fatalf("ListNeighbors returned unexpected neighbors: %v", neighbors.Neighbors)
+	}
+
+	// Relationships without a validity interval are valid at any time
+	neighborsAsOf, err := relationClient.ListNeighbors(ctx, &dapi.ListNeighborsRequest{
+		EntityId:  p2.GetPerson().GetId(),
+		Direction: "inbound",
+		Filter:    &dapi.RelationshipFilter{Name: "sponsor", AsOf: time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC).Unix()},
+	})
+	if err != nil {
+		t.Fatalf("Failed to list neighbors as of 2001: %v", err)
+	}
+	if len(neighborsAsOf.Neighbors) != 1 {
+		t.Fatalf("ListNeighbors as of 2001 returned unexpected neighbors: %v", neighborsAsOf.Neighbors)
+	}
+
+	// Relationships with a validity interval are only followed inside it
+	validity, _ := structpb.NewStruct(map[string]interface{}{
+		"valid_from": time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		"valid_to":   time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	})
+	pastSponsor, err := relationClient.CreateRelationship(ctx, &dapi.CreateRelationshipRequest{
+		Relationship: &model.Relation{
+			From:       e3.GetEvent().GetId(),
+			To:         p2.GetPerson().GetId(),
+			Owner:      "admin",
+			Read:       []string{"admin"},
+			Write:      []string{"admin"},
+			Name:       "sponsor",
+			Label:      "前赞助商",
+			Attributes: validity,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create relationship with validity interval: %v", err)
+	}
+	validityFilters := []struct {
+		name   string
+		filter *dapi.RelationshipFilter
+		want   int
+	}{
+		{"as of 2012", &dapi.RelationshipFilter{Name: "sponsor", AsOf: time.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC).Unix()}, 2},
+		{"as of 2020", &dapi.RelationshipFilter{Name: "sponsor", AsOf: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Unix()}, 1},
+		{"window 2014-2016", &dapi.RelationshipFilter{
+			Name:        "sponsor",
+			WindowStart: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+			WindowEnd:   time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		}, 2},
+		{"window 2016-2018", &dapi.RelationshipFilter{
+			Name:        "sponsor",
+			WindowStart: time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+			WindowEnd:   time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		}, 1},
+	}
+	for _, vf := range validityFilters {
+		resp, err := relationClient.ListNeighbors(ctx, &dapi.ListNeighborsRequest{
+			EntityId:  p2.GetPerson().GetId(),
+			Direction: "inbound",
+			Filter:    vf.filter,
+		})
+		if err != nil {
+			t.Fatalf("Failed to list neighbors %s: %v", vf.name, err)
+		}
+		if len(resp.Neighbors) != vf.want {
+			t.Fatalf("ListNeighbors %s returned unexpected neighbors: %v", vf.name, resp.Neighbors)
+		}
+		if vf.want == 1 && resp.Neighbors[0].GetEntity().GetEvent().GetId() != e2.GetEvent().GetId() {
+			t.Fatalf("ListNeighbors %s followed a relationship outside its validity: %v", vf.name, resp.Neighbors)
+		}
+	}
+
+	pastPaths, err := graphClient.FindPaths(ctx, &dapi.FindPathsRequest{
+		From:     e3.GetEvent().GetId(),
+		To:       p2.GetPerson().GetId(),
+		MaxDepth: 1,
+		AsOf:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to find paths as of 2020: %v", err)
+	}
+	if len(pastPaths.Paths) != 0 {
+		t.Fatalf("FindPaths followed a relationship outside its validity: %v", pastPaths.Paths)
+	}
+
+	_, err = relationClient.DeleteRelationship(ctx, &dapi.DeleteRelationshipRequest{
+		Collection: "event_sponsor_person",
+		Key:        pastSponsor.Relationship.GetKey(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to delete relationship with validity interval: %v", err)
+	}
+
+	// --- 4.8 Graph Queries ---
+	paths, err := graphClient.FindPaths(ctx, &dapi.FindPathsRequest{
+		From: p1.GetPerson().GetId(),
+		To:   w1.GetWebsite().GetId(),
+		K:    2,
+	})
+	if err != nil {
+		t.Fatalf("Failed to find paths: %v", err)
+	}
+	if len(paths.Paths) != 2 || len(paths.Paths[0].Relations) != 2 {
+		t.Fatalf("FindPaths returned unexpected paths: %v", paths.Paths)
+	}
+
+	// Fewer readable paths than k within the max depth
+	fewerPaths, err := graphClient.FindPaths(ctx, &dapi.FindPathsRequest{
+		From:     p1.GetPerson().GetId(),
+		To:       w1.GetWebsite().GetId(),
+		MaxDepth: 2,
+		K:        10,
+	})
+	if err != nil {
+		t.Fatalf("Failed to find paths when fewer than k exist: %v", err)
+	}
+	if len(fewerPaths.Paths) == 0 || len(fewerPaths.Paths) >= 10 {
+		t.Fatalf("FindPaths returned unexpected number of paths: %v", fewerPaths.Paths)
+	}
+	for i, path := range fewerPaths.Paths {
+		if len(path.Relations) > 2 || (i > 0 && len(path.Relations) < len(fewerPaths.Paths[i-1].Relations)) {
+			t.Fatalf("FindPaths returned paths out of depth or order: %v", fewerPaths.Paths)
+		}
+	}
+
+	// Relationships without a confidence are not followed once a minimum is set
+	confidentPaths, err := graphClient.FindPaths(ctx, &dapi.FindPathsRequest{
+		From:          p1.GetPerson().GetId(),
+		To:            w1.GetWebsite().GetId(),
+		K:             2,
+		MinConfidence: 50,
+	})
+	if err != nil {
+		t.Fatalf("Failed to find paths with min confidence: %v", err)
+	}
+	if len(confidentPaths.Paths) != 0 {
+		t.Fatalf("FindPaths followed low confidence relations: %v", confidentPaths.Paths)
+	}
+
+	weightedPaths, err := graphClient.FindPaths(ctx, &dapi.FindPathsRequest{
+		From:               p1.GetPerson().GetId(),
+		To:                 w1.GetWebsite().GetId(),
+		DeniedRelations:    []string{"mentioned_by"},
+		WeightByConfidence: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to find weighted paths: %v", err)
+	}
+	if len(weightedPaths.Paths) != 1 || len(weightedPaths.Paths[0].Relations) != 3 {
+		t.Fatalf("FindPaths (weighted) returned unexpected paths: %v", weightedPaths.Paths)
+	}
+
+	expanded, err := graphClient.ExpandGraph(ctx, &dapi.ExpandGraphRequest{
+		StartNodes: []string{p2.GetPerson().GetId()},
+		Direction:  "inbound",
+		HopFilters: []*dapi.HopFilter{{Relations: []string{"sponsor"}}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to expand graph: %v", err)
+	}
+	foundSponsor := false
+	for _, entity := range expanded.Entities {
+		if entity.GetEvent().GetId() == e2.GetEvent().GetId() {
+			foundSponsor = true
+		}
+	}
+	if !foundSponsor || len(expanded.Relations) == 0 || expanded.Truncated {
+		t.Fatalf("ExpandGraph returned unexpected graph: %v", expanded)
+	}
+
+	budgeted, err := graphClient.ExpandGraph(ctx, &dapi.ExpandGraphRequest{
+		StartNodes: []string{p2.GetPerson().GetId()},
+		NodeBudget: 1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to expand graph with node budget: %v", err)
+	}
+	if len(budgeted.Entities) != 1 || !budgeted.Truncated {
+		t.Fatalf("ExpandGraph ignored node budget: %v", budgeted)
+	}
+
+	analysis, err := graphClient.AnalyzeSubgraph(ctx, &dapi.AnalyzeSubgraphRequest{
+		EntityIds: []string{p1.GetPerson().GetId()},
+		Depth:     3,
+	})
+	if err != nil {
+		t.Fatalf("Failed to analyze subgraph: %v", err)
+	}
+	if len(analysis.Scores) != len(analysis.Entities) || analysis.CommunityCount == 0 {
+		t.Fatalf("AnalyzeSubgraph returned unexpected scores: %v", analysis.Scores)
+	}
+	for _, score := range analysis.Scores {
+		if score.GetPagerank() <= 0 || score.GetCommunity() >= analysis.CommunityCount {
+			t.Errorf("AnalyzeSubgraph returned invalid score: %v", score)
+		}
+	}
+
+	exported, err := graphClient.ExportGraph(ctx, &dapi.ExportGraphRequest{
+		Format:  "gexf",
+		RootIds: []string{p1.GetPerson().GetId()},
+		Depth:   1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to export graph: %v", err)
+	}
+	if exported.GetContentType() != "application/gexf+xml" ||
+		!strings.Contains(string(exported.GetData()), `<node id="`+p1.GetPerson().GetId()+`"`) ||
+		!strings.Contains(string(exported.GetData()), "<edge ") {
+		t.Fatalf("ExportGraph returned unexpected GEXF: %s", exported.GetData())
+	}
+
+	// GraphML and Cytoscape exports parse back to the same nodes and edges
+	var graphMLHeader metadata.MD
+	exported, err = graphClient.ExportGraph(ctx, &dapi.ExportGraphRequest{
+		Format:  "graphml",
+		RootIds: []string{p1.GetPerson().GetId()},
+		Depth:   1,
+	}, grpc.Header(&graphMLHeader))
+	if err != nil {
+		t.Fatalf("Failed to export graph: %v", err)
+	}
+	if exported.GetContentType() != "application/graphml+xml" {
+		t.Errorf("ExportGraph returned GraphML as %s", exported.GetContentType())
+	}
+	if disposition := graphMLHeader.Get("content-disposition"); len(disposition) != 1 || disposition[0] != `attachment; filename="graph.graphml"` {
+		t.Errorf("ExportGraph returned unexpected Content-Disposition: %v", disposition)
+	}
+	if truncated := graphMLHeader.Get("x-graph-truncated"); len(truncated) != 1 || truncated[0] != "false" {
+		t.Errorf("ExportGraph returned unexpected truncation header: %v", truncated)
+	}
+	var graphML struct {
+		Graph struct {
+			Nodes []struct {
+				Id string `xml:"id,attr"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	if err := xml.Unmarshal(exported.GetData(), &graphML); err != nil {
+		t.Fatalf("ExportGraph returned invalid GraphML: %v", err)
+	}
+	graphMLNodes := map[string]bool{}
+	for _, node := range graphML.Graph.Nodes {
+		graphMLNodes[node.Id] = true
+	}
+	if !graphMLNodes[p1.GetPerson().GetId()] || len(graphML.Graph.Edges) == 0 {
+		t.Fatalf("ExportGraph returned unexpected GraphML: %s", exported.GetData())
+	}
+	for _, edge := range graphML.Graph.Edges {
+		if !graphMLNodes[edge.Source] || !graphMLNodes[edge.Target] {
+			t.Errorf("GraphML edge %s -> %s has an unknown node", edge.Source, edge.Target)
+		}
+	}
+
+	var cytoscapeHeader metadata.MD
+	exported, err = graphClient.ExportGraph(ctx, &dapi.ExportGraphRequest{
+		Format:  "cytoscape",
+		RootIds: []string{p1.GetPerson().GetId()},
+		Depth:   1,
+	}, grpc.Header(&cytoscapeHeader))
+	if err != nil {
+		t.Fatalf("Failed to export graph: %v", err)
+	}
+	if disposition := cytoscapeHeader.Get("content-disposition"); len(disposition) != 1 || disposition[0] != `attachment; filename="graph.cyjs"` {
+		t.Errorf("ExportGraph returned unexpected Content-Disposition: %v", disposition)
+	}
+	var cytoscape struct {
+		Elements struct {
+			Nodes []struct {
+				Data map[string]interface{} `json:"data"`
+			} `json:"nodes"`
+			Edges []struct {
+				Data map[string]interface{} `json:"data"`
+			} `json:"edges"`
+		} `json:"elements"`
+	}
+	if err := json.Unmarshal(exported.GetData(), &cytoscape); err != nil {
+		t.Fatalf("ExportGraph returned invalid Cytoscape JSON: %v", err)
+	}
+	if len(cytoscape.Elements.Nodes) != len(graphML.Graph.Nodes) || len(cytoscape.Elements.Edges) != len(graphML.Graph.Edges) {
+		t.Errorf("Cytoscape export has %d nodes and %d edges, GraphML has %d and %d",
+			len(cytoscape.Elements.Nodes), len(cytoscape.Elements.Edges), len(graphML.Graph.Nodes), len(graphML.Graph.Edges))
+	}
+	for _, node := range cytoscape.Elements.Nodes {
+		if id, _ := node.Data["id"].(string); !graphMLNodes[id] {
+			t.Errorf("Cytoscape node %v is not in the GraphML export", node.Data["id"])
+		}
+	}
+	for _, edge := range cytoscape.Elements.Edges {
+		source, _ := edge.Data["source"].(string)
+		target, _ := edge.Data["target"].(string)
+		if !graphMLNodes[source] || !graphMLNodes[target] {
+			t.Errorf("Cytoscape edge %s -> %s has an unknown node", source, target)
+		}
+	}
+
+	if _, err := graphClient.ExportGraph(ctx, &dapi.ExportGraphRequest{Format: "dot"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ExportGraph accepted an unknown format: %v", err)
+	}
+
+	// Move Temp Relation to another entity type
+	respMoved, err := relationClient.UpdateRelationship(ctx, &dapi.UpdateRelationshipRequest{
+		Collection: "event_temp_relation_person",
+		Key:        tempRel.GetKey(),
+		Relationship: &model.Relation{
+			To:    o3.GetOrganization().GetId(),
+			Label: "临时关系（已迁移）",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to move temp relationship: %v", err)
+	}
+	if respMoved.Relationship.GetId() != "event_temp_relation_organization/"+tempRel.GetKey() {
+		t.Errorf("Moved relationship has unexpected id: %s", respMoved.Relationship.GetId())
+	}
+	if respMoved.Relationship.GetOwner() != "admin" {
+		t.Errorf("Moved relationship lost its owner: %v", respMoved.Relationship)
+	}
+
+	// --- 4.9 Entity Resolution ---
+	resolutionClient := dapi.NewResolutionServiceClient(conn)
+
+	respDup, err := entityClient.CreateEntity(ctx, &dapi.CreateEntityRequest{
+		EntityType: "person",
+		Entity: &model.Entity{Entity: &model.Entity_Person{Person: &model.Person{
+			Owner: "admin",
+			Read:  []string{"admin"},
+			Write: []string{"admin"},
+			// Romanized name of p1 (甘道夫) without an alias in common
+			Name:    "Gan Daofu",
+			Role:    "巫师",
+			Aliases: []string{"Gandalf"},
+		}}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create duplicate person: %v", err)
+	}
+	pDup := respDup.Entity
+
+	duplicates, err := resolutionClient.FindDuplicates(ctx, &dapi.FindDuplicatesRequest{
+		EntityType: "person",
+		EntityId:   p1.GetPerson().GetId(),
+		Rescan:     true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to find duplicates: %v", err)
+	}
+	foundDuplicate := false
+	for _, cluster := range duplicates.Clusters {
+		for _, entity := range cluster.Entities {
+			if entity.GetPerson().GetId() == pDup.GetPerson().GetId() {
+				foundDuplicate = true
+			}
+		}
+	}
+	if !foundDuplicate {
+		t.Fatalf("FindDuplicates did not find the duplicate person: %v", duplicates.Clusters)
+	}
+
+	// Only the relationship without a validity interval duplicates participant
+	dupRelations := make([]*model.Relation, 0, 2)
+	for _, attributes := range []*structpb.Struct{nil, validity} {
+		respRel, err := relationClient.CreateRelationship(ctx, &dapi.CreateRelationshipRequest{
+			Relationship: &model.Relation{
+				From:       e1.GetEvent().GetId(),
+				To:         pDup.GetPerson().GetId(),
+				Owner:      "admin",
+				Read:       []string{"admin"},
+				Write:      []string{"admin"},
+				Name:       "participant",
+				Attributes: attributes,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create relationship e1->pDup: %v", err)
+		}
+		dupRelations = append(dupRelations, respRel.Relationship)
+	}
+
+	merged, err := resolutionClient.MergeEntities(ctx, &dapi.MergeEntitiesRequest{
+		EntityType:  "person",
+		SurvivorKey: p1.GetPerson().GetKey(),
+		MergedKeys:  []string{pDup.GetPerson().GetKey()},
+	})
+	if err != nil {
+		t.Fatalf("Failed to merge duplicate person: %v", err)
+	}
+	if !slices.Contains(merged.Entity.GetPerson().GetAliases(), "Gandalf") {
+		t.Errorf("Expected merged person to keep the duplicate's aliases, got %v", merged.Entity.GetPerson().GetAliases())
+	}
+	if merged.RelationshipsRemoved != 1 {
+		t.Errorf("Expected 1 duplicate relationship removed on merge, got %d", merged.RelationshipsRemoved)
+	}
+	_, err = relationClient.GetRelationship(ctx, &dapi.GetRelationshipRequest{
+		Collection: "event_participant_person",
+		Key:        dupRelations[0].GetKey(),
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected the duplicate relationship to be removed, got: %v", err)
+	}
+	keptRel, err := relationClient.GetRelationship(ctx, &dapi.GetRelationshipRequest{
+		Collection: "event_participant_person",
+		Key:        dupRelations[1].GetKey(),
+	})
+	if err != nil {
+		t.Fatalf("Expected the relationship with a validity interval to survive the merge: %v", err)
+	}
+	if keptRel.Relationship.GetTo() != p1.GetPerson().GetId() {
+		t.Errorf("Expected the kept relationship to point at %s, got %s", p1.GetPerson().GetId(), keptRel.Relationship.GetTo())
+	}
+	_, err = relationClient.DeleteRelationship(ctx, &dapi.DeleteRelationshipRequest{
+		Collection: "event_participant_person",
+		Key:        dupRelations[1].GetKey(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to delete relationship with validity interval: %v", err)
+	}
+
+	redirected, err := entityClient.GetEntity(ctx, &dapi.GetEntityRequest{
+		EntityType: "person",
+		Key:        pDup.GetPerson().GetKey(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to get merged person: %v", err)
+	}
+	if redirected.Entity.GetPerson().GetId() != p1.GetPerson().GetId() || redirected.RedirectedFrom != pDup.GetPerson().GetId() {
+		t.Errorf("Expected %s to redirect to %s, got %s", pDup.GetPerson().GetId(), p1.GetPerson().GetId(), redirected.Entity.GetPerson().GetId())
+	}
+
+	split, err := resolutionClient.SplitEntity(ctx, &dapi.SplitEntityRequest{
+		EntityType: "person",
+		Key:        p1.GetPerson().GetKey(),
+		Fields:     []string{"role"},
+		Aliases:    []string{"Gandalf"},
+		Entity: &model.Entity{Entity: &model.Entity_Person{Person: &model.Person{
+			Name: "Gandalf the Grey",
+		}}},
+		RelationshipIds: []string{participant.Relationship.GetId()},
+	})
+	if err != nil {
+		t.Fatalf("Failed to split person: %v", err)
+	}
+	pSplit := split.SplitEntity
+	if pSplit.GetPerson().GetName() != "Gandalf the Grey" || !slices.Contains(pSplit.GetPerson().GetAliases(), "Gandalf") {
+		t.Errorf("Unexpected split person: %v", pSplit.GetPerson())
+	}
+	if slices.Contains(split.Entity.GetPerson().GetAliases(), "Gandalf") {
+		t.Errorf("Expected alias to move off the split person, got %v", split.Entity.GetPerson().GetAliases())
+	}
+	if split.RelationshipsMoved != 1 {
+		t.Errorf("Expected 1 relationship moved by the split, got %d", split.RelationshipsMoved)
+	}
+	movedParticipant, err := relationClient.GetRelationship(ctx, &dapi.GetRelationshipRequest{
+		Collection: "event_participant_person",
+		Key:        participant.Relationship.GetKey(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to get relationship moved by the split: %v", err)
+	}
+	if movedParticipant.Relationship.GetFrom() != e1.GetEvent().GetId() || movedParticipant.Relationship.GetTo() != pSplit.GetPerson().GetId() {
+		t.Errorf("Expected relationship to move from %s to %s, got %s -> %s", p1.GetPerson().GetId(), pSplit.GetPerson().GetId(),
+			movedParticipant.Relationship.GetFrom(), movedParticipant.Relationship.GetTo())
+	}
+
+	// --- 4.10 Upsert by Natural Key ---
+	upserted, err := entityClient.UpsertEntity(ctx, &dapi.UpsertEntityRequest{
+		EntityType: "website",
+		Entity: &model.Entity{Entity: &model.Entity_Website{Website: &model.Website{
+			// Matched after normalization strips the tracking parameter
+			Url:   strings.Replace(w1.GetWebsite().GetUrl(), "https://www.", "HTTPS://WWW.", 1) + "/?utm_source=newsletter",
+			Title: "  独角兽供应链官方网站 ",
+			Tags:  []string{"Official", "official", "魔法"},
+		}}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to upsert existing website: %v", err)
+	}
+	if upserted.Created || upserted.Entity.GetWebsite().GetKey() != w1.GetWebsite().GetKey() {
+		t.Errorf("Expected upsert to update %s, got %v", w1.GetWebsite().GetId(), upserted)
+	}
+	if website := upserted.Entity.GetWebsite(); website.GetUrl() != w1.GetWebsite().GetUrl() || website.GetTitle() != "独角兽供应链官方网站" || !slices.Equal(website.GetTags(), []string{"official", "魔法"}) {
+		t.Errorf("Expected normalized url, title and tags, got %v", website)
+	}
+
+	_, err = entityClient.CreateEntity(ctx, &dapi.CreateEntityRequest{
+		EntityType: "website",
+		Entity: &model.Entity{Entity: &model.Entity_Website{Website: &model.Website{
+			Url: w1.GetWebsite().GetUrl(),
+		}}},
+	})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("Expected AlreadyExists creating a website with a taken url, got %v", err)
+	}
+	conflictReported := false
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetMetadata()["conflicting_key"] == w1.GetWebsite().GetKey() {
+			conflictReported = true
+		}
+	}
+	if !conflictReported {
+		t.Errorf("Expected the conflicting key in the error details, got %v", status.Convert(err).Details())
+	}
+
+	_, err = entityClient.CreateEntity(ctx, &dapi.CreateEntityRequest{
+		EntityType: "event",
+		Entity: &model.Entity{Entity: &model.Entity_Event{Event: &model.Event{
+			Title:    "Invalid event",
+			Location: &model.LocationData{Latitude: 100, Longitude: 0, CountryCode: "FANTASY"},
+		}}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument creating an event with an invalid location, got %v", err)
+	}
+	var violatedFields []string
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				violatedFields = append(violatedFields, violation.GetField())
+			}
+		}
+	}
+	if !slices.Contains(violatedFields, "entity.event.location.latitude") || !slices.Contains(violatedFields, "entity.event.location.country_code") {
+		t.Errorf("Expected latitude and country_code violations, got %v", violatedFields)
+	}
+
+	_, err = entityClient.CreateEntity(ctx, &dapi.CreateEntityRequest{
+		EntityType: "event",
+		Entity:     &model.Entity{Entity: &model.Entity_Person{Person: &model.Person{Name: "Mismatched"}}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument creating an event from a person, got %v", err)
+	}
+
+	idempotentCtx := metadata.AppendToOutgoingContext(ctx, "idempotency-key", fmt.Sprintf("ingest-%d", time.Now().UnixNano()))
+	batchReq := &dapi.BatchCreateEntitiesRequest{
+		EntityType: "website",
+		Upsert:     true,
+		Entities: []*model.Entity{
+			{Entity: &model.Entity_Website{Website: &model.Website{
+				Read:  []string{"admin"},
+				Write: []string{"admin"},
+				Url:   fmt.Sprintf("https://www.batch-%d.fantasy", time.Now().UnixNano()),
+				Title: "Batch",
+			}}},
+		},
+	}
+	batch, err := entityClient.BatchCreateEntities(idempotentCtx, batchReq)
+	if err != nil {
+		t.Fatalf("Failed to batch upsert websites: %v", err)
+	}
+	retried, err := entityClient.BatchCreateEntities(idempotentCtx, batchReq)
+	if err != nil {
+		t.Fatalf("Failed to retry batch upsert: %v", err)
+	}
+	wBatch := batch.Entities[0]
+	if !batch.Created[0] || retried.Entities[0].GetWebsite().GetKey() != wBatch.GetWebsite().GetKey() || !retried.Created[0] {
+		t.Errorf("Expected retried batch to replay the first response, got %v and %v", batch, retried)
+	}
+
+	// --- 4.11 Bulk Import ---
+
+	importedKey := fmt.Sprintf("imported-%d", time.Now().UnixNano())
+	importLines := []string{
+		fmt.Sprintf(`{"organization": {"key": %q, "name": "Imported Org", "tags": ["Import", "import"]}}`, importedKey),
+		fmt.Sprintf(`{"from": %q, "to": "organization/%s", "name": "sponsor", "confidence": 60}`, e1.GetEvent().GetId(), importedKey),
+		`{"event": {"title": "Broken", "location": {"latitude": 120}}}`,
+		`not json`,
+	}
+	importStream, err := entityClient.ImportEntities(ctx)
+	if err != nil {
+		t.Fatalf("Failed to open import stream: %v", err)
+	}
+	if err := importStream.Send(&dapi.ImportEntitiesRequest{Options: &dapi.ImportOptions{Format: "jsonl", BatchSize: 10}}); err != nil {
+		t.Fatalf("Failed to send import options: %v", err)
+	}
+	for _, line := range importLines {
+		if err := importStream.Send(&dapi.ImportEntitiesRequest{Data: []byte(line + "\n")}); err != nil {
+			t.Fatalf("Failed to send import data: %v", err)
+		}
+	}
+	imported, err := importStream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("Failed to import: %v", err)
+	}
+	if imported.EntitiesImported != 1 || imported.RelationshipsImported != 1 || imported.RecordsFailed != 2 {
+		t.Errorf("Expected 1 entity and 1 relationship imported and 2 failures, got %v", imported)
+	}
+	if len(imported.Errors) != 2 || imported.Errors[0].GetLine() != 3 || imported.Errors[1].GetLine() != 4 {
+		t.Errorf("Expected errors on lines 3 and 4, got %v", imported.Errors)
+	}
+
+	// CSV with mapped columns, list cells and free-form attributes
+	importCSV := func(options *dapi.ImportOptions, data string) (*dapi.ImportEntitiesResponse, error) {
+		stream, err := entityClient.ImportEntities(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := stream.Send(&dapi.ImportEntitiesRequest{Options: options, Data: []byte(data)}); err != nil {
+			return nil, err
+		}
+		return stream.CloseAndRecv()
+	}
+	csvKey := fmt.Sprintf("imported-csv-%d", time.Now().UnixNano())
+	csvColumns := map[string]string{
+		"Id":       "key",
+		"Org Name": "name",
+		"Labels":   "tags",
+		"HQ":       "attributes.hq.country",
+	}
+	csvImported, err := importCSV(&dapi.ImportOptions{Format: "csv", EntityType: "organization", Columns: csvColumns},
+		"Id,Org Name,Labels,HQ,Ignored\n"+csvKey+",CSV Org,Alpha; beta;;alpha,DE,x\n")
+	if err != nil {
+		t.Fatalf("Failed to import CSV: %v", err)
+	}
+	if csvImported.EntitiesImported != 1 || csvImported.RecordsFailed != 0 {
+		t.Errorf("Expected 1 entity imported from CSV, got %v", csvImported)
+	}
+	csvOrg, err := entityClient.GetEntity(ctx, &dapi.GetEntityRequest{EntityType: "organization", Key: csvKey})
+	if err != nil {
+		t.Fatalf("Failed to get organization imported from CSV: %v", err)
+	}
+	if org := csvOrg.GetEntity().GetOrganization(); org.GetName() != "CSV Org" || !slices.Equal(org.GetTags(), []string{"alpha", "beta"}) {
+		t.Errorf("Organization imported from CSV has unexpected fields: %v", org)
+	}
+	if hq, _ := csvOrg.GetEntity().GetOrganization().GetAttributes().AsMap()["hq"].(map[string]interface{}); hq["country"] != "DE" {
+		t.Errorf("Organization imported from CSV lost its attributes: %v", csvOrg.GetEntity().GetOrganization().GetAttributes())
+	}
+
+	csvColumns["Missing"] = "description"
+	if _, err := importCSV(&dapi.ImportOptions{Format: "csv", EntityType: "organization", Columns: csvColumns},
+		"Id,Org Name,Labels,HQ\n"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a mapped column missing from the CSV header, got: %v", err)
+	}
+
+	// --- 4.12 STIX ---
+
+	stixExport, err := graphClient.ExportStix(ctx, &dapi.ExportStixRequest{
+		RootIds: []string{e1.GetEvent().GetId()},
+		Depth:   1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to export STIX: %v", err)
+	}
+	stixTypes := map[string]string{}
+	for _, object := range stixExport.GetBundle().AsMap()["objects"].([]interface{}) {
+		object := object.(map[string]interface{})
+		stixTypes[object["x_omnsight_id"].(string)] = object["type"].(string)
+	}
+	if stixTypes[e1.GetEvent().GetId()] != "incident" || !slices.Contains(slices.Collect(maps.Values(stixTypes)), "relationship") {
+		t.Errorf("ExportStix returned unexpected objects: %v", stixTypes)
+	}
+
+	stixOrgKey, stixReportKey := uuid.NewString(), uuid.NewString()
+	stixBundle, err := structpb.NewStruct(map[string]interface{}{
+		"type": "bundle",
+		"id":   "bundle--" + uuid.NewString(),
+		"objects": []interface{}{
+			map[string]interface{}{"type": "identity", "id": "identity--" + stixOrgKey, "name": "STIX Org", "identity_class": "organization"},
+			map[string]interface{}{"type": "report", "id": "report--" + stixReportKey, "name": "STIX Report", "published": "2020-01-01T00:00:00Z"},
+			map[string]interface{}{"type": "relationship", "id": "relationship--" + uuid.NewString(), "relationship_type": "sponsor",
+				"source_ref": "report--" + stixReportKey, "target_ref": "identity--" + stixOrgKey, "confidence": 70},
+			map[string]interface{}{"type": "indicator", "id": "indicator--" + uuid.NewString(), "pattern": "[url:value = 'http://x']"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build STIX bundle: %v", err)
+	}
+	stixImported, err := entityClient.ImportStix(ctx, &dapi.ImportStixRequest{Bundle: stixBundle})
+	if err != nil {
+		t.Fatalf("Failed to import STIX: %v", err)
+	}
+	if stixImported.EntitiesImported != 2 || stixImported.RelationshipsImported != 1 || stixImported.RecordsFailed != 1 {
+		t.Errorf("Expected 2 entities and 1 relationship imported from STIX and 1 failure, got %v", stixImported)
+	}
+	if len(stixImported.Errors) != 1 || !strings.HasPrefix(stixImported.Errors[0].GetObjectId(), "indicator--") {
+		t.Errorf("Expected the indicator to fail, got %v", stixImported.Errors)
+	}
+	stixOrg, err := entityClient.GetEntity(ctx, &dapi.GetEntityRequest{EntityType: "organization", Key: stixOrgKey})
+	if err != nil {
+		t.Fatalf("Failed to get organization imported from STIX: %v", err)
+	}
+	if stixOrg.GetEntity().GetOrganization().GetAttributes().AsMap()["stix_id"] != "identity--"+stixOrgKey {
+		t.Errorf("Organization imported from STIX lost its STIX id: %v", stixOrg.GetEntity())
+	}
+
+	// Import the export back into fresh keys, as into another database. Website
+	// URLs are unique so the copied websites get their own hosts.
+	stixIds := map[string]string{}
+	for _, object := range stixExport.GetBundle().AsMap()["objects"].([]interface{}) {
+		object := object.(map[string]interface{})
+		stixIds[object["x_omnsight_id"].(string)] = ""
+		sources, _ := object["x_omnsight_sources"].([]interface{})
+		for _, entry := range sources {
+			stixIds[entry.(map[string]interface{})["source_id"].(string)] = ""
+		}
+	}
+	var replacements []string
+	for id := range stixIds {
+		collection, _, _ := strings.Cut(id, "/")
+		stixIds[id] = collection + "/" + uuid.NewString()
+		replacements = append(replacements, `"`+id+`"`, `"`+stixIds[id]+`"`)
+	}
+	stixData, err := json.Marshal(stixExport.GetBundle().AsMap())
+	if err != nil {
+		t.Fatalf("Failed to marshal STIX bundle: %v", err)
+	}
+	var stixCopy map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.NewReplacer(replacements...).Replace(string(stixData))), &stixCopy); err != nil {
+		t.Fatalf("Failed to unmarshal STIX bundle: %v", err)
+	}
+	// Objects of the copy by x_omnsight_id, and x_omnsight_ids by STIX id
+	stixCopyObjects, stixCopyIds := map[string]map[string]interface{}{}, map[string]string{}
+	stixCopyRelationships := 0
+	for _, object := range stixCopy["objects"].([]interface{}) {
+		object := object.(map[string]interface{})
+		if fields, ok := object["x_omnsight_entity"].(map[string]interface{}); ok && object["type"] == "infrastructure" && fields["url"] != nil {
+			fields["url"] = strings.Replace(fields["url"].(string), "://", "://copy-"+uuid.NewString()+".", 1)
+		}
+		if object["type"] == "relationship" {
+			stixCopyRelationships++
+		}
+		stixCopyObjects[object["x_omnsight_id"].(string)] = object
+		stixCopyIds[object["id"].(string)] = object["x_omnsight_id"].(string)
+	}
+	stixCopyBundle, err := structpb.NewStruct(stixCopy)
+	if err != nil {
+		t.Fatalf("Failed to build STIX bundle: %v", err)
+	}
+	stixRoundTrip, err := entityClient.ImportStix(ctx, &dapi.ImportStixRequest{Bundle: stixCopyBundle})
+	if err != nil {
+		t.Fatalf("Failed to import exported STIX: %v", err)
+	}
+	if stixRoundTrip.RecordsFailed != 0 || stixRoundTrip.RelationshipsImported < int64(stixCopyRelationships) {
+		t.Fatalf("Expected the exported STIX to import without failures, got %v", stixRoundTrip)
+	}
+
+	stixReexport, err := graphClient.ExportStix(ctx, &dapi.ExportStixRequest{
+		RootIds: []string{stixIds[e1.GetEvent().GetId()]},
+		Depth:   1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to export imported STIX: %v", err)
+	}
+	stixReexported, stixReexportedIds := map[string]map[string]interface{}{}, map[string]string{}
+	for _, object := range stixReexport.GetBundle().AsMap()["objects"].([]interface{}) {
+		object := object.(map[string]interface{})
+		stixReexported[object["x_omnsight_id"].(string)] = object
+		stixReexportedIds[object["id"].(string)] = object["x_omnsight_id"].(string)
+	}
+	for id, imported := range stixCopyObjects {
+		exported, ok := stixReexported[id]
+		if !ok {
+			t.Errorf("STIX object %s was not imported under its id", id)
+			continue
+		}
+		fieldsProperty := "x_omnsight_entity"
+		if imported["type"] == "relationship" {
+			fieldsProperty = "x_omnsight_relation"
+			for _, ref := range []string{"source_ref", "target_ref"} {
+				if stixReexportedIds[exported[ref].(string)] != stixCopyIds[imported[ref].(string)] {
+					t.Errorf("Relationship %s lost its %s", id, ref)
+				}
+			}
+		}
+		importedFields := imported[fieldsProperty].(map[string]interface{})
+		exportedFields := exported[fieldsProperty].(map[string]interface{})
+		for _, field := range []string{"name", "title", "url", "label", "description", "confidence", "tags"} {
+			if fmt.Sprint(importedFields[field]) != fmt.Sprint(exportedFields[field]) {
+				t.Errorf("STIX object %s changed its %s from %v to %v", id, field, importedFields[field], exportedFields[field])
+			}
+		}
+	}
+
 	// --- 5. Delete One of Each ---
 
 	// Delete Temp Relation
 	_, err = relationClient.DeleteRelationship(ctx, &dapi.DeleteRelationshipRequest{
-		Collection: "event_temp_relation_person",
+		Collection: "event_temp_relation_organization",
 		Key:        tempRel.GetKey(),
 	})
 	if err != nil {
@@ -687,6 +1888,40 @@ func TestIntegration(t *testing.T) {
 		t.Fatalf("Failed to delete p3: %v", err)
 	}
 
+	// Delete batch Website
+	_, err = entityClient.DeleteEntity(ctx, &dapi.DeleteEntityRequest{
+		EntityType: "website",
+		Key:        wBatch.GetWebsite().GetKey(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to delete batch website: %v", err)
+	}
+
+	// Delete split Person
+	_, err = entityClient.DeleteEntity(ctx, &dapi.DeleteEntityRequest{
+		EntityType: "person",
+		Key:        pSplit.GetPerson().GetKey(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to delete split person: %v", err)
+	}
+
+	// Delete imported Organization
+	_, err = entityClient.DeleteEntity(ctx, &dapi.DeleteEntityRequest{
+		EntityType: "organization",
+		Key:        importedKey,
+	})
+	if err != nil {
+		t.Fatalf("Failed to delete imported organization: %v", err)
+	}
+
+	// Delete entities imported from STIX
+	for entityType, key := range map[string]string{"organization": stixOrgKey, "event": stixReportKey} {
+		if _, err := entityClient.DeleteEntity(ctx, &dapi.DeleteEntityRequest{EntityType: entityType, Key: key}); err != nil {
+			t.Fatalf("Failed to delete %s imported from STIX: %v", entityType, err)
+		}
+	}
+
 	// Delete Event 3
 	_, err = entityClient.DeleteEntity(ctx, &dapi.DeleteEntityRequest{
 		EntityType: "event",
@@ -5,6 +5,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -16,8 +17,13 @@ import (
 
 	gwRuntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/cli"
 	entityservice "github.com/omnsight/omndapi/src/entity_service"
+	graphservice "github.com/omnsight/omndapi/src/graph_service"
+	"github.com/omnsight/omndapi/src/migrations"
 	relationshipservice "github.com/omnsight/omndapi/src/relationship_service"
+	resolutionservice "github.com/omnsight/omndapi/src/resolution_service"
+	shareservice "github.com/omnsight/omndapi/src/share_service"
 	"github.com/omnsight/omndapi/src/utils"
 )
 
@@ -27,6 +33,14 @@ func main() {
 		logrus.Warn("No .env file found or failed to load, relying on environment variables")
 	}
 
+	// Subcommands run instead of the server
+	if len(os.Args) > 1 {
+		if err := cli.Run(os.Args[1], os.Args[2:]); err != nil {
+			logrus.Fatal(err)
+		}
+		return
+	}
+
 	// ---- 1. Start the gRPC Server (your logic) ----
 	// Get gRPC address from environment variable or use default
 	grpcPort := os.Getenv(utils.GrpcPort)
@@ -47,6 +61,7 @@ func main() {
 	// Create a gRPC server
 	gRPCServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(utils.LoggingInterceptor, utils.GrpcGatewayIdentityInterceptor(clientId)),
+		grpc.ChainStreamInterceptor(utils.LoggingStreamInterceptor, utils.GrpcGatewayIdentityStreamInterceptor(clientId)),
 	)
 
 	// Create a new ArangoDB client
@@ -57,6 +72,19 @@ func main() {
 		}).Fatal("failed to establish ArangoDB client")
 	}
 
+	// Apply pending schema migrations, replicas wait for the one holding the lock
+	migrator, err := migrations.NewMigrator(context.Background(), client)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("failed to create migrator")
+	}
+	if _, err := migrator.Up(context.Background(), 0); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("failed to apply migrations")
+	}
+
 	// Register your business logic implementation with the gRPC server
 	entityService, err := entityservice.NewEntityService(client)
 	if err != nil {
@@ -74,6 +102,48 @@ func main() {
 	}
 	dapi.RegisterRelationshipServiceServer(gRPCServer, relationService)
 
+	graphService, err := graphservice.NewGraphService(client)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("failed to create GraphService")
+	}
+	dapi.RegisterGraphServiceServer(gRPCServer, graphService)
+
+	shareService, err := shareservice.NewShareService(client)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("failed to create ShareService")
+	}
+	dapi.RegisterShareServiceServer(gRPCServer, shareService)
+
+	resolutionService, err := resolutionservice.NewResolutionService(client)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("failed to create ResolutionService")
+	}
+	dapi.RegisterResolutionServiceServer(gRPCServer, resolutionService)
+
+	// Periodically remove expired share grants
+	grantSweepInterval := 10 * time.Minute
+	if v := os.Getenv(utils.GrantSweepInterval); v != "" {
+		if grantSweepInterval, err = time.ParseDuration(v); err != nil {
+			logrus.Fatalf("invalid %s: %v", utils.GrantSweepInterval, err)
+		}
+	}
+	go shareService.Pipeline.RunGrantSweeper(context.Background(), grantSweepInterval)
+
+	// Periodically score entities for duplicates
+	duplicateScanInterval := time.Hour
+	if v := os.Getenv(utils.DuplicateScanInterval); v != "" {
+		if duplicateScanInterval, err = time.ParseDuration(v); err != nil {
+			logrus.Fatalf("invalid %s: %v", utils.DuplicateScanInterval, err)
+		}
+	}
+	go resolutionService.Pipeline.RunDuplicateScanner(context.Background(), duplicateScanInterval)
+
 	// Enable reflection for debugging
 	reflection.Register(gRPCServer)
 
@@ -107,7 +177,24 @@ func main() {
 
 	// Create the gRPC-Gateway's multiplexer (router)
 	// This mux knows how to translate HTTP routes (from proto definitions) to gRPC calls
-	gwmux := gwRuntime.NewServeMux()
+	gwmux := gwRuntime.NewServeMux(
+		gwRuntime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+			// Forward share link tokens and idempotency keys as gRPC metadata
+			switch strings.ToLower(key) {
+			case utils.ShareTokenHeader, utils.IdempotencyKeyHeader:
+				return strings.ToLower(key), true
+			}
+			return gwRuntime.DefaultHeaderMatcher(key)
+		}),
+		gwRuntime.WithOutgoingHeaderMatcher(func(key string) (string, bool) {
+			// Forward export file names instead of prefixing them as gRPC metadata
+			switch key {
+			case utils.ContentDispositionHeader, utils.GraphTruncatedHeader:
+				return key, true
+			}
+			return gwRuntime.MetadataHeaderPrefix + key, true
+		}),
+	)
 
 	// Register all service handlers with the gateway's router
 	if err := dapi.RegisterEntityServiceHandler(ctx, gwmux, conn); err != nil {
@@ -120,6 +207,21 @@ func main() {
 			"error": err,
 		}).Fatal("failed to register RelationshipService handler")
 	}
+	if err := dapi.RegisterGraphServiceHandler(ctx, gwmux, conn); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("failed to register GraphService handler")
+	}
+	if err := dapi.RegisterShareServiceHandler(ctx, gwmux, conn); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("failed to register ShareService handler")
+	}
+	if err := dapi.RegisterResolutionServiceHandler(ctx, gwmux, conn); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": err,
+		}).Fatal("failed to register ResolutionService handler")
+	}
 
 	// ---- 3. Start the Gin Server (the HTTP entrypoint) ----
 	// Create a Gin router
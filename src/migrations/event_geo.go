@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+)
+
+// addEventGeo derives the GeoJSON point of events stored before points were
+// written with the location, as pipeline.GeoPoint does.
+func addEventGeo(ctx context.Context, client *utils.ArangoDBClient) error {
+	cursor, err := client.DB.Query(ctx, `
+		FOR e IN event
+		FILTER IS_NUMBER(e.location.latitude) AND IS_NUMBER(e.location.longitude)
+		FILTER e.location.latitude != 0 OR e.location.longitude != 0
+		FILTER e.@geo == null
+		UPDATE e WITH { [@geo]: GEO_POINT(e.location.longitude, e.location.latitude) } IN event
+	`, map[string]interface{}{
+		"geo": pipeline.GeoField,
+	})
+	if err != nil {
+		return err
+	}
+	return cursor.Close()
+}
+
+func removeEventGeo(ctx context.Context, client *utils.ArangoDBClient) error {
+	cursor, err := client.DB.Query(ctx, `
+		FOR e IN event
+		FILTER e.@geo != null
+		UPDATE e WITH { [@geo]: null } IN event
+		OPTIONS { keepNull: false }
+	`, map[string]interface{}{
+		"geo": pipeline.GeoField,
+	})
+	if err != nil {
+		return err
+	}
+	return cursor.Close()
+}
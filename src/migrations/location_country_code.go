@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/omnsight/omndapi/src/utils"
+)
+
+// renameLocationCountryCode moves event locations written with the JSON name
+// countryCode to country_code, the name the model is stored with. A
+// country_code already set wins.
+func renameLocationCountryCode(ctx context.Context, client *utils.ArangoDBClient) error {
+	cursor, err := client.DB.Query(ctx, `
+		FOR e IN event
+		FILTER HAS(e.location, "countryCode")
+		UPDATE e WITH {
+			location: {
+				country_code: e.location.country_code ? e.location.country_code : e.location.countryCode,
+				countryCode: null
+			}
+		} IN event
+		OPTIONS { keepNull: false }
+	`, nil)
+	if err != nil {
+		return err
+	}
+	return cursor.Close()
+}
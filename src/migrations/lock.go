@@ -0,0 +1,140 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultLockTimeout = 5 * time.Minute
+	// A lock not renewed within the lease was left by a crashed replica
+	lockLease = time.Minute
+	lockRetry = time.Second
+)
+
+// Key of the lock document in Collection, the other documents record the
+// applied migrations
+const LockKey = "lock"
+
+// lockDocument is held by the replica running migrations.
+type lockDocument struct {
+	Key       string `json:"_key"`
+	Holder    string `json:"holder"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// withLock runs fn while holding the migration lock, so replicas starting
+// together apply each migration once. The lock is renewed while fn runs; if
+// renewal fails another replica may take the lock, so the context of fn is
+// cancelled and the renewal error returned.
+func (m *Migrator) withLock(ctx context.Context, fn func(ctx context.Context) error) error {
+	hostname, _ := os.Hostname()
+	holder := fmt.Sprintf("%s/%d/%s", hostname, os.Getpid(), uuid.NewString())
+
+	rev, err := m.acquireLock(ctx, holder)
+	if err != nil {
+		return err
+	}
+
+	fnCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	var lost error
+	stop := make(chan struct{})
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		ticker := time.NewTicker(lockLease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				meta, err := m.col.ReplaceDocument(driver.WithRevision(ctx, rev), LockKey, m.newLock(holder))
+				if err != nil {
+					lost = fmt.Errorf("failed to renew migration lock: %w", err)
+					cancel(lost)
+					return
+				}
+				rev = meta.Rev
+			}
+		}
+	}()
+
+	err = fn(fnCtx)
+
+	close(stop)
+	<-renewed
+	if lost != nil {
+		return lost
+	}
+	if _, releaseErr := m.col.RemoveDocument(driver.WithRevision(ctx, rev), LockKey); releaseErr != nil {
+		logrus.WithFields(logrus.Fields{
+			"error": releaseErr,
+		}).Warn("failed to release migration lock")
+	}
+	return err
+}
+
+// acquireLock waits until the lock is free or expired and takes it. The
+// revision of the lock document guards against replicas taking it together.
+func (m *Migrator) acquireLock(ctx context.Context, holder string) (string, error) {
+	deadline := time.Now().Add(m.LockTimeout)
+	for {
+		meta, err := m.col.CreateDocument(ctx, m.newLock(holder))
+		if err == nil {
+			return meta.Rev, nil
+		}
+		if !driver.IsConflict(err) {
+			return "", fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+
+		var current lockDocument
+		meta, err = m.col.ReadDocument(ctx, LockKey, &current)
+		switch {
+		case driver.IsNotFound(err):
+			// Released in the meantime
+			continue
+		case err != nil:
+			return "", fmt.Errorf("failed to read migration lock: %w", err)
+		case current.ExpiresAt < time.Now().UnixMilli():
+			logrus.WithFields(logrus.Fields{
+				"holder": current.Holder,
+			}).Warn("taking over expired migration lock")
+			meta, err = m.col.ReplaceDocument(driver.WithRevision(ctx, meta.Rev), LockKey, m.newLock(holder))
+			if err == nil {
+				return meta.Rev, nil
+			}
+			if !driver.IsPreconditionFailed(err) && !driver.IsNotFound(err) {
+				return "", fmt.Errorf("failed to acquire migration lock: %w", err)
+			}
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("migration lock is held by %s", current.Holder)
+		}
+		logrus.WithFields(logrus.Fields{
+			"holder": current.Holder,
+		}).Info("waiting for migration lock")
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(lockRetry):
+		}
+	}
+}
+
+func (m *Migrator) newLock(holder string) lockDocument {
+	return lockDocument{
+		Key:       LockKey,
+		Holder:    holder,
+		ExpiresAt: time.Now().Add(lockLease).UnixMilli(),
+	}
+}
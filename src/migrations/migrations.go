@@ -0,0 +1,227 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/src/entity_service/collections"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// Collection recording the applied migrations and holding the migration lock
+const Collection = "_migrations"
+
+// Migration changes the schema or the documents of the database. Up must be
+// idempotent: a migration interrupted before it is recorded runs again.
+// Migrations without Down cannot be reverted.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, client *utils.ArangoDBClient) error
+	Down    func(ctx context.Context, client *utils.ArangoDBClient) error
+}
+
+// Migrations lists every migration by ascending version. Versions are never
+// reused or reordered once released.
+var Migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "create collections and indexes",
+		Up: func(ctx context.Context, client *utils.ArangoDBClient) error {
+			return collections.RegisterAll(ctx, client, pipeline.NewWorker(client))
+		},
+	},
+	{
+		Version: 2,
+		Name:    "rename location.countryCode to location.country_code",
+		Up:      renameLocationCountryCode,
+	},
+	{
+		Version: 3,
+		Name:    "add GeoJSON points to event locations",
+		Up:      addEventGeo,
+		Down:    removeEventGeo,
+	},
+	{
+		Version: 4,
+		Name:    "register relation types of existing edge collections",
+		Up:      registerEdgeRelationTypes,
+	},
+	{
+		Version: 5,
+		Name:    "add path weights to relationships with a confidence",
+		Up:      addPathWeight,
+		Down:    removePathWeight,
+	},
+}
+
+// record is a document of the migrations collection.
+type record struct {
+	Key       string `json:"_key"`
+	Version   int    `json:"version"`
+	Name      string `json:"name"`
+	AppliedAt int64  `json:"applied_at"`
+}
+
+// Status is a known or applied migration. AppliedAt is zero for pending
+// migrations and Unknown is set for versions applied by a newer release.
+type Status struct {
+	Version   int
+	Name      string
+	AppliedAt int64
+	Unknown   bool
+}
+
+// Migrator applies and reverts migrations while holding the migration lock.
+type Migrator struct {
+	client     *utils.ArangoDBClient
+	migrations []Migration
+	col        driver.Collection
+	// How long to wait for another replica holding the lock
+	LockTimeout time.Duration
+}
+
+func NewMigrator(ctx context.Context, client *utils.ArangoDBClient) (*Migrator, error) {
+	col, err := client.GetCreateDocumentCollection(ctx, Collection, &driver.CreateCollectionOptions{
+		IsSystem: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migrations collection: %w", err)
+	}
+	return &Migrator{
+		client:      client,
+		migrations:  Migrations,
+		col:         col,
+		LockTimeout: DefaultLockTimeout,
+	}, nil
+}
+
+// Up applies the pending migrations up to the target version, or all of
+// them if target is 0. It returns the applied versions.
+func (m *Migrator) Up(ctx context.Context, target int) ([]int, error) {
+	var done []int
+	err := m.withLock(ctx, func(ctx context.Context) error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if target > 0 && migration.Version > target {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			logrus.WithFields(logrus.Fields{
+				"version": migration.Version,
+				"name":    migration.Name,
+			}).Info("applying migration")
+			if err := migration.Up(ctx, m.client); err != nil {
+				return fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Name, err)
+			}
+			if _, err := m.col.CreateDocument(ctx, record{
+				Key:       strconv.Itoa(migration.Version),
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now().UnixMilli(),
+			}); err != nil {
+				return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+			}
+			done = append(done, migration.Version)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverts the given number of most recently applied migrations and
+// returns the reverted versions. It stops at the first irreversible one.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]int, error) {
+	var done []int
+	err := m.withLock(ctx, func(ctx context.Context) error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == nil {
+				return fmt.Errorf("migration %d (%s) cannot be reverted", migration.Version, migration.Name)
+			}
+
+			logrus.WithFields(logrus.Fields{
+				"version": migration.Version,
+				"name":    migration.Name,
+			}).Info("reverting migration")
+			if err := migration.Down(ctx, m.client); err != nil {
+				return fmt.Errorf("reverting migration %d (%s) failed: %w", migration.Version, migration.Name, err)
+			}
+			if _, err := m.col.RemoveDocument(ctx, strconv.Itoa(migration.Version)); err != nil {
+				return fmt.Errorf("failed to remove migration record %d: %w", migration.Version, err)
+			}
+			done = append(done, migration.Version)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status lists the known migrations, followed by applied versions this
+// release does not know.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Status
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if r, ok := applied[migration.Version]; ok {
+			status.AppliedAt = r.AppliedAt
+			delete(applied, migration.Version)
+		}
+		result = append(result, status)
+	}
+	for _, r := range applied {
+		result = append(result, Status{Version: r.Version, Name: r.Name, AppliedAt: r.AppliedAt, Unknown: true})
+	}
+	return result, nil
+}
+
+// applied returns the records of the applied migrations by version.
+func (m *Migrator) applied(ctx context.Context) (map[int]record, error) {
+	cursor, err := m.client.DB.Query(ctx, `
+		FOR m IN @@collection
+		FILTER HAS(m, "version")
+		SORT m.version
+		RETURN m
+	`, map[string]interface{}{
+		"@collection": Collection,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer cursor.Close()
+
+	applied := make(map[int]record)
+	for {
+		var r record
+		if _, err := cursor.ReadDocument(ctx, &r); driver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+		}
+		applied[r.Version] = r
+	}
+	return applied, nil
+}
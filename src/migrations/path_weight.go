@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+)
+
+// addPathWeight derives the path weight of relationships stored with a
+// confidence before weights were written with it, as pipeline.PathWeight does.
+func addPathWeight(ctx context.Context, client *utils.ArangoDBClient) error {
+	collectionNames, err := pipeline.NewWorker(client).ListEdgeCollections(ctx, "", "", "")
+	if err != nil {
+		return err
+	}
+	for _, name := range collectionNames {
+		cursor, err := client.DB.Query(ctx, `
+			FOR e IN @@collection
+			FILTER IS_NUMBER(e.confidence)
+			UPDATE e WITH { [@weight]: @defaultWeight - MIN([MAX([e.confidence, 0]), @maxConfidence]) / @maxConfidence } IN @@collection
+		`, map[string]interface{}{
+			"@collection":   name,
+			"weight":        pipeline.PathWeightField,
+			"defaultWeight": pipeline.DefaultPathWeight,
+			"maxConfidence": pipeline.MaxConfidence,
+		})
+		if err != nil {
+			return err
+		}
+		if err := cursor.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func removePathWeight(ctx context.Context, client *utils.ArangoDBClient) error {
+	collectionNames, err := pipeline.NewWorker(client).ListEdgeCollections(ctx, "", "", "")
+	if err != nil {
+		return err
+	}
+	for _, name := range collectionNames {
+		cursor, err := client.DB.Query(ctx, `
+			FOR e IN @@collection
+			FILTER e.@weight != null
+			UPDATE e WITH { [@weight]: null } IN @@collection
+			OPTIONS { keepNull: false }
+		`, map[string]interface{}{
+			"@collection": name,
+			"weight":      pipeline.PathWeightField,
+		})
+		if err != nil {
+			return err
+		}
+		if err := cursor.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+)
+
+// registerEdgeRelationTypes registers the relation names of edge collections
+// created before relationships were validated against the relation type
+// registry, allowing the entity types they already connect. Registered
+// relation types are left unchanged. Edge collections with a relation name
+// that is no longer accepted, such as a non-ASCII one, fail the migration so
+// that they are renamed rather than left out of the registry.
+func registerEdgeRelationTypes(ctx context.Context, client *utils.ArangoDBClient) error {
+	worker := pipeline.NewWorker(client)
+	collectionNames, err := worker.ListEdgeCollections(ctx, "", "", "")
+	if err != nil {
+		return err
+	}
+
+	relationTypes := make(map[string]*dapi.RelationType)
+	var relationNames, invalid []string
+	for _, name := range collectionNames {
+		fromType, relationName, toType, _ := pipeline.ParseEdgeCollectionName(name)
+		if normalized, err := worker.NormalizeRelationName(relationName); err != nil || normalized != relationName {
+			invalid = append(invalid, name)
+			continue
+		}
+
+		relationType, ok := relationTypes[relationName]
+		if !ok {
+			relationType = &dapi.RelationType{Key: relationName, Name: relationName, Directed: true}
+			relationTypes[relationName] = relationType
+			relationNames = append(relationNames, relationName)
+		}
+		if !slices.Contains(relationType.FromTypes, fromType) {
+			relationType.FromTypes = append(relationType.FromTypes, fromType)
+		}
+		if !slices.Contains(relationType.ToTypes, toType) {
+			relationType.ToTypes = append(relationType.ToTypes, toType)
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("edge collections with invalid relation names, rename them to lower-case ASCII names: %s", strings.Join(invalid, ", "))
+	}
+
+	var documents []map[string]interface{}
+	for _, relationName := range relationNames {
+		relationType := relationTypes[relationName]
+		slices.Sort(relationType.FromTypes)
+		slices.Sort(relationType.ToTypes)
+		documents = append(documents, map[string]interface{}{
+			"_key":       relationType.Key,
+			"name":       relationType.Name,
+			"from_types": relationType.FromTypes,
+			"to_types":   relationType.ToTypes,
+			"directed":   relationType.Directed,
+		})
+	}
+	if len(documents) == 0 {
+		return nil
+	}
+
+	cursor, err := client.DB.Query(ctx, `
+		FOR t IN @relationTypes
+		UPSERT { _key: t._key }
+		INSERT t
+		UPDATE {}
+		IN @@collection
+	`, map[string]interface{}{
+		"relationTypes": documents,
+		"@collection":   pipeline.RelationTypeCollection,
+	})
+	if err != nil {
+		return err
+	}
+	return cursor.Close()
+}
@@ -2,27 +2,39 @@ package pipeline
 
 import (
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/src/utils"
 	openai "github.com/sashabaranov/go-openai"
 	"github.com/sirupsen/logrus"
 )
 
 type ConcereteEntityCommon interface {
+	GetId() string
 	GetOwner() string
 	GetRead() []string
 	GetWrite() []string
 }
 
 type Worker struct {
+	dbClient       *utils.ArangoDBClient
 	collections    map[string]driver.Collection
+	grants         driver.Collection
+	relationTypes  driver.Collection
+	duplicates     driver.Collection
+	tombstones     driver.Collection
+	idempotency    driver.Collection
+	normalizers    []string
+	schemaLevel    driver.CollectionSchemaLevel
+	schemas        map[string]struct{}
 	openaiClient   *openai.Client
 	embeddingModel openai.EmbeddingModel
 	mu             sync.RWMutex
 }
 
-func NewWorker() *Worker {
+func NewWorker(dbClient *utils.ArangoDBClient) *Worker {
 	apiKey := os.Getenv("OPENAI_API_KEY")
 	baseUrl := os.Getenv("OPENAI_BASE_URL")
 	modelName := os.Getenv("EMBEDDING_MODEL")
@@ -43,11 +55,26 @@ func NewWorker() *Worker {
 		embeddingModel = openai.EmbeddingModel(modelName)
 	}
 
-	return &Worker{
+	worker := &Worker{
+		dbClient:       dbClient,
 		collections:    make(map[string]driver.Collection),
+		normalizers:    DefaultNormalizers,
+		schemaLevel:    DefaultSchemaLevel,
+		schemas:        make(map[string]struct{}),
 		openaiClient:   client,
 		embeddingModel: embeddingModel,
 	}
+	if v := os.Getenv(utils.Normalizers); v != "" {
+		if err := worker.SetNormalizers(strings.Split(v, ",")); err != nil {
+			logrus.Warnf("invalid %s, using default normalizers: %v", utils.Normalizers, err)
+		}
+	}
+	if v := os.Getenv(utils.SchemaLevel); v != "" {
+		if err := worker.SetSchemaLevel(v); err != nil {
+			logrus.Warnf("invalid %s, using schema level %s: %v", utils.SchemaLevel, DefaultSchemaLevel, err)
+		}
+	}
+	return worker
 }
 
 func (w *Worker) RegisterCollection(entityType string, col driver.Collection) {
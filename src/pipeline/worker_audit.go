@@ -0,0 +1,28 @@
+package pipeline
+
+import (
+	"time"
+)
+
+const (
+	CreatedAtField = "created_at"
+	CreatedByField = "created_by"
+	UpdatedAtField = "updated_at"
+	UpdatedByField = "updated_by"
+)
+
+// SetAuditFields stamps a document about to be written with the time of the
+// write and the user making it, replacing any values sent by the client.
+// The creation fields are only written when the document is created.
+func (w *Worker) SetAuditFields(dataMap map[string]interface{}, userId string, create bool) {
+	now := time.Now().Unix()
+	if create {
+		dataMap[CreatedAtField] = now
+		dataMap[CreatedByField] = userId
+	} else {
+		delete(dataMap, CreatedAtField)
+		delete(dataMap, CreatedByField)
+	}
+	dataMap[UpdatedAtField] = now
+	dataMap[UpdatedByField] = userId
+}
@@ -29,8 +29,17 @@ func (w *Worker) CheckCreatePermission(userRoles []string) error {
 	return status.Errorf(codes.PermissionDenied, "Access denied: only admin or pro users can create resources")
 }
 
-// CheckReadPermission checks if the user has permission to read the entity.
-func (w *Worker) CheckReadPermission(entity ConcereteEntityCommon, userId string, userRoles []string) error {
+// CheckAdminPermission checks if the user is an administrator.
+func (w *Worker) CheckAdminPermission(userRoles []string) error {
+	if slices.Contains(userRoles, "admin") {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "Access denied: only admin users can perform this operation")
+}
+
+// CheckReadPermission checks if the user has permission to read the entity,
+// either through its ACL or through an active share grant.
+func (w *Worker) CheckReadPermission(ctx context.Context, entity ConcereteEntityCommon, userId string, userRoles []string) error {
 	if entity.GetOwner() == userId {
 		return nil
 	}
@@ -43,6 +52,18 @@ func (w *Worker) CheckReadPermission(entity ConcereteEntityCommon, userId string
 		return nil
 	}
 
+	granted, err := w.HasActiveGrant(ctx, entity.GetId(), userId, userRoles)
+	if err != nil {
+		logrus.WithContext(ctx).WithFields(logrus.Fields{
+			"id":    entity.GetId(),
+			"error": err,
+		}).Error("Failed to evaluate share grants")
+		return status.Errorf(codes.Internal, "Internal service error")
+	}
+	if granted {
+		return nil
+	}
+
 	return status.Errorf(codes.PermissionDenied, "Access denied")
 }
 
@@ -73,3 +94,12 @@ func (w *Worker) CheckDeletePermission(entity ConcereteEntityCommon, userId stri
 
 	return status.Errorf(codes.PermissionDenied, "Access denied: only owner can delete entity")
 }
+
+// CheckSharePermission checks if the user may grant others access to the entity.
+func (w *Worker) CheckSharePermission(entity ConcereteEntityCommon, userId string, userRoles []string) error {
+	if entity.GetOwner() == userId || slices.Contains(userRoles, "admin") {
+		return nil
+	}
+
+	return status.Errorf(codes.PermissionDenied, "Access denied: only owner can share entity")
+}
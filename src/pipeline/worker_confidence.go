@@ -0,0 +1,111 @@
+package pipeline
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ConfidenceProduct = "product"
+	ConfidenceMin     = "min"
+	MaxConfidence     = 100
+)
+
+// PathWeightField holds the cost of following a relationship in confidence
+// weighted path searches, derived from its confidence on write. Relationships
+// without it cost DefaultPathWeight.
+const PathWeightField = "path_weight"
+
+// DefaultPathWeight is the cost of a relationship without confidence.
+const DefaultPathWeight = 2.0
+
+// PathWeight returns the cost of following a relationship of the given
+// confidence: from 1 for certain relationships to DefaultPathWeight.
+func PathWeight(confidence float64) float64 {
+	return DefaultPathWeight - min(max(confidence, 0), MaxConfidence)/MaxConfidence
+}
+
+// SetPathWeight derives the path weight of a relationship document written
+// with its confidence.
+func SetPathWeight(dataMap map[string]interface{}) {
+	if confidence, ok := dataMap["confidence"].(float64); ok {
+		dataMap[PathWeightField] = PathWeight(confidence)
+	}
+}
+
+// ConfidenceFilter keeps the edge bound to the variable e when its confidence
+// reaches the @minConfidence bind variable.
+func ConfidenceFilter(e string) string {
+	return fmt.Sprintf(`(@minConfidence == 0 OR %[1]s.confidence >= @minConfidence)`, e)
+}
+
+// CheckConfidenceOptions validates the confidence options of a traversal and
+// returns the aggregation mode to use.
+func (w *Worker) CheckConfidenceOptions(minConfidence int32, aggregation string) (string, error) {
+	if minConfidence < 0 || minConfidence > MaxConfidence {
+		return "", status.Errorf(codes.InvalidArgument, "min confidence must be between 0 and %d", MaxConfidence)
+	}
+	switch aggregation {
+	case "", ConfidenceProduct:
+		return ConfidenceProduct, nil
+	case ConfidenceMin:
+		return ConfidenceMin, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "invalid confidence aggregation: %s", aggregation)
+	}
+}
+
+// PathConfidences computes the best path confidence from the start entities to
+// every entity reachable over relations within depth hops. direction is the
+// AQL traversal keyword and hopRelations optionally restricts the relation
+// names followed at each hop.
+func PathConfidences(startIds []string, relations []*model.Relation, direction string, depth int, aggregation string, hopRelations [][]string) map[string]float64 {
+	confidences := make(map[string]float64)
+	for _, id := range startIds {
+		confidences[id] = 1
+	}
+
+	combine := func(pathConfidence float64, relationConfidence float64) float64 {
+		if aggregation == ConfidenceMin {
+			return min(pathConfidence, relationConfidence)
+		}
+		return pathConfidence * relationConfidence
+	}
+	relax := func(current map[string]float64, next map[string]float64, from string, to string, confidence float64) {
+		pathConfidence, ok := current[from]
+		if !ok {
+			return
+		}
+		candidate := combine(pathConfidence, confidence)
+		if best, ok := next[to]; !ok || candidate > best {
+			next[to] = candidate
+		}
+	}
+
+	// Bounded Bellman-Ford: after k rounds every entity holds the best
+	// confidence over paths of at most k relations
+	for hop := 0; hop < depth; hop++ {
+		next := make(map[string]float64, len(confidences))
+		for id, confidence := range confidences {
+			next[id] = confidence
+		}
+		for _, relation := range relations {
+			if hop < len(hopRelations) && len(hopRelations[hop]) > 0 && !slices.Contains(hopRelations[hop], relation.GetName()) {
+				continue
+			}
+			confidence := float64(min(max(relation.GetConfidence(), 0), MaxConfidence)) / MaxConfidence
+			if direction != "INBOUND" {
+				relax(confidences, next, relation.GetFrom(), relation.GetTo(), confidence)
+			}
+			if direction != "OUTBOUND" {
+				relax(confidences, next, relation.GetTo(), relation.GetFrom(), confidence)
+			}
+		}
+		confidences = next
+	}
+	return confidences
+}
@@ -22,7 +22,7 @@ func (w *Worker) CreateDocument(ctx context.Context, col driver.Collection, data
 			"collection": col.Name(),
 			"error":      err,
 		}).Error("Failed to create document")
-		return driver.DocumentMeta{}, status.Errorf(codes.Internal, "Internal service error")
+		return driver.DocumentMeta{}, w.TranslateDBError(err, col.Name(), "")
 	}
 
 	if err := w.mapToStruct(resultMap, resultStruct); err != nil {
@@ -44,7 +44,7 @@ func (w *Worker) ReadDocument(ctx context.Context, col driver.Collection, key st
 			"key":        key,
 			"error":      err,
 		}).Error("Failed to read document")
-		return driver.DocumentMeta{}, status.Errorf(codes.Internal, "Internal service error")
+		return driver.DocumentMeta{}, w.TranslateDBError(err, col.Name(), key)
 	}
 
 	if err := w.mapToStruct(resultMap, resultStruct); err != nil {
@@ -65,7 +65,7 @@ func (w *Worker) UpdateDocument(ctx context.Context, col driver.Collection, key
 			"key":        key,
 			"error":      err,
 		}).Error("Failed to update document")
-		return driver.DocumentMeta{}, status.Errorf(codes.Internal, "Internal service error")
+		return driver.DocumentMeta{}, w.TranslateDBError(err, col.Name(), key)
 	}
 
 	if err := w.mapToStruct(resultMap, resultStruct); err != nil {
@@ -83,7 +83,7 @@ func (w *Worker) DeleteDocument(ctx context.Context, col driver.Collection, key
 			"key":        key,
 			"error":      err,
 		}).Error("Failed to delete document")
-		return status.Errorf(codes.Internal, "Internal service error")
+		return w.TranslateDBError(err, col.Name(), key)
 	}
 	return nil
 }
@@ -98,3 +98,18 @@ func (w *Worker) mapToStruct(m map[string]interface{}, v interface{}) error {
 	}
 	return nil
 }
+
+// MergeDocumentData recursively merges the update into doc the same way
+// ArangoDB merges objects on document updates.
+func (w *Worker) MergeDocumentData(doc map[string]interface{}, update map[string]interface{}) map[string]interface{} {
+	for k, v := range update {
+		if src, ok := v.(map[string]interface{}); ok {
+			if dst, ok := doc[k].(map[string]interface{}); ok {
+				doc[k] = w.MergeDocumentData(dst, src)
+				continue
+			}
+		}
+		doc[k] = v
+	}
+	return doc
+}
@@ -0,0 +1,273 @@
+package pipeline
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DuplicateCandidateCollection = "duplicate_candidate"
+	// Pairs scoring below this are not stored by the duplicate scan
+	MinDuplicateScore = 0.6
+	// Maximum number of entities of one type loaded by a duplicate scan
+	MaxDuplicateScan = 20000
+	// Blocking keys shared by more entities than this are too common to
+	// identify duplicates and are skipped
+	maxDuplicateBlockSize = 200
+
+	nameScoreWeight      = 0.6
+	embeddingScoreWeight = 0.25
+	neighborScoreWeight  = 0.15
+)
+
+// DuplicateEntityTypes lists the entity types scanned for duplicates.
+var DuplicateEntityTypes = []string{"person", "organization", "source"}
+
+// duplicateProfile holds what the duplicate scan compares about an entity.
+type duplicateProfile struct {
+	Id        string    `json:"_id"`
+	Names     []string  `json:"names"`
+	Embedding []float64 `json:"embedding"`
+	Neighbors []string  `json:"neighbors"`
+}
+
+func (w *Worker) RegisterDuplicateCollection(col driver.Collection) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.duplicates = col
+}
+
+// GetDuplicateCollection retrieves the registered duplicate candidate collection.
+func (w *Worker) GetDuplicateCollection() (driver.Collection, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.duplicates == nil {
+		return nil, fmt.Errorf("duplicate candidate collection not registered")
+	}
+	return w.duplicates, nil
+}
+
+// scoreDuplicates compares the entities sharing a name blocking key and
+// returns the pairs scoring at least MinDuplicateScore.
+func scoreDuplicates(entityType string, profiles []duplicateProfile) []*dapi.DuplicatePair {
+	normalized := make([][]string, len(profiles))
+	blocks := make(map[string][]int)
+	for i, profile := range profiles {
+		keys := make(map[string]struct{})
+		for _, name := range profile.Names {
+			normalizedName := NormalizeName(name)
+			if normalizedName == "" {
+				continue
+			}
+			normalized[i] = append(normalized[i], normalizedName)
+			for _, token := range NameBlockingKeys(name, normalizedName) {
+				if len([]rune(token)) > 1 {
+					keys[token] = struct{}{}
+				}
+			}
+		}
+		for key := range keys {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	// Compare every pair of entities sharing at least one blocking key once
+	compared := make(map[[2]int]struct{})
+	var pairs []*dapi.DuplicatePair
+	for _, members := range blocks {
+		if len(members) > maxDuplicateBlockSize {
+			continue
+		}
+		for x := 0; x < len(members); x++ {
+			for y := x + 1; y < len(members); y++ {
+				i, j := members[x], members[y]
+				if _, ok := compared[[2]int{i, j}]; ok {
+					continue
+				}
+				compared[[2]int{i, j}] = struct{}{}
+
+				pair := scoreDuplicatePair(profiles[i], profiles[j], normalized[i], normalized[j])
+				if pair.Score >= MinDuplicateScore {
+					pair.EntityType = entityType
+					pairs = append(pairs, pair)
+				}
+			}
+		}
+	}
+	return pairs
+}
+
+func scoreDuplicatePair(a duplicateProfile, b duplicateProfile, aNames []string, bNames []string) *dapi.DuplicatePair {
+	pair := &dapi.DuplicatePair{EntityA: a.Id, EntityB: b.Id}
+	if pair.EntityA > pair.EntityB {
+		pair.EntityA, pair.EntityB = pair.EntityB, pair.EntityA
+	}
+
+	for _, aName := range aNames {
+		for _, bName := range bNames {
+			pair.NameScore = max(pair.NameScore, NameSimilarity(aName, bName))
+		}
+	}
+
+	// Signals missing on either side do not count for or against the pair
+	score, weight := nameScoreWeight*pair.NameScore, nameScoreWeight
+	if len(a.Embedding) > 0 && len(a.Embedding) == len(b.Embedding) {
+		pair.EmbeddingScore = cosineSimilarity(a.Embedding, b.Embedding)
+		score += embeddingScoreWeight * pair.EmbeddingScore
+		weight += embeddingScoreWeight
+	}
+	if len(a.Neighbors) > 0 && len(b.Neighbors) > 0 {
+		shared := 0
+		for _, id := range a.Neighbors {
+			if slices.Contains(b.Neighbors, id) {
+				shared++
+			}
+		}
+		pair.NeighborScore = float64(shared) / float64(len(a.Neighbors)+len(b.Neighbors)-shared)
+		score += neighborScoreWeight * pair.NeighborScore
+		weight += neighborScoreWeight
+	}
+	pair.Score = score / weight
+	return pair
+}
+
+func cosineSimilarity(a []float64, b []float64) float64 {
+	var dot, aNorm, bNorm float64
+	for i := range a {
+		dot += a[i] * b[i]
+		aNorm += a[i] * a[i]
+		bNorm += b[i] * b[i]
+	}
+	if aNorm == 0 || bNorm == 0 {
+		return 0
+	}
+	return max(0, dot/math.Sqrt(aNorm*bNorm))
+}
+
+// ScanDuplicates scores the entities of one type and replaces the stored
+// duplicate candidates of that type. Entities are scanned in key order, when
+// more than MaxDuplicateScan exist only the candidates between scanned
+// entities are replaced. It returns the number of stored pairs.
+func (w *Worker) ScanDuplicates(ctx context.Context, entityType string) (int, error) {
+	if !slices.Contains(DuplicateEntityTypes, entityType) {
+		return 0, fmt.Errorf("%s is not scanned for duplicates", entityType)
+	}
+	scannedAt := time.Now().Unix()
+
+	query := `
+		FOR d IN @@collection
+		SORT d._key
+		LIMIT @scanLimit
+		RETURN {
+			_id: d._id,
+			names: APPEND([d.name], IS_LIST(d.aliases) ? d.aliases : []),
+			embedding: d.embedding,
+			neighbors: (FOR v IN 1..1 ANY d GRAPH @graphName RETURN DISTINCT v._id)
+		}
+	`
+	cursor, err := w.dbClient.DB.Query(ctx, query, map[string]interface{}{
+		"@collection": entityType,
+		"scanLimit":   MaxDuplicateScan,
+		"graphName":   w.dbClient.OsintGraph.Name(),
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close()
+
+	var profiles []duplicateProfile
+	for {
+		var profile duplicateProfile
+		if _, err := cursor.ReadDocument(ctx, &profile); err != nil {
+			if driver.IsNoMoreDocuments(err) {
+				break
+			}
+			return 0, err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	// A truncated scan only covers the keys up to the last scanned one
+	var lastKey interface{}
+	if len(profiles) == MaxDuplicateScan {
+		_, lastKey, _ = strings.Cut(profiles[len(profiles)-1].Id, "/")
+	}
+
+	pairs := scoreDuplicates(entityType, profiles)
+	if pairs == nil {
+		pairs = []*dapi.DuplicatePair{}
+	}
+	for _, pair := range pairs {
+		pair.Key = fmt.Sprintf("%x", sha1.Sum([]byte(pair.EntityA+"|"+pair.EntityB)))
+		pair.ScannedAt = scannedAt
+	}
+
+	// Store the new candidates first, then drop the ones this scan no longer found
+	storeQuery := `
+		FOR c IN @candidates
+		UPSERT { _key: c._key }
+		INSERT c
+		REPLACE c
+		IN duplicate_candidate
+	`
+	storeCursor, err := w.dbClient.DB.Query(ctx, storeQuery, map[string]interface{}{
+		"candidates": pairs,
+	})
+	if err != nil {
+		return 0, err
+	}
+	storeCursor.Close()
+
+	removeQuery := `
+		FOR c IN duplicate_candidate
+		FILTER c.entity_type == @entityType AND c.scanned_at < @scannedAt
+		FILTER @lastKey == null OR (
+			PARSE_IDENTIFIER(c.entity_a).key <= @lastKey AND
+			PARSE_IDENTIFIER(c.entity_b).key <= @lastKey
+		)
+		REMOVE c IN duplicate_candidate
+	`
+	removeCursor, err := w.dbClient.DB.Query(ctx, removeQuery, map[string]interface{}{
+		"entityType": entityType,
+		"scannedAt":  scannedAt,
+		"lastKey":    lastKey,
+	})
+	if err != nil {
+		return 0, err
+	}
+	removeCursor.Close()
+
+	return len(pairs), nil
+}
+
+// RunDuplicateScanner scans every entity type for duplicates every interval
+// until ctx is done.
+func (w *Worker) RunDuplicateScanner(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, entityType := range DuplicateEntityTypes {
+				found, err := w.ScanDuplicates(ctx, entityType)
+				if err != nil {
+					logrus.WithError(err).Errorf("failed to scan %s for duplicates", entityType)
+					continue
+				}
+				logrus.Infof("found %d duplicate candidates for %s", found, entityType)
+			}
+		}
+	}
+}
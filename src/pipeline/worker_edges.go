@@ -0,0 +1,181 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	DefaultPageLimit = 100
+	MaxPageLimit     = 1000
+)
+
+// RelationFilterQuery filters the edge bound to the variable e with the
+// bind variables set by AddRelationFilterBindVars.
+func RelationFilterQuery(e string) string {
+	return fmt.Sprintf(`(
+					(@relationName == "" OR %[1]s.name == @relationName) AND
+					(@relationLabel == "" OR %[1]s.label == @relationLabel) AND
+					(@minConfidence == 0 OR %[1]s.confidence >= @minConfidence) AND
+					(@maxConfidence == 0 OR %[1]s.confidence <= @maxConfidence) AND
+					(@createdAfter == 0 OR %[1]s.created_at >= @createdAfter) AND
+					(@createdBefore == 0 OR %[1]s.created_at <= @createdBefore) AND
+					%[2]s
+				)`, e, ValidityFilter(e))
+}
+
+// AddRelationFilterBindVars adds the bind variables required by RelationFilterQuery.
+func (w *Worker) AddRelationFilterBindVars(bindVars map[string]interface{}, filter *dapi.RelationshipFilter) error {
+	relationName := ""
+	if filter.GetName() != "" {
+		name, err := w.NormalizeRelationName(filter.GetName())
+		if err != nil {
+			return err
+		}
+		relationName = name
+	}
+
+	bindVars["relationName"] = relationName
+	bindVars["relationLabel"] = filter.GetLabel()
+	bindVars["minConfidence"] = filter.GetMinConfidence()
+	bindVars["maxConfidence"] = filter.GetMaxConfidence()
+	bindVars["createdAfter"] = filter.GetCreatedAfter()
+	bindVars["createdBefore"] = filter.GetCreatedBefore()
+	return w.AddValidityBindVars(bindVars, filter.GetAsOf(), filter.GetWindowStart(), filter.GetWindowEnd())
+}
+
+// AddPageBindVars adds the @offset and @limit bind variables, applying the
+// default and maximum page sizes.
+func (w *Worker) AddPageBindVars(bindVars map[string]interface{}, offset int32, limit int32) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+	bindVars["offset"] = offset
+	bindVars["limit"] = limit
+}
+
+// EdgeCollectionName returns the edge collection holding relationName edges
+// from fromType to toType entities.
+func EdgeCollectionName(fromType string, relationName string, toType string) string {
+	return fmt.Sprintf("%s_%s_%s", fromType, relationName, toType)
+}
+
+// ParseEdgeCollectionName splits an edge collection name into its entity types
+// and relation name.
+func ParseEdgeCollectionName(name string) (fromType string, relationName string, toType string, ok bool) {
+	for _, from := range EntityTypes {
+		if !strings.HasPrefix(name, from+"_") {
+			continue
+		}
+		for _, to := range EntityTypes {
+			rest := strings.TrimPrefix(name, from+"_")
+			if strings.HasSuffix(rest, "_"+to) && len(rest) > len(to)+1 {
+				return from, strings.TrimSuffix(rest, "_"+to), to, true
+			}
+		}
+	}
+	return "", "", "", false
+}
+
+// ListEdgeCollections lists the edge collections of the graph matching the
+// given entity types and relation name. Empty arguments match anything.
+func (w *Worker) ListEdgeCollections(ctx context.Context, fromType string, relationName string, toType string) ([]string, error) {
+	cols, _, err := w.dbClient.OsintGraph.EdgeCollections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, col := range cols {
+		from, relation, to, ok := ParseEdgeCollectionName(col.Name())
+		if !ok {
+			continue
+		}
+		if (fromType == "" || from == fromType) &&
+			(relationName == "" || relation == relationName) &&
+			(toType == "" || to == toType) {
+			names = append(names, col.Name())
+		}
+	}
+	return names, nil
+}
+
+// TraversalDirection maps a request direction to the AQL traversal keyword.
+func (w *Worker) TraversalDirection(direction string) (string, error) {
+	switch strings.ToLower(direction) {
+	case "", "any":
+		return "ANY", nil
+	case "outbound":
+		return "OUTBOUND", nil
+	case "inbound":
+		return "INBOUND", nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "invalid direction: %s", direction)
+	}
+}
+
+// FilterEdgeCollections lists the edge collections whose relation name is
+// allowed (any when allowed is empty) and not denied.
+func (w *Worker) FilterEdgeCollections(ctx context.Context, allowed []string, denied []string) ([]string, error) {
+	normalize := func(names []string) ([]string, error) {
+		var normalized []string
+		for _, name := range names {
+			relationName, err := w.NormalizeRelationName(name)
+			if err != nil {
+				return nil, err
+			}
+			normalized = append(normalized, relationName)
+		}
+		return normalized, nil
+	}
+
+	allowedNames, err := normalize(allowed)
+	if err != nil {
+		return nil, err
+	}
+	deniedNames, err := normalize(denied)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionNames, err := w.ListEdgeCollections(ctx, "", "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, name := range collectionNames {
+		_, relationName, _, _ := ParseEdgeCollectionName(name)
+		if len(allowedNames) > 0 && !slices.Contains(allowedNames, relationName) {
+			continue
+		}
+		if slices.Contains(deniedNames, relationName) {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// AddEdgeCollectionBindVars binds the edge collections as @@col0, @@col1, ...
+// and returns them as a list usable in traversal queries.
+func AddEdgeCollectionBindVars(bindVars map[string]interface{}, names []string) string {
+	var params []string
+	for i, name := range names {
+		bindVars[fmt.Sprintf("@col%d", i)] = name
+		params = append(params, fmt.Sprintf("@@col%d", i))
+	}
+	return strings.Join(params, ", ")
+}
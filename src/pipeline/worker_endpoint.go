@@ -0,0 +1,63 @@
+package pipeline
+
+import (
+	"context"
+	"slices"
+
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EntityTypes lists the vertex collections relationships are allowed to connect.
+var EntityTypes = []string{"event", "source", "website", "person", "organization"}
+
+// ResolveEndpoint checks that the relationship endpoint with the given _id is an
+// existing entity the user can read, and returns its entity type.
+func (w *Worker) ResolveEndpoint(ctx context.Context, id string, userId string, userRoles []string) (string, error) {
+	logger := utils.GetLogger(ctx)
+
+	entityType, key, err := w.dbClient.ParseDocID(id)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Error("failed to parse endpoint id")
+		return "", status.Errorf(codes.InvalidArgument, "invalid entity id: %s", id)
+	}
+
+	if !slices.Contains(EntityTypes, entityType) {
+		return "", status.Errorf(codes.InvalidArgument, "%s is not an entity collection", entityType)
+	}
+
+	col, err := w.dbClient.OsintGraph.VertexCollection(ctx, entityType)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":      err,
+			"collection": entityType,
+		}).Error("failed to get collection")
+		return "", status.Errorf(codes.NotFound, "entity %s not found", id)
+	}
+
+	entity, err := w.CreateEntityStruct(entityType)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := w.ReadDocument(ctx, col, key, entity); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return "", status.Errorf(codes.NotFound, "entity %s not found", id)
+		}
+		return "", err
+	}
+
+	if err := w.CheckReadPermission(ctx, entity, userId, userRoles); err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			return "", status.Errorf(codes.PermissionDenied, "Access denied: cannot read entity %s", id)
+		}
+		return "", err
+	}
+
+	return entityType, nil
+}
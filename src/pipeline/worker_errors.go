@@ -0,0 +1,148 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/arangodb/go-driver"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain is the domain of the ErrorInfo details attached to errors.
+const ErrorDomain = "omndapi"
+
+// ErrorInfo reasons clients can react to.
+const (
+	ErrorReasonUniqueConstraint = "UNIQUE_CONSTRAINT_VIOLATED"
+	ErrorReasonRevisionConflict = "REVISION_CONFLICT"
+	ErrorReasonTimeout          = "DATABASE_TIMEOUT"
+	ErrorReasonUnavailable      = "DATABASE_UNAVAILABLE"
+	ErrorReasonSchemaViolation  = "SCHEMA_VIOLATED"
+)
+
+// Matches messages like "unique constraint violated - in index idx_website_url
+// of type persistent over 'url'; conflicting key: 1234"
+var uniqueConstraintPattern = regexp.MustCompile(`in index (\S+) of type \S+ over '([^']*)'(?:; conflicting key: (\S+))?`)
+
+// TranslateDBError maps an error of the ArangoDB driver on a document of the
+// collection to a gRPC status error with google.rpc error details. Errors
+// that are already gRPC status errors are returned unchanged and errors
+// without a more specific meaning become codes.Internal. Callers log the
+// original error.
+func (w *Worker) TranslateDBError(err error, collection string, key string) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	metadata := make(map[string]string)
+	if collection != "" {
+		metadata["collection"] = collection
+	}
+	if key != "" {
+		metadata["key"] = key
+	}
+	arangoErr, isArangoErr := driver.AsArangoError(err)
+
+	switch {
+	case driver.IsNotFoundGeneral(err):
+		return status.Errorf(codes.NotFound, "entity not found")
+
+	case driver.IsArangoErrorWithErrorNum(err, driver.ErrArangoUniqueConstraintViolated):
+		var fields []string
+		conflictingKey := ""
+		if match := uniqueConstraintPattern.FindStringSubmatch(arangoErr.ErrorMessage); match != nil {
+			metadata["index"] = match[1]
+			fields = strings.Split(match[2], ", ")
+			conflictingKey = match[3]
+		}
+		message := fmt.Sprintf("%s already exists", collection)
+		if conflictingKey != "" {
+			metadata["conflicting_key"] = conflictingKey
+			message = fmt.Sprintf("%s already exists: conflicting key %s", collection, conflictingKey)
+		}
+
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field,
+				Description: fmt.Sprintf("must be unique in %s", collection),
+			})
+		}
+		return statusWithDetails(codes.AlreadyExists, message, &errdetails.ErrorInfo{
+			Reason:   ErrorReasonUniqueConstraint,
+			Domain:   ErrorDomain,
+			Metadata: metadata,
+		}, badRequest)
+
+	case driver.IsArangoErrorWithErrorNum(err, driver.ErrValidationFailed):
+		return statusWithDetails(codes.InvalidArgument, fmt.Sprintf("%s: %s", collection, arangoErr.ErrorMessage), &errdetails.ErrorInfo{
+			Reason:   ErrorReasonSchemaViolation,
+			Domain:   ErrorDomain,
+			Metadata: metadata,
+		})
+
+	case driver.IsArangoErrorWithErrorNum(err, driver.ErrArangoConflict) || driver.IsPreconditionFailed(err):
+		return statusWithDetails(codes.Aborted, "the document was modified concurrently, please retry", &errdetails.ErrorInfo{
+			Reason:   ErrorReasonRevisionConflict,
+			Domain:   ErrorDomain,
+			Metadata: metadata,
+		})
+
+	case driver.IsTimeout(err) || errors.Is(err, context.DeadlineExceeded) ||
+		driver.IsArangoErrorWithErrorNum(err, driver.ErrLockTimeout, driver.ErrClusterTimeout) ||
+		(isArangoErr && (arangoErr.Code == http.StatusRequestTimeout || arangoErr.Code == http.StatusGatewayTimeout)):
+		return statusWithDetails(codes.DeadlineExceeded, "the database did not answer in time", &errdetails.ErrorInfo{
+			Reason:   ErrorReasonTimeout,
+			Domain:   ErrorDomain,
+			Metadata: metadata,
+		})
+
+	case isUnavailable(err, arangoErr, isArangoErr):
+		return statusWithDetails(codes.Unavailable, "the database is unavailable, please retry later", &errdetails.ErrorInfo{
+			Reason:   ErrorReasonUnavailable,
+			Domain:   ErrorDomain,
+			Metadata: metadata,
+		})
+	}
+
+	return status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+}
+
+// PrefixStatus prefixes the message of a gRPC status error, keeping its code
+// and error details.
+func PrefixStatus(err error, prefix string) error {
+	st := status.Convert(err).Proto()
+	st.Message = prefix + st.Message
+	return status.FromProto(st).Err()
+}
+
+func isUnavailable(err error, arangoErr driver.ArangoError, isArangoErr bool) bool {
+	if driver.IsNoLeaderOrOngoing(err) ||
+		driver.IsArangoErrorWithErrorNum(err, driver.ErrClusterBackendUnavailable, driver.ErrClusterConnectionLost) ||
+		(isArangoErr && arangoErr.Code == http.StatusServiceUnavailable) {
+		return true
+	}
+	// Connection failures reach us as network errors. ArangoError has the
+	// methods of net.Error too, so answers of the server are excluded.
+	var netErr net.Error
+	return !isArangoErr && errors.As(driver.Cause(err), &netErr)
+}
+
+func statusWithDetails(code codes.Code, message string, details ...protoadapt.MessageV1) error {
+	st := status.New(code, message)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
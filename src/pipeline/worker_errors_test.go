@@ -0,0 +1,70 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/arangodb/go-driver"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTranslateDBError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{"not found", driver.ArangoError{HasError: true, Code: http.StatusNotFound, ErrorNum: driver.ErrArangoDocumentNotFound}, codes.NotFound, ""},
+		{"unique constraint", driver.ArangoError{
+			HasError:     true,
+			Code:         http.StatusConflict,
+			ErrorNum:     driver.ErrArangoUniqueConstraintViolated,
+			ErrorMessage: "unique constraint violated - in index idx_website_url of type persistent over 'url'; conflicting key: 1234",
+		}, codes.AlreadyExists, ErrorReasonUniqueConstraint},
+		{"revision conflict", driver.ArangoError{HasError: true, Code: http.StatusConflict, ErrorNum: driver.ErrArangoConflict}, codes.Aborted, ErrorReasonRevisionConflict},
+		{"precondition failed", driver.ArangoError{HasError: true, Code: http.StatusPreconditionFailed, ErrorNum: 1200}, codes.Aborted, ErrorReasonRevisionConflict},
+		{"lock timeout", driver.ArangoError{HasError: true, Code: http.StatusRequestTimeout, ErrorNum: driver.ErrLockTimeout}, codes.DeadlineExceeded, ErrorReasonTimeout},
+		{"gateway timeout", driver.ArangoError{HasError: true, Code: http.StatusGatewayTimeout}, codes.DeadlineExceeded, ErrorReasonTimeout},
+		{"context deadline", context.DeadlineExceeded, codes.DeadlineExceeded, ErrorReasonTimeout},
+		{"service unavailable", driver.ArangoError{HasError: true, Code: http.StatusServiceUnavailable}, codes.Unavailable, ErrorReasonUnavailable},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, codes.Unavailable, ErrorReasonUnavailable},
+		{"backend unavailable", driver.ArangoError{HasError: true, Code: http.StatusInternalServerError, ErrorNum: driver.ErrClusterBackendUnavailable}, codes.Unavailable, ErrorReasonUnavailable},
+		{"other", driver.ArangoError{HasError: true, Code: http.StatusInternalServerError, ErrorNum: 4}, codes.Internal, ""},
+		{"status error", status.Error(codes.PermissionDenied, "Access denied"), codes.PermissionDenied, ""},
+	}
+	w := &Worker{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(w.TranslateDBError(tt.err, "website", "1"))
+			if st.Code() != tt.wantCode {
+				t.Fatalf("got code %v, want %v: %v", st.Code(), tt.wantCode, st.Message())
+			}
+			reason := ""
+			for _, detail := range st.Details() {
+				if info, ok := detail.(*errdetails.ErrorInfo); ok {
+					reason = info.GetReason()
+				}
+			}
+			if reason != tt.wantReason {
+				t.Errorf("got reason %q, want %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestPrefixStatusKeepsDetails(t *testing.T) {
+	err := (&Worker{}).TranslateDBError(driver.ArangoError{HasError: true, Code: http.StatusConflict, ErrorNum: driver.ErrArangoConflict}, "website", "1")
+	st := status.Convert(PrefixStatus(err, "entity 2: "))
+	if st.Code() != codes.Aborted || st.Message() != "entity 2: the document was modified concurrently, please retry" {
+		t.Errorf("got %v: %s", st.Code(), st.Message())
+	}
+	if len(st.Details()) != 1 {
+		t.Errorf("got details %v", st.Details())
+	}
+}
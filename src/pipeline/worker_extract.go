@@ -29,6 +29,8 @@ func (w *Worker) CreateEntityStruct(entityType string) (ConcereteEntityCommon, e
 	}
 }
 
+// ExtractInputEntity returns the entity of the request, checking that the
+// populated oneof matches the requested entity type.
 func (w *Worker) ExtractInputEntity(req EntityRequest) (ConcereteEntityCommon, error) {
 	if req == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "request is nil")
@@ -39,18 +41,36 @@ func (w *Worker) ExtractInputEntity(req EntityRequest) (ConcereteEntityCommon, e
 		return nil, status.Errorf(codes.InvalidArgument, "entity wrapper is nil")
 	}
 
+	var entity ConcereteEntityCommon
 	switch req.GetEntityType() {
 	case "event":
-		return wrapper.GetEvent(), nil
+		if v := wrapper.GetEvent(); v != nil {
+			entity = v
+		}
 	case "source":
-		return wrapper.GetSource(), nil
+		if v := wrapper.GetSource(); v != nil {
+			entity = v
+		}
 	case "website":
-		return wrapper.GetWebsite(), nil
+		if v := wrapper.GetWebsite(); v != nil {
+			entity = v
+		}
 	case "person":
-		return wrapper.GetPerson(), nil
+		if v := wrapper.GetPerson(); v != nil {
+			entity = v
+		}
 	case "organization":
-		return wrapper.GetOrganization(), nil
+		if v := wrapper.GetOrganization(); v != nil {
+			entity = v
+		}
 	default:
 		return nil, status.Errorf(codes.InvalidArgument, "unknown entity type: %s", req.GetEntityType())
 	}
+
+	if entity == nil {
+		var violations FieldViolations
+		violations.Add("entity", "must hold a %s to match entity_type", req.GetEntityType())
+		return nil, violations.Err()
+	}
+	return entity, nil
 }
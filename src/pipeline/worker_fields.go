@@ -14,6 +14,16 @@ import (
 )
 
 func (w *Worker) SetAdditionalFields(ctx context.Context, entity interface{}) (map[string]interface{}, error) {
+	embeddings, err := w.GetEmbedding(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	return w.EntityDataMap(entity, embeddings)
+}
+
+// EntityDataMap converts an entity into the document written to the database,
+// with the embedding computed for it beforehand by GetEmbedding.
+func (w *Worker) EntityDataMap(entity interface{}, embeddings []float32) (map[string]interface{}, error) {
 	data, err := json.Marshal(entity)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal entity: %w", err)
@@ -23,12 +33,14 @@ func (w *Worker) SetAdditionalFields(ctx context.Context, entity interface{}) (m
 	if err := json.Unmarshal(data, &entityMap); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal into map: %w", err)
 	}
+	entityMap["embedding"] = embeddings
 
-	embeddings, err := w.GetEmbedding(ctx, entity)
-	if err != nil {
-		return nil, err
+	// Locations given without coordinates keep the stored point
+	if event, ok := entity.(*model.Event); ok {
+		if point := GeoPoint(event.GetLocation()); point != nil {
+			entityMap[GeoField] = point
+		}
 	}
-	entityMap["embedding"] = embeddings
 	return entityMap, nil
 }
 
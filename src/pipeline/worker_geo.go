@@ -0,0 +1,136 @@
+package pipeline
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GeoField holds the GeoJSON point of an event location, derived from its
+// latitude and longitude on write and covered by a geo index.
+const GeoField = "geo"
+
+// GeoPoint returns the GeoJSON point of a location, or nil if the location
+// has no coordinates.
+func GeoPoint(location *model.LocationData) map[string]interface{} {
+	if location == nil || (location.GetLatitude() == 0 && location.GetLongitude() == 0) {
+		return nil
+	}
+	return map[string]interface{}{
+		"type":        "Point",
+		"coordinates": []float64{float64(location.GetLongitude()), float64(location.GetLatitude())},
+	}
+}
+
+// GeoFilter keeps the event bound to the variable e when its location is
+// inside the area set by AddGeoBindVars. The box is bounded by lines of
+// latitude and longitude, as a map view is, so it compares the coordinates
+// rather than testing a polygon whose sides are great circles.
+func GeoFilter(e string) string {
+	return fmt.Sprintf(`(
+					(@geoRadius == 0 OR (%[1]s.%[2]s != null AND GEO_DISTANCE(@geoCenter, %[1]s.%[2]s) <= @geoRadius)) AND
+					(@geoMinLatitude == null OR (%[1]s.%[2]s != null AND
+						%[1]s.location.latitude >= @geoMinLatitude AND %[1]s.location.latitude <= @geoMaxLatitude AND
+						(@geoMinLongitude < @geoMaxLongitude ?
+							%[1]s.location.longitude >= @geoMinLongitude AND %[1]s.location.longitude <= @geoMaxLongitude :
+							%[1]s.location.longitude >= @geoMinLongitude OR %[1]s.location.longitude <= @geoMaxLongitude))) AND
+					(@geoPolygon == null OR (%[1]s.%[2]s != null AND GEO_CONTAINS(@geoPolygon, %[1]s.%[2]s)))
+				)`, e, GeoField)
+}
+
+// GeoArea selects events by location. Zero values leave the area unbounded:
+// a radius of 0 disables the circle, a box with all corners at 0 disables
+// the box and an empty polygon disables the polygon.
+type GeoArea struct {
+	NearLatitude  float64
+	NearLongitude float64
+	RadiusMeters  float64
+	MinLatitude   float64
+	MinLongitude  float64
+	MaxLatitude   float64
+	MaxLongitude  float64
+	// Latitude, longitude pairs of the polygon vertices
+	Polygon []float64
+}
+
+// AddGeoBindVars adds the bind variables required by GeoFilter.
+func (w *Worker) AddGeoBindVars(bindVars map[string]interface{}, area GeoArea) error {
+	bindVars["geoCenter"] = nil
+	bindVars["geoRadius"] = 0
+	bindVars["geoMinLatitude"] = nil
+	bindVars["geoMinLongitude"] = nil
+	bindVars["geoMaxLatitude"] = nil
+	bindVars["geoMaxLongitude"] = nil
+	bindVars["geoPolygon"] = nil
+
+	if area.RadiusMeters < 0 || math.IsNaN(area.RadiusMeters) {
+		return status.Errorf(codes.InvalidArgument, "radius must not be negative")
+	}
+	if area.RadiusMeters > 0 {
+		if err := CheckCoordinates("near", area.NearLatitude, area.NearLongitude); err != nil {
+			return err
+		}
+		bindVars["geoCenter"] = []float64{area.NearLongitude, area.NearLatitude}
+		bindVars["geoRadius"] = area.RadiusMeters
+	}
+
+	if area.MinLatitude != 0 || area.MinLongitude != 0 || area.MaxLatitude != 0 || area.MaxLongitude != 0 {
+		if err := CheckCoordinates("min", area.MinLatitude, area.MinLongitude); err != nil {
+			return err
+		}
+		if err := CheckCoordinates("max", area.MaxLatitude, area.MaxLongitude); err != nil {
+			return err
+		}
+		// A min longitude east of the max longitude wraps across the antimeridian
+		if area.MinLatitude >= area.MaxLatitude || area.MinLongitude == area.MaxLongitude {
+			return status.Errorf(codes.InvalidArgument, "bounding box min corner must be south west of its max corner")
+		}
+		bindVars["geoMinLatitude"] = area.MinLatitude
+		bindVars["geoMinLongitude"] = area.MinLongitude
+		bindVars["geoMaxLatitude"] = area.MaxLatitude
+		bindVars["geoMaxLongitude"] = area.MaxLongitude
+	}
+
+	if len(area.Polygon) > 0 {
+		if len(area.Polygon)%2 != 0 || len(area.Polygon) < 6 {
+			return status.Errorf(codes.InvalidArgument, "polygon must be at least 3 latitude, longitude pairs")
+		}
+		var ring [][]float64
+		for i := 0; i < len(area.Polygon); i += 2 {
+			lat, lng := area.Polygon[i], area.Polygon[i+1]
+			if err := CheckCoordinates(fmt.Sprintf("polygon vertex %d", i/2), lat, lng); err != nil {
+				return err
+			}
+			ring = append(ring, []float64{lng, lat})
+		}
+		bindVars["geoPolygon"] = geoPolygon(ring)
+	}
+	return nil
+}
+
+// geoPolygon returns a GeoJSON polygon of the ring of longitude, latitude
+// positions, closing the ring if needed.
+func geoPolygon(ring [][]float64) map[string]interface{} {
+	first, last := ring[0], ring[len(ring)-1]
+	if first[0] != last[0] || first[1] != last[1] {
+		ring = append(ring, first)
+	}
+	return map[string]interface{}{
+		"type":        "Polygon",
+		"coordinates": [][][]float64{ring},
+	}
+}
+
+// CheckCoordinates checks that a latitude and longitude are on the globe.
+func CheckCoordinates(name string, lat float64, lng float64) error {
+	if math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return status.Errorf(codes.InvalidArgument, "%s latitude must be between -90 and 90", name)
+	}
+	if math.IsNaN(lng) || lng < -180 || lng > 180 {
+		return status.Errorf(codes.InvalidArgument, "%s longitude must be between -180 and 180", name)
+	}
+	return nil
+}
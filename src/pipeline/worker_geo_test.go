@@ -0,0 +1,35 @@
+package pipeline
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAddGeoBindVarsBoundingBox(t *testing.T) {
+	tests := []struct {
+		name     string
+		area     GeoArea
+		wantCode codes.Code
+	}{
+		{"box", GeoArea{MinLatitude: 36, MinLongitude: -124, MaxLatitude: 37, MaxLongitude: -123}, codes.OK},
+		{"across the antimeridian", GeoArea{MinLatitude: -30, MinLongitude: 170, MaxLatitude: 10, MaxLongitude: -170}, codes.OK},
+		{"inverted latitudes", GeoArea{MinLatitude: 37, MinLongitude: -124, MaxLatitude: 36, MaxLongitude: -123}, codes.InvalidArgument},
+		{"no width", GeoArea{MinLatitude: 36, MinLongitude: -124, MaxLatitude: 37, MaxLongitude: -124}, codes.InvalidArgument},
+		{"invalid longitude", GeoArea{MinLatitude: 36, MinLongitude: -190, MaxLatitude: 37, MaxLongitude: -123}, codes.InvalidArgument},
+	}
+	w := &Worker{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bindVars := make(map[string]interface{})
+			err := w.AddGeoBindVars(bindVars, tt.area)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("got %v, want %v", err, tt.wantCode)
+			}
+			if err == nil && (bindVars["geoMinLongitude"] != tt.area.MinLongitude || bindVars["geoMaxLongitude"] != tt.area.MaxLongitude) {
+				t.Errorf("got bind vars %v", bindVars)
+			}
+		})
+	}
+}
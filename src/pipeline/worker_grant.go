@@ -0,0 +1,143 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	GrantCollection = "grant"
+	// MaxGrantDepth bounds how many hops a subgraph grant reaches from its root.
+	MaxGrantDepth = 3
+	// ShareLinkPrefix marks grant principals that are share link tokens.
+	ShareLinkPrefix = "link:"
+	// GrantPrincipalIndex indexes grants by principal and expiry.
+	GrantPrincipalIndex = "idx_grant_principal_expires_at"
+)
+
+// GrantedIdsQuery collects the _id of every vertex covered by an active grant
+// to one of the caller's principals. Grants are looked up through the principal
+// index so the cost does not grow with grants issued to others; the optimizer
+// could otherwise pick the expiry index, which matches nearly every grant.
+// It expects the @principals, @now and @graphName bind variables (see AddGrantBindVars).
+var GrantedIdsQuery = fmt.Sprintf(`
+		LET granted_ids = (
+			FOR g IN grant OPTIONS { indexHint: "%s", forceIndexHint: true }
+			FILTER g.principal IN @principals AND g.expires_at > @now
+				FOR v, e, p IN 0..%d ANY g.target GRAPH @graphName
+				PRUNE LENGTH(p.edges) >= g.depth
+				FILTER LENGTH(p.edges) <= g.depth
+				RETURN DISTINCT v._id
+		)
+`, GrantPrincipalIndex, MaxGrantDepth)
+
+// ReadFilter returns the AQL condition under which the document bound to the
+// variable v is readable. It expects @userId and @userRoles bind variables and
+// the granted_ids variable from GrantedIdsQuery.
+func ReadFilter(v string) string {
+	return fmt.Sprintf(`(
+					%[1]s.owner == @userId OR
+					@userId IN %[1]s.read OR
+					LENGTH(INTERSECTION(@userRoles, %[1]s.read)) > 0 OR
+					%[1]s._id IN granted_ids
+				)`, v)
+}
+
+func (w *Worker) RegisterGrantCollection(col driver.Collection) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.grants = col
+}
+
+// GetGrantCollection retrieves the registered grant collection.
+func (w *Worker) GetGrantCollection() (driver.Collection, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.grants == nil {
+		return nil, fmt.Errorf("grant collection not registered")
+	}
+	return w.grants, nil
+}
+
+// GetPrincipals lists every principal a grant can be issued to for the caller:
+// the user itself, its roles and any share link token sent with the request.
+func (w *Worker) GetPrincipals(ctx context.Context, userId string, userRoles []string) []string {
+	principals := append([]string{userId}, userRoles...)
+	for _, token := range utils.GetShareTokens(ctx) {
+		principals = append(principals, ShareLinkPrefix+token)
+	}
+	return principals
+}
+
+// AddGrantBindVars adds the bind variables required by GrantedIdsQuery.
+func (w *Worker) AddGrantBindVars(ctx context.Context, bindVars map[string]interface{}, userId string, userRoles []string) {
+	bindVars["principals"] = w.GetPrincipals(ctx, userId, userRoles)
+	bindVars["now"] = time.Now().Unix()
+	bindVars["graphName"] = w.dbClient.OsintGraph.Name()
+}
+
+// HasActiveGrant reports whether any active grant covers the document with the given _id.
+func (w *Worker) HasActiveGrant(ctx context.Context, id string, userId string, userRoles []string) (bool, error) {
+	query := GrantedIdsQuery + `
+		RETURN @id IN granted_ids
+	`
+	bindVars := map[string]interface{}{"id": id}
+	w.AddGrantBindVars(ctx, bindVars, userId, userRoles)
+
+	cursor, err := w.dbClient.DB.Query(ctx, query, bindVars)
+	if err != nil {
+		return false, err
+	}
+	defer cursor.Close()
+
+	var granted bool
+	if _, err := cursor.ReadDocument(ctx, &granted); err != nil {
+		return false, err
+	}
+	return granted, nil
+}
+
+// SweepExpiredGrants removes every grant past its expiry.
+func (w *Worker) SweepExpiredGrants(ctx context.Context) (int64, error) {
+	query := `
+		FOR g IN grant
+		FILTER g.expires_at <= @now
+		REMOVE g IN grant
+	`
+	cursor, err := w.dbClient.DB.Query(ctx, query, map[string]interface{}{
+		"now": time.Now().Unix(),
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close()
+
+	return cursor.Statistics().WritesExecuted(), nil
+}
+
+// RunGrantSweeper sweeps expired grants every interval until ctx is done.
+func (w *Worker) RunGrantSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			removed, err := w.SweepExpiredGrants(ctx)
+			if err != nil {
+				logrus.WithError(err).Error("failed to sweep expired grants")
+				continue
+			}
+			if removed > 0 {
+				logrus.Infof("swept %d expired grants", removed)
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package pipeline
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	IdempotencyCollection = "idempotency_key"
+	// How long a response is replayed for retries carrying the same Idempotency-Key
+	IdempotencyKeyTTL = 24 * time.Hour
+
+	idempotencyPending = "pending"
+	idempotencyDone    = "done"
+)
+
+// idempotencyRecord remembers the response of a request sent with an Idempotency-Key.
+type idempotencyRecord struct {
+	Key         string `json:"_key"`
+	UserId      string `json:"user_id"`
+	Method      string `json:"method"`
+	Fingerprint string `json:"fingerprint"`
+	Status      string `json:"status"`
+	Response    string `json:"response,omitempty"`
+	CreatedAt   int64  `json:"created_at"`
+}
+
+func (w *Worker) RegisterIdempotencyCollection(col driver.Collection) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.idempotency = col
+}
+
+// GetIdempotencyCollection retrieves the registered idempotency key collection.
+func (w *Worker) GetIdempotencyCollection() (driver.Collection, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.idempotency == nil {
+		return nil, fmt.Errorf("idempotency key collection not registered")
+	}
+	return w.idempotency, nil
+}
+
+func idempotencyRecordKey(userId string, method string, idempotencyKey string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(userId+"|"+method+"|"+idempotencyKey)))
+}
+
+// BeginIdempotentRequest reserves the Idempotency-Key sent with the request.
+// If the request was already served it fills resp with the stored response
+// and returns true. Requests without an Idempotency-Key always proceed.
+func (w *Worker) BeginIdempotentRequest(ctx context.Context, userId string, method string, req proto.Message, resp proto.Message) (bool, error) {
+	idempotencyKey := utils.GetIdempotencyKey(ctx)
+	if idempotencyKey == "" {
+		return false, nil
+	}
+
+	logger := utils.GetLogger(ctx)
+	col, err := w.GetIdempotencyCollection()
+	if err != nil {
+		logger.WithError(err).Error("idempotency key collection not registered")
+		return false, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to marshal request")
+	}
+	record := idempotencyRecord{
+		Key:         idempotencyRecordKey(userId, method, idempotencyKey),
+		UserId:      userId,
+		Method:      method,
+		Fingerprint: fmt.Sprintf("%x", sha256.Sum256(data)),
+		Status:      idempotencyPending,
+		CreatedAt:   time.Now().Unix(),
+	}
+
+	_, err = col.CreateDocument(ctx, record)
+	if err == nil {
+		return false, nil
+	}
+	if !driver.IsConflict(err) {
+		logger.WithFields(logrus.Fields{
+			"error":  err,
+			"method": method,
+		}).Error("failed to reserve idempotency key")
+		return false, w.TranslateDBError(err, IdempotencyCollection, "")
+	}
+
+	// The key was used before, replay its response if it belongs to the same request
+	var existing idempotencyRecord
+	if _, err := col.ReadDocument(ctx, record.Key, &existing); err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":  err,
+			"method": method,
+		}).Error("failed to read idempotency key")
+		return false, w.TranslateDBError(err, IdempotencyCollection, record.Key)
+	}
+	if existing.Fingerprint != record.Fingerprint {
+		return false, status.Errorf(codes.InvalidArgument, "Idempotency-Key was already used for a different request")
+	}
+	if existing.Status != idempotencyDone {
+		return false, status.Errorf(codes.Aborted, "a request with this Idempotency-Key is still in progress")
+	}
+	if err := protojson.Unmarshal([]byte(existing.Response), resp); err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":  err,
+			"method": method,
+		}).Error("failed to unmarshal stored response")
+		return false, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	logger.Infof("replayed %s response for idempotency key %s", method, idempotencyKey)
+	return true, nil
+}
+
+// FinishIdempotentRequest stores the response of a request reserved by
+// BeginIdempotentRequest. If the request failed the key is released so that
+// the request can be retried.
+func (w *Worker) FinishIdempotentRequest(ctx context.Context, userId string, method string, resp proto.Message, reqErr error) {
+	idempotencyKey := utils.GetIdempotencyKey(ctx)
+	if idempotencyKey == "" {
+		return
+	}
+
+	logger := utils.GetLogger(ctx)
+	col, err := w.GetIdempotencyCollection()
+	if err != nil {
+		logger.WithError(err).Error("idempotency key collection not registered")
+		return
+	}
+	key := idempotencyRecordKey(userId, method, idempotencyKey)
+
+	if reqErr != nil {
+		if _, err := col.RemoveDocument(ctx, key); err != nil {
+			logger.WithFields(logrus.Fields{
+				"error":  err,
+				"method": method,
+			}).Error("failed to release idempotency key")
+		}
+		return
+	}
+
+	data, err := protojson.Marshal(resp)
+	if err == nil {
+		_, err = col.UpdateDocument(ctx, key, map[string]interface{}{
+			"status":   idempotencyDone,
+			"response": string(data),
+		})
+	}
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":  err,
+			"method": method,
+		}).Error("failed to store idempotent response")
+	}
+}
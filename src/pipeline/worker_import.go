@@ -0,0 +1,290 @@
+package pipeline
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	ImportFormatJSONL = "jsonl"
+	ImportFormatCSV   = "csv"
+
+	DefaultImportBatchSize = 100
+	MaxImportBatchSize     = 1000
+	// Errors reported back to the client, further failures are only counted
+	MaxImportErrors = 1000
+	// Separator of the values of list fields in CSV cells
+	CSVListSeparator = ";"
+)
+
+// ImportRecord is an entity or a relationship read from an import file.
+type ImportRecord struct {
+	// Line of the record in the file, starting at 1
+	Line       int
+	EntityType string
+	Entity     *model.Entity
+	Relation   *model.Relation
+	// STIX id of the object the record was read from, for STIX bundles
+	ObjectId string
+	// Why the record could not be read, the other fields are unset
+	Err error
+}
+
+// ReadImportRecords reads the records of a JSON Lines or CSV file and passes
+// them to fn in order. Records that cannot be read are passed with Err set.
+// Reading stops at the first error returned by fn or by the reader.
+func (w *Worker) ReadImportRecords(r io.Reader, options *dapi.ImportOptions, fn func(record *ImportRecord) error) error {
+	switch options.GetFormat() {
+	case ImportFormatJSONL:
+		return w.readJSONLRecords(r, fn)
+	case ImportFormatCSV:
+		return w.readCSVRecords(r, options, fn)
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown import format: %q", options.GetFormat())
+	}
+}
+
+// =====================================================
+// JSON Lines
+// =====================================================
+
+func (w *Worker) readJSONLRecords(r io.Reader, fn func(record *ImportRecord) error) error {
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			record := w.parseJSONRecord(data)
+			record.Line = line
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return nil
+		}
+	}
+}
+
+// parseJSONRecord reads a model.Entity, recognized by its entity type field,
+// or a model.Relation in the JSON form used by the API.
+func (w *Worker) parseJSONRecord(data []byte) *ImportRecord {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return &ImportRecord{Err: fmt.Errorf("invalid JSON: %w", err)}
+	}
+
+	for _, entityType := range EntityTypes {
+		if _, ok := fields[entityType]; !ok {
+			continue
+		}
+		entity := &model.Entity{}
+		if err := protojson.Unmarshal(data, entity); err != nil {
+			return &ImportRecord{Err: fmt.Errorf("invalid %s: %w", entityType, err)}
+		}
+		return &ImportRecord{EntityType: entityType, Entity: entity}
+	}
+
+	if _, ok := fields["from"]; ok {
+		relation := &model.Relation{}
+		if err := protojson.Unmarshal(data, relation); err != nil {
+			return &ImportRecord{Err: fmt.Errorf("invalid relationship: %w", err)}
+		}
+		return &ImportRecord{Relation: relation}
+	}
+
+	return &ImportRecord{Err: fmt.Errorf("record is neither an entity of type %s nor a relationship", strings.Join(EntityTypes, ", "))}
+}
+
+// =====================================================
+// CSV
+// =====================================================
+
+func (w *Worker) readCSVRecords(r io.Reader, options *dapi.ImportOptions, fn func(record *ImportRecord) error) error {
+	entityType := options.GetEntityType()
+	if !slices.Contains(EntityTypes, entityType) {
+		return status.Errorf(codes.InvalidArgument, "unknown entity type: %q", entityType)
+	}
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to read CSV header: %v", err)
+	}
+
+	// Field path of each column, empty for unmapped columns
+	paths := make([]string, len(header))
+	for i, column := range header {
+		if len(options.GetColumns()) == 0 {
+			paths[i] = column
+		} else {
+			paths[i] = options.GetColumns()[column]
+		}
+	}
+	for column := range options.GetColumns() {
+		if !slices.Contains(header, column) {
+			return status.Errorf(codes.InvalidArgument, "mapped column %q is not in the CSV header", column)
+		}
+	}
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		var record *ImportRecord
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr):
+			// A row failing in its first field has no field positions
+			record = &ImportRecord{Line: parseErr.StartLine, Err: err}
+		case err != nil:
+			return err
+		default:
+			line, _ := reader.FieldPos(0)
+			record = &ImportRecord{Line: line, EntityType: entityType}
+			record.Entity, record.Err = w.parseCSVEntity(entityType, paths, row)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
+
+// parseCSVEntity builds an entity from the cells of a CSV row, where paths
+// holds the field path of each column.
+func (w *Worker) parseCSVEntity(entityType string, paths []string, row []string) (*model.Entity, error) {
+	entity, err := w.CreateEntityStruct(entityType)
+	if err != nil {
+		return nil, err
+	}
+	message := entity.(proto.Message)
+
+	data := map[string]interface{}{}
+	for i, path := range paths {
+		value := strings.TrimSpace(row[i])
+		if path == "" || value == "" {
+			continue
+		}
+		if err := setImportField(data, message.ProtoReflect().Descriptor(), strings.Split(path, "."), value); err != nil {
+			return nil, fmt.Errorf("column %d (%s): %w", i+1, path, err)
+		}
+	}
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := protojson.Unmarshal(jsonData, message); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", entityType, err)
+	}
+	return w.WrapEntityResponse(entity)
+}
+
+// setImportField sets the field at path of the JSON form of a message to the
+// text of a CSV cell, converted to the type the field expects.
+func setImportField(data map[string]interface{}, descriptor protoreflect.MessageDescriptor, path []string, value string) error {
+	field := descriptor.Fields().ByName(protoreflect.Name(path[0]))
+	if field == nil {
+		field = descriptor.Fields().ByJSONName(path[0])
+	}
+	if field == nil {
+		return fmt.Errorf("unknown field %q", path[0])
+	}
+	name := string(field.Name())
+
+	if field.Kind() == protoreflect.MessageKind && !field.IsList() && !field.IsMap() {
+		if len(path) == 1 {
+			return fmt.Errorf("field %q is a message", name)
+		}
+		nested, _ := data[name].(map[string]interface{})
+		if nested == nil {
+			nested = map[string]interface{}{}
+			data[name] = nested
+		}
+		// Free-form attributes take any key
+		if field.Message().FullName() == "google.protobuf.Struct" {
+			setStructField(nested, path[1:], value)
+			return nil
+		}
+		return setImportField(nested, field.Message(), path[1:], value)
+	}
+
+	if len(path) > 1 {
+		return fmt.Errorf("field %q has no subfields", name)
+	}
+	if field.IsMap() {
+		return fmt.Errorf("field %q cannot be set from CSV", name)
+	}
+
+	if field.IsList() {
+		var values []interface{}
+		for _, item := range strings.Split(value, CSVListSeparator) {
+			if item = strings.TrimSpace(item); item != "" {
+				converted, err := importScalar(field, item)
+				if err != nil {
+					return err
+				}
+				values = append(values, converted)
+			}
+		}
+		data[name] = values
+		return nil
+	}
+
+	converted, err := importScalar(field, value)
+	if err != nil {
+		return err
+	}
+	data[name] = converted
+	return nil
+}
+
+func setStructField(data map[string]interface{}, path []string, value string) {
+	for _, key := range path[:len(path)-1] {
+		nested, _ := data[key].(map[string]interface{})
+		if nested == nil {
+			nested = map[string]interface{}{}
+			data[key] = nested
+		}
+		data = nested
+	}
+	data[path[len(path)-1]] = value
+}
+
+// importScalar converts the text of a CSV cell to the JSON value of a scalar
+// field. Numbers stay strings, which protojson accepts for every numeric type.
+func importScalar(field protoreflect.FieldDescriptor, value string) (interface{}, error) {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("field %q expects a boolean, got %q", field.Name(), value)
+		}
+		return b, nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return nil, fmt.Errorf("field %q cannot be set from CSV", field.Name())
+	default:
+		return value, nil
+	}
+}
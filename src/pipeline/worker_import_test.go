@@ -0,0 +1,47 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+)
+
+func TestReadCSVRecordsMalformedRow(t *testing.T) {
+	data := "name,tags\n" +
+		"Acme,first\n" +
+		// Bare quote in the first field of the row
+		"a\"b,c\n" +
+		"Beta,third\n"
+
+	var records []*ImportRecord
+	err := (&Worker{}).ReadImportRecords(strings.NewReader(data), &dapi.ImportOptions{Format: ImportFormatCSV, EntityType: "organization"}, func(record *ImportRecord) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3", len(records))
+	}
+	for i, want := range []struct {
+		line int
+		name string
+	}{{2, "Acme"}, {3, ""}, {4, "Beta"}} {
+		record := records[i]
+		if record.Line != want.line {
+			t.Errorf("record %d: got line %d, want %d", i, record.Line, want.line)
+		}
+		if want.name == "" {
+			if record.Err == nil {
+				t.Errorf("record %d: accepted a malformed row", i)
+			}
+			continue
+		}
+		if record.Err != nil || record.Entity.GetOrganization().GetName() != want.name {
+			t.Errorf("record %d: got %v, %v", i, record.Entity, record.Err)
+		}
+	}
+}
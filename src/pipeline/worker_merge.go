@@ -0,0 +1,293 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// The survivor keeps its values, merged entities only fill its empty fields
+	MergeRuleSurvivor = "survivor"
+	// Merged entities override the survivor in the order they are given
+	MergeRuleMerged = "merged"
+	// Maximum number of entities merged into a survivor at once
+	MaxMergedEntities = 20
+)
+
+// ProtectedEntityFields are never copied from one entity to another
+var ProtectedEntityFields = []string{"_id", "_key", "_rev", "owner", "embedding"}
+
+// List fields a merge combines instead of choosing one value
+var mergeListFields = []string{"aliases", "tags", "read", "write"}
+
+// CheckMergeRule validates the conflict rule of a merge and returns its default.
+func (w *Worker) CheckMergeRule(rule string) (string, error) {
+	switch rule {
+	case "":
+		return MergeRuleSurvivor, nil
+	case MergeRuleSurvivor, MergeRuleMerged:
+		return rule, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "conflict rule must be %q or %q", MergeRuleSurvivor, MergeRuleMerged)
+	}
+}
+
+// MergeEntityData merges entity documents ordered from highest to lowest
+// priority. List fields are combined, attributes are merged recursively and
+// any other field takes the first non-empty value. fieldSources pins a field
+// to the value of the document at the given index.
+func (w *Worker) MergeEntityData(docs []map[string]interface{}, fieldSources map[string]int) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for _, doc := range docs {
+		for field := range doc {
+			if _, ok := merged[field]; ok || slices.Contains(ProtectedEntityFields, field) {
+				continue
+			}
+
+			if index, ok := fieldSources[field]; ok {
+				merged[field] = docs[index][field]
+				continue
+			}
+
+			switch {
+			case slices.Contains(mergeListFields, field):
+				var values []interface{}
+				for _, d := range docs {
+					list, _ := d[field].([]interface{})
+					for _, value := range list {
+						if !slices.Contains(values, value) {
+							values = append(values, value)
+						}
+					}
+				}
+				merged[field] = values
+			case field == "attributes":
+				attributes := make(map[string]interface{})
+				for i := len(docs) - 1; i >= 0; i-- {
+					if update, ok := docs[i][field].(map[string]interface{}); ok {
+						attributes = w.MergeDocumentData(attributes, update)
+					}
+				}
+				merged[field] = attributes
+			default:
+				for _, d := range docs {
+					if value, ok := d[field]; ok && !isEmptyValue(value) {
+						merged[field] = value
+						break
+					}
+				}
+			}
+		}
+	}
+	return merged
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// IncidentRelationships lists every relationship connecting the entity with
+// the given _id to another entity.
+func (w *Worker) IncidentRelationships(ctx context.Context, id string) ([]*model.Relation, error) {
+	query := `
+		FOR v, e IN 1..1 ANY @id GRAPH @graphName
+		RETURN DISTINCT e
+	`
+	cursor, err := w.dbClient.DB.Query(ctx, query, map[string]interface{}{
+		"id":        id,
+		"graphName": w.dbClient.OsintGraph.Name(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var relations []*model.Relation
+	for {
+		var relation model.Relation
+		if _, err := cursor.ReadDocument(ctx, &relation); err != nil {
+			if driver.IsNoMoreDocuments(err) {
+				break
+			}
+			return nil, err
+		}
+		relations = append(relations, &relation)
+	}
+	return relations, nil
+}
+
+// RewireRelationships points every relationship of the edge collection that
+// touches one of oldIds at newId instead. Relationships that would connect
+// newId to itself are removed. It returns the number of rewired and removed
+// relationships.
+func (w *Worker) RewireRelationships(ctx context.Context, collectionName string, oldIds []string, newId string, userId string) (int64, int64, error) {
+	bindVars := map[string]interface{}{
+		"@collection": collectionName,
+		"oldIds":      oldIds,
+		"newId":       newId,
+	}
+
+	removeQuery := `
+		FOR e IN @@collection
+		FILTER e._from IN @oldIds OR e._to IN @oldIds
+		LET from = e._from IN @oldIds ? @newId : e._from
+		LET to = e._to IN @oldIds ? @newId : e._to
+		FILTER from == to
+		REMOVE e IN @@collection
+	`
+	removeCursor, err := w.dbClient.DB.Query(ctx, removeQuery, bindVars)
+	if err != nil {
+		return 0, 0, err
+	}
+	removed := removeCursor.Statistics().WritesExecuted()
+	removeCursor.Close()
+
+	updateQuery := `
+		FOR e IN @@collection
+		FILTER e._from IN @oldIds OR e._to IN @oldIds
+		UPDATE e WITH MERGE(@audit, {
+			_from: e._from IN @oldIds ? @newId : e._from,
+			_to: e._to IN @oldIds ? @newId : e._to
+		}) IN @@collection
+	`
+	audit := map[string]interface{}{}
+	w.SetAuditFields(audit, userId, false)
+	bindVars["audit"] = audit
+	updateCursor, err := w.dbClient.DB.Query(ctx, updateQuery, bindVars)
+	if err != nil {
+		return 0, 0, err
+	}
+	rewired := updateCursor.Statistics().WritesExecuted()
+	updateCursor.Close()
+
+	return rewired, removed, nil
+}
+
+// MoveRelationshipEndpoint points the ends of the relationship at oldId to
+// newId instead.
+func (w *Worker) MoveRelationshipEndpoint(ctx context.Context, relation *model.Relation, oldId string, newId string, userId string) error {
+	collectionName, key, err := w.dbClient.ParseDocID(relation.GetId())
+	if err != nil {
+		return err
+	}
+	col, err := w.dbClient.DB.Collection(ctx, collectionName)
+	if err != nil {
+		return err
+	}
+
+	update := make(map[string]interface{})
+	if relation.GetFrom() == oldId {
+		update["_from"] = newId
+	}
+	if relation.GetTo() == oldId {
+		update["_to"] = newId
+	}
+	w.SetAuditFields(update, userId, false)
+	_, err = col.UpdateDocument(ctx, key, update)
+	return err
+}
+
+// DeduplicateRelationships collapses parallel relationships of the edge
+// collection touching the entity with the given _id into the oldest one,
+// which keeps the highest confidence and the combined ACL lists and
+// attributes. Relationships are parallel when they connect the same entities
+// over the same validity period. Only relationships the user can write are
+// collapsed. It returns the number of removed relationships.
+func (w *Worker) DeduplicateRelationships(ctx context.Context, collectionName string, id string, userId string, userRoles []string) (int64, error) {
+	col, err := w.dbClient.DB.Collection(ctx, collectionName)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		FOR e IN @@collection
+		FILTER e._from == @id OR e._to == @id
+		COLLECT from = e._from, to = e._to, validFrom = e.attributes[@validFrom], validTo = e.attributes[@validTo] INTO group = e
+		FILTER LENGTH(group) > 1
+		RETURN (FOR g IN group SORT g.created_at, g._key RETURN g)
+	`
+	cursor, err := w.dbClient.DB.Query(ctx, query, map[string]interface{}{
+		"@collection": collectionName,
+		"id":          id,
+		"validFrom":   ValidFromAttribute,
+		"validTo":     ValidToAttribute,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close()
+
+	var removed int64
+	for {
+		var candidates []map[string]interface{}
+		if _, err := cursor.ReadDocument(ctx, &candidates); err != nil {
+			if driver.IsNoMoreDocuments(err) {
+				break
+			}
+			return 0, err
+		}
+
+		// Relationships of other owners are left as they are
+		var group []map[string]interface{}
+		for _, edge := range candidates {
+			data, err := json.Marshal(edge)
+			if err != nil {
+				return 0, err
+			}
+			var relation model.Relation
+			if err := json.Unmarshal(data, &relation); err != nil {
+				return 0, err
+			}
+			if w.CheckWritePermission(&relation, userId, userRoles) == nil {
+				group = append(group, edge)
+			}
+		}
+		if len(group) < 2 {
+			continue
+		}
+
+		kept := w.MergeEntityData(group, nil)
+		confidence := 0.0
+		for _, edge := range group {
+			if value, ok := edge["confidence"].(float64); ok {
+				confidence = max(confidence, value)
+			}
+		}
+		kept["confidence"] = confidence
+		SetPathWeight(kept)
+		w.SetAuditFields(kept, userId, false)
+
+		keptKey := fmt.Sprint(group[0]["_key"])
+		if _, err := col.UpdateDocument(ctx, keptKey, kept); err != nil {
+			return 0, err
+		}
+		for _, edge := range group[1:] {
+			if _, err := col.RemoveDocument(ctx, fmt.Sprint(edge["_key"])); err != nil {
+				return 0, err
+			}
+			removed++
+		}
+	}
+	return removed, nil
+}
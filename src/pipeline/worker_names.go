@@ -0,0 +1,98 @@
+package pipeline
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	pinyin "github.com/mozillazg/go-pinyin"
+	"golang.org/x/text/unicode/norm"
+)
+
+var pinyinArgs = pinyin.NewArgs()
+
+// NormalizeName reduces a person or organization name to a comparable form:
+// full-width characters are folded, Chinese characters are transliterated to
+// toneless pinyin, accents and punctuation are dropped and the remaining
+// tokens are lower-cased and sorted so that word order does not matter.
+func NormalizeName(name string) string {
+	var builder strings.Builder
+	inHan := false
+	for _, r := range norm.NFKD.String(name) {
+		// A run of Chinese characters becomes a single token
+		isHan := unicode.Is(unicode.Han, r)
+		if isHan != inHan {
+			builder.WriteRune(' ')
+			inHan = isHan
+		}
+
+		switch {
+		case isHan:
+			if syllables := pinyin.SinglePinyin(r, pinyinArgs); len(syllables) > 0 {
+				builder.WriteString(syllables[0])
+			}
+		case unicode.Is(unicode.Mn, r):
+			// Combining accents left over by the decomposition
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			builder.WriteRune(unicode.ToLower(r))
+		default:
+			builder.WriteRune(' ')
+		}
+	}
+
+	tokens := strings.Fields(builder.String())
+	slices.Sort(tokens)
+	return strings.Join(tokens, " ")
+}
+
+// NameBlockingKeys returns the tokens of a normalized name together with the
+// pinyin syllable of every Chinese character in the original name, so that a
+// transliterated run such as "zhangwei" shares keys with "Zhang Wei".
+func NameBlockingKeys(name string, normalized string) []string {
+	keys := strings.Fields(normalized)
+	for _, r := range norm.NFKD.String(name) {
+		if !unicode.Is(unicode.Han, r) {
+			continue
+		}
+		if syllables := pinyin.SinglePinyin(r, pinyinArgs); len(syllables) > 0 {
+			keys = append(keys, syllables[0])
+		}
+	}
+	return keys
+}
+
+// NameSimilarity scores two normalized names between 0 and 1 by the Dice
+// coefficient of their character bigrams, ignoring spaces.
+func NameSimilarity(a string, b string) float64 {
+	a = strings.ReplaceAll(a, " ", "")
+	b = strings.ReplaceAll(b, " ", "")
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	bigrams := func(s string) map[string]int {
+		runes := []rune(s)
+		result := make(map[string]int)
+		if len(runes) == 1 {
+			result[s]++
+		}
+		for i := 0; i+1 < len(runes); i++ {
+			result[string(runes[i:i+2])]++
+		}
+		return result
+	}
+	aBigrams, bBigrams := bigrams(a), bigrams(b)
+
+	shared, total := 0, 0
+	for bigram, count := range aBigrams {
+		shared += min(count, bBigrams[bigram])
+		total += count
+	}
+	for _, count := range bBigrams {
+		total += count
+	}
+	return 2 * float64(shared) / float64(total)
+}
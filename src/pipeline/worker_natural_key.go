@@ -0,0 +1,80 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/arangodb/go-driver"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NaturalKeyFields lists, per entity type, the fields identifying an entity
+// across ingestions. The first field is required. Organizations have no
+// country field, their country is read from the country attribute.
+var NaturalKeyFields = map[string][]string{
+	"website":      {"url"},
+	"source":       {"url"},
+	"person":       {"name", "birth_date"},
+	"organization": {"name", "attributes.country"},
+}
+
+// NaturalKey extracts the natural key of an entity document as values in
+// NaturalKeyFields order. Missing optional fields are nil.
+func (w *Worker) NaturalKey(entityType string, data map[string]interface{}) ([]interface{}, error) {
+	fields, ok := NaturalKeyFields[entityType]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "%s entities have no natural key", entityType)
+	}
+
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		var value interface{} = data
+		for _, part := range strings.Split(field, ".") {
+			doc, _ := value.(map[string]interface{})
+			value = doc[part]
+		}
+		if isEmptyValue(value) {
+			if i == 0 {
+				return nil, status.Errorf(codes.InvalidArgument, "%s is required to identify a %s", field, entityType)
+			}
+			value = nil
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// FindByNaturalKey returns the key of the entity matching the natural key
+// returned by NaturalKey, or an empty string if there is none.
+func (w *Worker) FindByNaturalKey(ctx context.Context, entityType string, naturalKey []interface{}) (string, error) {
+	bindVars := map[string]interface{}{"@collection": entityType}
+	var filters []string
+	for i, field := range NaturalKeyFields[entityType] {
+		filters = append(filters, fmt.Sprintf("d.%s == @key%d", field, i))
+		bindVars[fmt.Sprintf("key%d", i)] = naturalKey[i]
+	}
+
+	query := `
+		FOR d IN @@collection
+		FILTER ` + strings.Join(filters, " AND ") + `
+		SORT d._key
+		LIMIT 1
+		RETURN d._key
+	`
+	cursor, err := w.dbClient.DB.Query(ctx, query, bindVars)
+	if err != nil {
+		return "", err
+	}
+	defer cursor.Close()
+
+	var key string
+	if _, err := cursor.ReadDocument(ctx, &key); err != nil {
+		if driver.IsNoMoreDocuments(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return key, nil
+}
@@ -0,0 +1,299 @@
+package pipeline
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	NormalizerUnicode     = "unicode"
+	NormalizerURL         = "url"
+	NormalizerTags        = "tags"
+	NormalizerCountryCode = "country_code"
+
+	// Document field keeping the values replaced by normalization
+	RawValuesField = "raw"
+)
+
+// Normalizers run on every entity unless configured otherwise. Unicode
+// normalization runs first so that the other normalizers see NFKC text.
+var DefaultNormalizers = []string{NormalizerUnicode, NormalizerURL, NormalizerTags, NormalizerCountryCode}
+
+// Query parameters added by trackers that do not identify the page
+var trackingQueryParams = []string{"fbclid", "gclid", "dclid", "msclkid", "yclid", "igshid", "mc_cid", "mc_eid", "_ga", "ref_src"}
+
+// RawValues maps the field paths changed by normalization to their values
+// before normalization. Fields written without a change map to nil.
+type RawValues map[string]interface{}
+
+// AddTo stores the raw values in the document. Updates also reset the raw
+// values of the fields they write without a change, so that a stale value
+// does not outlive the field it was recorded for.
+func (r RawValues) AddTo(dataMap map[string]interface{}, isCreate bool) {
+	values := make(map[string]interface{}, len(r))
+	for field, value := range r {
+		if value != nil || !isCreate {
+			values[field] = value
+		}
+	}
+	if len(values) > 0 {
+		dataMap[RawValuesField] = values
+	}
+}
+
+// normalizer rewrites the fields of an entity in place, recording the
+// original values of the fields it changes.
+type normalizer func(entity ConcereteEntityCommon, raw RawValues)
+
+var normalizers = map[string]normalizer{
+	NormalizerUnicode:     normalizeUnicode,
+	NormalizerURL:         normalizeURLs,
+	NormalizerTags:        normalizeTags,
+	NormalizerCountryCode: normalizeCountryCodes,
+}
+
+// SetNormalizers configures the normalizers run by NormalizeEntity, in order.
+func (w *Worker) SetNormalizers(names []string) error {
+	var enabled []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || name == "none" {
+			continue
+		}
+		if _, ok := normalizers[name]; !ok {
+			return fmt.Errorf("unknown normalizer: %s", name)
+		}
+		enabled = append(enabled, name)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.normalizers = enabled
+	return nil
+}
+
+// NormalizeEntity cleans up the fields of an input entity before it is
+// persisted and returns the original values of the fields it changed.
+func (w *Worker) NormalizeEntity(entity ConcereteEntityCommon) RawValues {
+	w.mu.RLock()
+	enabled := w.normalizers
+	w.mu.RUnlock()
+
+	raw := RawValues{}
+	for _, name := range enabled {
+		normalizers[name](entity, raw)
+	}
+	return raw
+}
+
+// setString replaces *value with its normalized form, remembering the value
+// first seen for the field. Empty values are not written and not recorded.
+func (r RawValues) setString(field string, value *string, normalize func(string) string) {
+	if *value == "" {
+		return
+	}
+	normalized := normalize(*value)
+	if normalized == *value {
+		if _, ok := r[field]; !ok {
+			r[field] = nil
+		}
+		return
+	}
+	if r[field] == nil {
+		r[field] = *value
+	}
+	*value = normalized
+}
+
+func (r RawValues) setStrings(field string, values *[]string, normalize func([]string) []string) {
+	if len(*values) == 0 {
+		return
+	}
+	normalized := normalize(*values)
+	if slices.Equal(normalized, *values) {
+		if _, ok := r[field]; !ok {
+			r[field] = nil
+		}
+		return
+	}
+	if r[field] == nil {
+		r[field] = slices.Clone(*values)
+	}
+	*values = normalized
+}
+
+// =====================================================
+// Unicode
+// =====================================================
+
+// NormalizeText folds a single line of text to NFKC and collapses its
+// whitespace.
+func NormalizeText(text string) string {
+	return strings.Join(strings.Fields(norm.NFKC.String(text)), " ")
+}
+
+// normalizeParagraph folds free text to NFKC, keeping its line breaks.
+func normalizeParagraph(text string) string {
+	return strings.TrimSpace(norm.NFKC.String(text))
+}
+
+func normalizeTexts(values []string) []string {
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = NormalizeText(value); value != "" && !slices.Contains(normalized, value) {
+			normalized = append(normalized, value)
+		}
+	}
+	return normalized
+}
+
+func normalizeUnicode(entity ConcereteEntityCommon, raw RawValues) {
+	switch v := entity.(type) {
+	case *model.Event:
+		raw.setString("type", &v.Type, NormalizeText)
+		raw.setString("title", &v.Title, NormalizeText)
+		raw.setString("description", &v.Description, normalizeParagraph)
+		if location := v.GetLocation(); location != nil {
+			raw.setString("location.administrative_area", &location.AdministrativeArea, NormalizeText)
+			raw.setString("location.sub_administrative_area", &location.SubAdministrativeArea, NormalizeText)
+			raw.setString("location.locality", &location.Locality, NormalizeText)
+			raw.setString("location.sub_locality", &location.SubLocality, NormalizeText)
+			raw.setString("location.address", &location.Address, NormalizeText)
+		}
+	case *model.Source:
+		raw.setString("type", &v.Type, NormalizeText)
+		raw.setString("name", &v.Name, NormalizeText)
+		raw.setString("title", &v.Title, NormalizeText)
+		raw.setString("description", &v.Description, normalizeParagraph)
+	case *model.Website:
+		raw.setString("title", &v.Title, NormalizeText)
+		raw.setString("description", &v.Description, normalizeParagraph)
+	case *model.Person:
+		raw.setString("role", &v.Role, NormalizeText)
+		raw.setString("name", &v.Name, NormalizeText)
+		raw.setString("nationality", &v.Nationality, NormalizeText)
+		raw.setStrings("aliases", &v.Aliases, normalizeTexts)
+	case *model.Organization:
+		raw.setString("type", &v.Type, NormalizeText)
+		raw.setString("name", &v.Name, NormalizeText)
+	}
+}
+
+// =====================================================
+// URLs
+// =====================================================
+
+// CanonicalURL lower-cases the scheme and host of an absolute URL and drops
+// default ports, fragments and tracking query parameters. The remaining
+// query parameters are sorted. Values that are not absolute URLs are only
+// trimmed.
+func CanonicalURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return rawURL
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	if port := parsed.Port(); (parsed.Scheme == "http" && port == "80") || (parsed.Scheme == "https" && port == "443") {
+		parsed.Host = parsed.Hostname()
+	}
+	parsed.Fragment = ""
+	parsed.RawFragment = ""
+	if parsed.Path == "/" {
+		parsed.Path = ""
+		parsed.RawPath = ""
+	}
+
+	query := parsed.Query()
+	for param := range query {
+		if strings.HasPrefix(strings.ToLower(param), "utm_") || slices.Contains(trackingQueryParams, strings.ToLower(param)) {
+			query.Del(param)
+		}
+	}
+	parsed.RawQuery = query.Encode()
+	parsed.ForceQuery = false
+
+	return parsed.String()
+}
+
+func normalizeURLs(entity ConcereteEntityCommon, raw RawValues) {
+	switch v := entity.(type) {
+	case *model.Source:
+		raw.setString("url", &v.Url, CanonicalURL)
+	case *model.Website:
+		raw.setString("url", &v.Url, CanonicalURL)
+	}
+}
+
+// =====================================================
+// Tags
+// =====================================================
+
+// NormalizeTags lower-cases tags and removes empty and duplicate ones,
+// keeping the order in which they first appear.
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = strings.ToLower(NormalizeText(tag)); tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// NormalizeTagFilter normalizes a tag to filter by the way the stored tags
+// are, so that it matches them when the tags normalizer runs.
+func (w *Worker) NormalizeTagFilter(tag string) string {
+	w.mu.RLock()
+	enabled := slices.Contains(w.normalizers, NormalizerTags)
+	w.mu.RUnlock()
+
+	if !enabled {
+		return tag
+	}
+	return strings.ToLower(NormalizeText(tag))
+}
+
+func normalizeTags(entity ConcereteEntityCommon, raw RawValues) {
+	switch v := entity.(type) {
+	case *model.Event:
+		raw.setStrings("tags", &v.Tags, NormalizeTags)
+	case *model.Source:
+		raw.setStrings("tags", &v.Tags, NormalizeTags)
+	case *model.Website:
+		raw.setStrings("tags", &v.Tags, NormalizeTags)
+	case *model.Person:
+		raw.setStrings("tags", &v.Tags, NormalizeTags)
+	case *model.Organization:
+		raw.setStrings("tags", &v.Tags, NormalizeTags)
+	}
+}
+
+// =====================================================
+// Country codes
+// =====================================================
+
+func normalizeCountryCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func normalizeCountryCodes(entity ConcereteEntityCommon, raw RawValues) {
+	switch v := entity.(type) {
+	case *model.Event:
+		if location := v.GetLocation(); location != nil {
+			raw.setString("location.country_code", &location.CountryCode, normalizeCountryCode)
+		}
+	case *model.Person:
+		// Nationalities are free text, only codes are upper-cased
+		if code := normalizeCountryCode(v.GetNationality()); IsCountryCode(code) {
+			raw.setString("nationality", &v.Nationality, normalizeCountryCode)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package pipeline
+
+import "testing"
+
+func TestNormalizeTagFilter(t *testing.T) {
+	w := &Worker{}
+	if got := w.NormalizeTagFilter(" Supply Chain "); got != " Supply Chain " {
+		t.Errorf("got %q without the tags normalizer", got)
+	}
+
+	if err := w.SetNormalizers(DefaultNormalizers); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"", ""},
+		{" Supply Chain ", "supply chain"},
+		{"ＡＩ", "ai"},
+		{"产业", "产业"},
+	}
+	for _, tt := range tests {
+		if got := w.NormalizeTagFilter(tt.tag); got != tt.want {
+			t.Errorf("NormalizeTagFilter(%q) = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+	for _, tt := range tests[1:] {
+		if tags := NormalizeTags([]string{tt.tag}); len(tags) != 1 || tags[0] != w.NormalizeTagFilter(tt.tag) {
+			t.Errorf("filter for %q does not match the stored tags %v", tt.tag, tags)
+		}
+	}
+}
@@ -25,70 +25,16 @@ func (w *Worker) ProcessEntities(ctx context.Context, entities []EntityResult, r
 	logger := utils.GetLogger(ctx)
 
 	for _, er := range entities {
-		switch er.Type {
-		case "event":
-			var event model.Event
-			if err := json.Unmarshal(er.Data, &event); err != nil {
-				logger.WithFields(logrus.Fields{
-					"type":  er.Type,
-					"error": err,
-				}).Error("failed to unmarshal entity data")
-				continue
-			}
-			allowedIds[event.GetId()] = struct{}{}
-			pbEntities = append(pbEntities, &model.Entity{Entity: &model.Entity_Event{Event: &event}})
-
-		case "source":
-			var source model.Source
-			if err := json.Unmarshal(er.Data, &source); err != nil {
-				logger.WithFields(logrus.Fields{
-					"type":  er.Type,
-					"error": err,
-				}).Error("failed to unmarshal entity data")
-				continue
-			}
-			allowedIds[source.GetId()] = struct{}{}
-			pbEntities = append(pbEntities, &model.Entity{Entity: &model.Entity_Source{Source: &source}})
-
-		case "website":
-			var website model.Website
-			if err := json.Unmarshal(er.Data, &website); err != nil {
-				logger.WithFields(logrus.Fields{
-					"type":  er.Type,
-					"error": err,
-				}).Error("failed to unmarshal entity data")
-				continue
-			}
-			allowedIds[website.GetId()] = struct{}{}
-			pbEntities = append(pbEntities, &model.Entity{Entity: &model.Entity_Website{Website: &website}})
-
-		case "person":
-			var person model.Person
-			if err := json.Unmarshal(er.Data, &person); err != nil {
-				logger.WithFields(logrus.Fields{
-					"type":  er.Type,
-					"error": err,
-				}).Error("failed to unmarshal entity data")
-				continue
-			}
-			allowedIds[person.GetId()] = struct{}{}
-			pbEntities = append(pbEntities, &model.Entity{Entity: &model.Entity_Person{Person: &person}})
-
-		case "organization":
-			var organization model.Organization
-			if err := json.Unmarshal(er.Data, &organization); err != nil {
-				logger.WithFields(logrus.Fields{
-					"type":  er.Type,
-					"error": err,
-				}).Error("failed to unmarshal entity data")
-				continue
-			}
-			allowedIds[organization.GetId()] = struct{}{}
-			pbEntities = append(pbEntities, &model.Entity{Entity: &model.Entity_Organization{Organization: &organization}})
-
-		default:
-			logger.WithField("type", er.Type).Warn("unknown entity type")
+		entity, id, err := w.DecodeEntity(er)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"type":  er.Type,
+				"error": err,
+			}).Error("failed to unmarshal entity data")
+			continue
 		}
+		allowedIds[id] = struct{}{}
+		pbEntities = append(pbEntities, entity)
 	}
 
 	var pbRelations []*model.Relation
@@ -103,3 +49,20 @@ func (w *Worker) ProcessEntities(ctx context.Context, entities []EntityResult, r
 
 	return pbEntities, pbRelations
 }
+
+// DecodeEntity converts a typed query result into an entity wrapper and returns its _id.
+func (w *Worker) DecodeEntity(er EntityResult) (*model.Entity, string, error) {
+	entity, err := w.CreateEntityStruct(er.Type)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := json.Unmarshal(er.Data, entity); err != nil {
+		return nil, "", err
+	}
+
+	wrapped, err := w.WrapEntityResponse(entity)
+	if err != nil {
+		return nil, "", err
+	}
+	return wrapped, entity.GetId(), nil
+}
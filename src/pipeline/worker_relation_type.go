@@ -0,0 +1,97 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const RelationTypeCollection = "relation_type"
+
+// Relation names are part of edge collection names and relation type keys,
+// which ArangoDB limits to ASCII. Other scripts belong in relationship labels.
+var relationNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+func (w *Worker) RegisterRelationTypeCollection(col driver.Collection) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.relationTypes = col
+}
+
+// GetRelationTypeCollection retrieves the registered relation type collection.
+func (w *Worker) GetRelationTypeCollection() (driver.Collection, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.relationTypes == nil {
+		return nil, fmt.Errorf("relation type collection not registered")
+	}
+	return w.relationTypes, nil
+}
+
+// NormalizeRelationName converts a client supplied relation name into the form
+// used in edge collection names.
+func (w *Worker) NormalizeRelationName(name string) (string, error) {
+	relationName := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), " ", "_"))
+	if !relationNamePattern.MatchString(relationName) {
+		return "", status.Errorf(codes.InvalidArgument, "invalid relation name %q: relation names are ASCII letters, digits and underscores starting with a letter", name)
+	}
+	return relationName, nil
+}
+
+// CheckRelationTypeDefinition validates the entity types of a relation type.
+func (w *Worker) CheckRelationTypeDefinition(relationType *dapi.RelationType) error {
+	for _, entityType := range append(relationType.GetFromTypes(), relationType.GetToTypes()...) {
+		if !slices.Contains(EntityTypes, entityType) {
+			return status.Errorf(codes.InvalidArgument, "%s is not an entity collection", entityType)
+		}
+	}
+	if relationType.GetInverseName() != "" {
+		if _, err := w.NormalizeRelationName(relationType.GetInverseName()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetRelationType reads a registered relation type by its normalized name.
+func (w *Worker) GetRelationType(ctx context.Context, relationName string) (*dapi.RelationType, error) {
+	col, err := w.GetRelationTypeCollection()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal service error")
+	}
+
+	var relationType dapi.RelationType
+	if _, err := w.ReadDocument(ctx, col, relationName, &relationType); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown relation type: %s", relationName)
+		}
+		return nil, err
+	}
+	return &relationType, nil
+}
+
+// ValidateRelationType checks that relationName is registered and allows an edge
+// from fromType to toType.
+func (w *Worker) ValidateRelationType(ctx context.Context, relationName string, fromType string, toType string) (*dapi.RelationType, error) {
+	relationType, err := w.GetRelationType(ctx, relationName)
+	if err != nil {
+		return nil, err
+	}
+
+	allows := func(from, to string) bool {
+		return (len(relationType.GetFromTypes()) == 0 || slices.Contains(relationType.GetFromTypes(), from)) &&
+			(len(relationType.GetToTypes()) == 0 || slices.Contains(relationType.GetToTypes(), to))
+	}
+	if allows(fromType, toType) || (!relationType.GetDirected() && allows(toType, fromType)) {
+		return relationType, nil
+	}
+
+	return nil, status.Errorf(codes.InvalidArgument, "relation type %s does not allow %s -> %s", relationName, fromType, toType)
+}
@@ -0,0 +1,132 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RelationshipCollection checks the name, endpoint types and attributes of a
+// new relationship against the relation type registry and returns the edge
+// collection it belongs to, creating the collection if needed. The name of
+// the relationship is replaced by its normalized form. Only the endpoint ids
+// are parsed, their existence is checked by CheckRelationshipEndpoints.
+func (w *Worker) RelationshipCollection(ctx context.Context, relation *model.Relation) (driver.Collection, error) {
+	logger := utils.GetLogger(ctx)
+
+	endpointType := func(id string) (string, error) {
+		entityType, _, err := w.dbClient.ParseDocID(id)
+		if err != nil {
+			return "", status.Errorf(codes.InvalidArgument, "invalid entity id: %s", id)
+		}
+		if !slices.Contains(EntityTypes, entityType) {
+			return "", status.Errorf(codes.InvalidArgument, "%s is not an entity collection", entityType)
+		}
+		return entityType, nil
+	}
+	fromColl, err := endpointType(relation.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	toColl, err := endpointType(relation.GetTo())
+	if err != nil {
+		return nil, err
+	}
+
+	// Process relation name against the relation type registry
+	relationName, err := w.NormalizeRelationName(relation.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.ValidateRelationType(ctx, relationName, fromColl, toColl); err != nil {
+		return nil, err
+	}
+	relation.Name = relationName
+
+	if err := w.CheckValidityAttributes(relation.GetAttributes().AsMap()); err != nil {
+		return nil, err
+	}
+
+	// Create the edge collection if it doesn't exist
+	collectionName := EdgeCollectionName(fromColl, relationName, toColl)
+	col, err := w.dbClient.GetCreateEdgeCollection(ctx, collectionName, driver.VertexConstraints{
+		From: []string{fromColl},
+		To:   []string{toColl},
+	}, driver.CreateEdgeCollectionOptions{})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"name":  collectionName,
+		}).Errorf("failed to get or create collection %s", collectionName)
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	if err := w.ApplyRelationSchema(ctx, col); err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	return col, nil
+}
+
+// CheckRelationshipEndpoints checks that both endpoints of a relationship
+// exist and are readable by the user.
+func (w *Worker) CheckRelationshipEndpoints(ctx context.Context, relation *model.Relation, userId string, userRoles []string) error {
+	if _, err := w.ResolveEndpoint(ctx, relation.GetFrom(), userId, userRoles); err != nil {
+		return err
+	}
+	if _, err := w.ResolveEndpoint(ctx, relation.GetTo(), userId, userRoles); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateRelationship inserts a relationship owned by the user into the edge
+// collection returned by RelationshipCollection, under its key if set as for
+// imported relationships. The endpoints must have been checked with
+// CheckRelationshipEndpoints.
+func (w *Worker) CreateRelationship(ctx context.Context, col driver.Collection, relation *model.Relation, userId string) (*model.Relation, error) {
+	logger := utils.GetLogger(ctx)
+
+	if err := w.SetPermissions(relation, userId, true); err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to set permissions")
+		return nil, err
+	}
+
+	// Create document in collection
+	relation.Id = ""
+	relation.Rev = ""
+
+	data, err := json.Marshal(relation)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal relationship data")
+	}
+	var dataMap map[string]interface{}
+	if err := json.Unmarshal(data, &dataMap); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal relationship data")
+	}
+	SetPathWeight(dataMap)
+	w.SetAuditFields(dataMap, userId, true)
+
+	var createdRelationship model.Relation
+	ctxWithReturnNew := driver.WithReturnNew(ctx, &createdRelationship)
+	meta, err := col.CreateDocument(ctxWithReturnNew, dataMap)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"data":  dataMap,
+		}).Error("failed to create relationship document")
+		return nil, w.TranslateDBError(err, col.Name(), "")
+	}
+
+	createdRelationship.Id = meta.ID.String()
+	createdRelationship.Key = meta.Key
+	createdRelationship.Rev = meta.Rev
+	return &createdRelationship, nil
+}
@@ -0,0 +1,253 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DefaultSchemaLevel validates new documents and changes to valid documents,
+// so documents written before the schema existed can still be updated.
+const DefaultSchemaLevel = driver.CollectionSchemaLevelModerate
+
+var schemaLevels = map[string]driver.CollectionSchemaLevel{
+	string(driver.CollectionSchemaLevelNone):     driver.CollectionSchemaLevelNone,
+	string(driver.CollectionSchemaLevelNew):      driver.CollectionSchemaLevelNew,
+	string(driver.CollectionSchemaLevelModerate): driver.CollectionSchemaLevelModerate,
+	string(driver.CollectionSchemaLevelStrict):   driver.CollectionSchemaLevelStrict,
+}
+
+// Model fields stored with the ArangoDB system attribute names
+var systemAttributes = map[protoreflect.Name]string{
+	"id":   "_id",
+	"key":  "_key",
+	"rev":  "_rev",
+	"from": "_from",
+	"to":   "_to",
+}
+
+// Fields the service stores next to the model fields of a document
+var serviceFields = map[string]interface{}{
+	"embedding":     nullable(map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "number"}}),
+	CreatedAtField:  nullable(map[string]interface{}{"type": "integer"}),
+	CreatedByField:  nullable(map[string]interface{}{"type": "string"}),
+	UpdatedAtField:  nullable(map[string]interface{}{"type": "integer"}),
+	UpdatedByField:  nullable(map[string]interface{}{"type": "string"}),
+	RawValuesField:  nullable(map[string]interface{}{"type": "object"}),
+	GeoField:        nullable(map[string]interface{}{"type": "object"}),
+	PathWeightField: nullable(map[string]interface{}{"type": "number"}),
+}
+
+// SetSchemaLevel configures how strictly the collection schemas installed by
+// ApplyEntitySchema and ApplyRelationSchema are enforced.
+func (w *Worker) SetSchemaLevel(level string) error {
+	schemaLevel, ok := schemaLevels[strings.TrimSpace(level)]
+	if !ok {
+		return fmt.Errorf("unknown schema level: %s", level)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.schemaLevel = schemaLevel
+	return nil
+}
+
+// SchemaRule returns the JSON Schema of documents stored from the message:
+// the model fields as written by SetAdditionalFields and the service fields.
+// Unknown fields are rejected.
+func SchemaRule(message proto.Message) map[string]interface{} {
+	rule := messageSchema(message.ProtoReflect().Descriptor(), true, nil)
+	properties := rule["properties"].(map[string]interface{})
+	for name, schema := range serviceFields {
+		if _, ok := properties[name]; !ok {
+			properties[name] = schema
+		}
+	}
+	rule["type"] = "object"
+	return rule
+}
+
+// messageSchema describes a message as encoded by encoding/json, which uses
+// the proto field names. Fields of the top level message that hold the
+// document id are stored under the system attribute names.
+func messageSchema(desc protoreflect.MessageDescriptor, top bool, seen []protoreflect.FullName) map[string]interface{} {
+	switch desc.FullName() {
+	case "google.protobuf.Struct":
+		return nullable(map[string]interface{}{"type": "object"})
+	case "google.protobuf.ListValue":
+		return nullable(map[string]interface{}{"type": "array"})
+	case "google.protobuf.Value":
+		return map[string]interface{}{}
+	}
+	for _, name := range seen {
+		if name == desc.FullName() {
+			// Recursive messages are only checked down to the first repetition
+			return nullable(map[string]interface{}{"type": "object"})
+		}
+	}
+	seen = append(seen, desc.FullName())
+
+	properties := make(map[string]interface{})
+	closed := true
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if oneof := field.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			// encoding/json names oneofs after their Go wrapper types
+			closed = false
+			continue
+		}
+		name := string(field.Name())
+		if attribute, ok := systemAttributes[field.Name()]; ok && top {
+			name = attribute
+		}
+		properties[name] = fieldSchema(field, seen)
+	}
+
+	return nullable(map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": !closed,
+	})
+}
+
+func fieldSchema(field protoreflect.FieldDescriptor, seen []protoreflect.FullName) map[string]interface{} {
+	if field.IsMap() {
+		return nullable(map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": singularSchema(field.MapValue(), seen),
+		})
+	}
+	if field.IsList() {
+		return nullable(map[string]interface{}{
+			"type":  "array",
+			"items": singularSchema(field, seen),
+		})
+	}
+	return singularSchema(field, seen)
+}
+
+func singularSchema(field protoreflect.FieldDescriptor, seen []protoreflect.FullName) map[string]interface{} {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageSchema(field.Message(), false, seen)
+	case protoreflect.BoolKind:
+		return nullable(map[string]interface{}{"type": "boolean"})
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		// encoding/json writes bytes as base64 strings
+		return nullable(map[string]interface{}{"type": "string"})
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return nullable(map[string]interface{}{"type": "number"})
+	default:
+		// Integers and enums, which encoding/json writes as numbers
+		return nullable(map[string]interface{}{"type": "integer"})
+	}
+}
+
+// nullable lets a field hold null, which updates write to clear it.
+func nullable(schema map[string]interface{}) map[string]interface{} {
+	if t, ok := schema["type"].(string); ok {
+		schema["type"] = []string{t, "null"}
+	}
+	return schema
+}
+
+// ApplyEntitySchema installs the schema of the entity type on its collection
+// with the configured level.
+func (w *Worker) ApplyEntitySchema(ctx context.Context, entityType string, col driver.Collection) error {
+	entity, err := w.CreateEntityStruct(entityType)
+	if err != nil {
+		return err
+	}
+	return w.applySchema(ctx, col, entity.(proto.Message))
+}
+
+// ApplyRelationSchema installs the relation schema on an edge collection.
+// Edge collections are created on demand, so the schema is installed once
+// per collection and process.
+func (w *Worker) ApplyRelationSchema(ctx context.Context, col driver.Collection) error {
+	w.mu.RLock()
+	_, applied := w.schemas[col.Name()]
+	w.mu.RUnlock()
+	if applied {
+		return nil
+	}
+
+	if err := w.applySchema(ctx, col, &model.Relation{}); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.schemas[col.Name()] = struct{}{}
+	return nil
+}
+
+func (w *Worker) applySchema(ctx context.Context, col driver.Collection, message proto.Message) error {
+	w.mu.RLock()
+	level := w.schemaLevel
+	w.mu.RUnlock()
+
+	err := col.SetProperties(ctx, driver.SetCollectionPropertiesOptions{
+		Schema: &driver.CollectionSchemaOptions{
+			Rule:    SchemaRule(message),
+			Level:   level,
+			Message: fmt.Sprintf("document does not match the fields of %s", message.ProtoReflect().Descriptor().Name()),
+		},
+	})
+	if err != nil {
+		logrus.WithContext(ctx).WithFields(logrus.Fields{
+			"collection": col.Name(),
+			"level":      level,
+			"error":      err,
+		}).Error("Failed to install collection schema")
+		return err
+	}
+	return nil
+}
+
+// SchemaViolation is a stored document that does not match the schema of
+// its collection.
+type SchemaViolation struct {
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+// SchemaViolations counts the documents of a collection that do not match
+// the schema SchemaRule generates from message, whatever schema and level are
+// installed, and returns up to limit of them. The collection is only read.
+func (w *Worker) SchemaViolations(ctx context.Context, collection string, message proto.Message, limit int) (int64, []SchemaViolation, error) {
+	query := `
+		LET violations = (
+			FOR d IN @@collection
+			LET result = SCHEMA_VALIDATE(d, @schema)
+			FILTER NOT result.valid
+			RETURN { key: d._key, message: result.errorMessage }
+		)
+		RETURN { count: LENGTH(violations), violations: SLICE(violations, 0, @limit) }
+	`
+	cursor, err := w.dbClient.DB.Query(ctx, query, map[string]interface{}{
+		"@collection": collection,
+		"schema":      map[string]interface{}{"rule": SchemaRule(message)},
+		"limit":       limit,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	defer cursor.Close()
+
+	var result struct {
+		Count      int64             `json:"count"`
+		Violations []SchemaViolation `json:"violations"`
+	}
+	if _, err := cursor.ReadDocument(ctx, &result); err != nil {
+		return 0, nil, err
+	}
+	return result.Count, result.Violations, nil
+}
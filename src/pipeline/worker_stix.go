@@ -0,0 +1,616 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	StixSpecVersion = "2.1"
+
+	// Custom properties keeping what STIX has no property for. Objects that
+	// carry them are imported back without loss.
+	StixIdProperty       = "x_omnsight_id"
+	StixEntityProperty   = "x_omnsight_entity"
+	StixRelationProperty = "x_omnsight_relation"
+	StixSourcesProperty  = "x_omnsight_sources"
+
+	// Attribute keeping the STIX id of an imported object when it differs from
+	// the id ExportStix derives
+	StixIdAttribute = "stix_id"
+)
+
+// STIX type of each exported entity type. Sources become external references.
+var stixTypes = map[string]string{
+	"event":        "incident",
+	"person":       "identity",
+	"organization": "identity",
+	"website":      "infrastructure",
+}
+
+// Namespace of the STIX ids derived from entity and relationship ids
+var stixNamespace = uuid.MustParse("3d0c6f4e-8a51-4b7f-9e26-5c1d7a9b0e83")
+
+// Fields kept out of the custom properties, ids are mapped separately and
+// permissions belong to the importing user
+var stixSkippedFields = []string{"id", "key", "rev", "owner", "read", "write", "from", "to"}
+
+// StixId derives the STIX id of an entity or relationship from its _id.
+func StixId(stixType string, id string) string {
+	return stixType + "--" + uuid.NewSHA1(stixNamespace, []byte(id)).String()
+}
+
+// =====================================================
+// Export
+// =====================================================
+
+// StixBundle maps entities and the relations among them to a STIX 2.1 bundle.
+// Relations with a source become external references of the other endpoint.
+func (w *Worker) StixBundle(entities []*model.Entity, relations []*model.Relation) (map[string]interface{}, error) {
+	objects := []interface{}{}
+	byId := map[string]map[string]interface{}{}
+	sources := map[string]*model.Source{}
+
+	for _, entity := range entities {
+		if source := entity.GetSource(); source != nil {
+			sources[source.GetId()] = source
+			continue
+		}
+		object, err := w.stixEntityObject(entity)
+		if err != nil {
+			return nil, err
+		}
+		byId[object[StixIdProperty].(string)] = object
+		objects = append(objects, object)
+	}
+
+	for _, relation := range relations {
+		from, to := byId[relation.GetFrom()], byId[relation.GetTo()]
+		source, inbound := sources[relation.GetTo()], false
+		if source == nil && sources[relation.GetFrom()] != nil {
+			source, inbound = sources[relation.GetFrom()], true
+			from = to
+		}
+
+		switch {
+		case source != nil && from != nil:
+			if err := addStixReference(from, source, relation, inbound); err != nil {
+				return nil, err
+			}
+		case from != nil && to != nil:
+			object, err := stixRelationshipObject(relation, from["id"].(string), to["id"].(string))
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, object)
+		}
+	}
+
+	return map[string]interface{}{
+		"type":    "bundle",
+		"id":      "bundle--" + uuid.NewString(),
+		"objects": objects,
+	}, nil
+}
+
+func (w *Worker) stixEntityObject(entity *model.Entity) (map[string]interface{}, error) {
+	oneof := entity.ProtoReflect().WhichOneof(entity.ProtoReflect().Descriptor().Oneofs().ByName("entity"))
+	if oneof == nil {
+		return nil, fmt.Errorf("entity has no type")
+	}
+	entityType := string(oneof.Name())
+	message := entity.ProtoReflect().Get(oneof).Message().Interface()
+
+	fields, err := stixCustomFields(message)
+	if err != nil {
+		return nil, err
+	}
+	id := message.(ConcereteEntityCommon).GetId()
+	object := stixCommonProperties(stixTypes[entityType], id, message)
+	object[StixIdProperty] = id
+	object[StixEntityProperty] = fields
+
+	// STIX requires a name, fall back to the id for unnamed entities
+	switch v := message.(type) {
+	case *model.Event:
+		object["name"] = lo.CoalesceOrEmpty(v.GetTitle(), id)
+		setStixProperty(object, "description", v.GetDescription())
+	case *model.Person:
+		object["name"] = lo.CoalesceOrEmpty(v.GetName(), id)
+		object["identity_class"] = "individual"
+		if v.GetRole() != "" {
+			object["roles"] = []interface{}{v.GetRole()}
+		}
+	case *model.Organization:
+		object["name"] = lo.CoalesceOrEmpty(v.GetName(), id)
+		object["identity_class"] = "organization"
+	case *model.Website:
+		object["name"] = lo.CoalesceOrEmpty(v.GetTitle(), v.GetUrl(), id)
+		setStixProperty(object, "description", v.GetDescription())
+		setStixProperty(object, "first_seen", stixTimestamp(v.GetFoundedAt()))
+		setStixProperty(object, "last_seen", stixTimestamp(v.GetLastVisited()))
+	}
+	return object, nil
+}
+
+func stixRelationshipObject(relation *model.Relation, sourceRef string, targetRef string) (map[string]interface{}, error) {
+	fields, err := stixCustomFields(relation)
+	if err != nil {
+		return nil, err
+	}
+	object := stixCommonProperties("relationship", relation.GetId(), relation)
+	object[StixIdProperty] = relation.GetId()
+	object[StixRelationProperty] = fields
+	object["relationship_type"] = strings.ReplaceAll(relation.GetName(), "_", "-")
+	object["source_ref"] = sourceRef
+	object["target_ref"] = targetRef
+	setStixProperty(object, "description", relation.GetLabel())
+	if relation.GetConfidence() > 0 {
+		object["confidence"] = relation.GetConfidence()
+	}
+
+	attributes := relation.GetAttributes().AsMap()
+	for attribute, property := range map[string]string{ValidFromAttribute: "start_time", ValidToAttribute: "stop_time"} {
+		if seconds, ok := attributes[attribute].(float64); ok {
+			object[property] = stixTimestamp(int64(seconds))
+		}
+	}
+	return object, nil
+}
+
+// addStixReference adds a source related to an object as an external reference,
+// and keeps the source and the relation in a custom property.
+func addStixReference(object map[string]interface{}, source *model.Source, relation *model.Relation, inbound bool) error {
+	sourceFields, err := stixCustomFields(source)
+	if err != nil {
+		return err
+	}
+	relationFields, err := stixCustomFields(relation)
+	if err != nil {
+		return err
+	}
+
+	references, _ := object["external_references"].([]interface{})
+	referenced := false
+	for _, reference := range references {
+		referenced = referenced || reference.(map[string]interface{})["external_id"] == source.GetId()
+	}
+	if !referenced {
+		reference := map[string]interface{}{
+			"source_name": lo.CoalesceOrEmpty(source.GetName(), source.GetTitle(), source.GetUrl(), source.GetId()),
+			"external_id": source.GetId(),
+		}
+		setStixProperty(reference, "url", source.GetUrl())
+		setStixProperty(reference, "description", source.GetDescription())
+		object["external_references"] = append(references, reference)
+	}
+
+	entries, _ := object[StixSourcesProperty].([]interface{})
+	object[StixSourcesProperty] = append(entries, map[string]interface{}{
+		"source_id": source.GetId(),
+		"source":    sourceFields,
+		"relation":  relationFields,
+		"inbound":   inbound,
+	})
+	return nil
+}
+
+// stixCommonProperties returns the properties shared by all STIX objects. The
+// id is the one the object was imported with, if any, else derived from _id.
+func stixCommonProperties(stixType string, id string, message proto.Message) map[string]interface{} {
+	reflection := message.ProtoReflect()
+	field := func(name string) (protoreflect.Value, bool) {
+		fd := reflection.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil || !reflection.Has(fd) {
+			return protoreflect.Value{}, false
+		}
+		return reflection.Get(fd), true
+	}
+
+	stixId := StixId(stixType, id)
+	if attributes, ok := field("attributes"); ok {
+		imported, _ := attributes.Message().Interface().(*structpb.Struct).AsMap()[StixIdAttribute].(string)
+		if strings.HasPrefix(imported, stixType+"--") {
+			stixId = imported
+		}
+	}
+
+	modified := time.Now().Unix()
+	if updatedAt, ok := field("updated_at"); ok {
+		modified = updatedAt.Int()
+	}
+	created := modified
+	if createdAt, ok := field("created_at"); ok {
+		created = createdAt.Int()
+	}
+
+	object := map[string]interface{}{
+		"type":         stixType,
+		"spec_version": StixSpecVersion,
+		"id":           stixId,
+		"created":      stixTimestamp(created),
+		"modified":     stixTimestamp(modified),
+	}
+	if tags, ok := field("tags"); ok {
+		var labels []interface{}
+		for i := 0; i < tags.List().Len(); i++ {
+			labels = append(labels, tags.List().Get(i).String())
+		}
+		object["labels"] = labels
+	}
+	return object
+}
+
+// stixCustomFields returns the JSON form of a message without the fields
+// STIX objects hold elsewhere.
+func stixCustomFields(message proto.Message) (map[string]interface{}, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, name := range stixSkippedFields {
+		delete(fields, name)
+	}
+	return fields, nil
+}
+
+func stixTimestamp(seconds int64) string {
+	if seconds == 0 {
+		return ""
+	}
+	return time.Unix(seconds, 0).UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+func setStixProperty(object map[string]interface{}, name string, value string) {
+	if value != "" {
+		object[name] = value
+	}
+}
+
+func stixString(object map[string]interface{}, name string) string {
+	value, _ := object[name].(string)
+	return value
+}
+
+// =====================================================
+// Import
+// =====================================================
+
+// ReadStixBundle maps the objects of a STIX 2.1 bundle to import records and
+// passes them to fn, entities first. Line is the position of the object in
+// the bundle. Sources are read from the custom properties of ExportStix, or
+// from external references when referenceRelation names the relationship
+// connecting them to the object. Reading stops at the first error of fn.
+func (w *Worker) ReadStixBundle(bundle map[string]interface{}, referenceRelation string, fn func(record *ImportRecord) error) error {
+	if stixString(bundle, "type") != "bundle" {
+		return status.Errorf(codes.InvalidArgument, "not a STIX bundle")
+	}
+	objects, _ := bundle["objects"].([]interface{})
+
+	var entityRecords, referenceRecords, relationRecords []*ImportRecord
+	var relationObjects []map[string]interface{}
+	// _id of the entity each STIX object is imported as
+	ids := map[string]string{}
+	importedSources := map[string]bool{}
+
+	for i, item := range objects {
+		object, _ := item.(map[string]interface{})
+		objectId := stixString(object, "id")
+		record := func(r *ImportRecord) *ImportRecord {
+			r.Line, r.ObjectId = i+1, objectId
+			return r
+		}
+
+		if stixString(object, "type") == "relationship" {
+			relationRecords = append(relationRecords, record(&ImportRecord{}))
+			relationObjects = append(relationObjects, object)
+			continue
+		}
+
+		entityType, entity, err := w.stixEntity(object)
+		if err != nil {
+			entityRecords = append(entityRecords, record(&ImportRecord{Err: err}))
+			continue
+		}
+		ids[objectId] = entity.GetId()
+		wrapped, err := w.WrapEntityResponse(entity)
+		if err != nil {
+			return err
+		}
+		entityRecords = append(entityRecords, record(&ImportRecord{EntityType: entityType, Entity: wrapped}))
+
+		for _, r := range w.stixReferenceRecords(object, entity.GetId(), referenceRelation, importedSources) {
+			referenceRecords = append(referenceRecords, record(r))
+		}
+	}
+
+	// Relationships are mapped once the entities of all refs are known
+	for i, r := range relationRecords {
+		r.Relation, r.Err = stixRelation(relationObjects[i], ids)
+	}
+
+	for _, records := range [][]*ImportRecord{entityRecords, referenceRecords, relationRecords} {
+		for _, r := range records {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// stixEntity maps a STIX object to an entity with its key set.
+func (w *Worker) stixEntity(object map[string]interface{}) (string, ConcereteEntityCommon, error) {
+	objectId := stixString(object, "id")
+	stixType, stixUUID, ok := strings.Cut(objectId, "--")
+	if !ok || uuid.Validate(stixUUID) != nil {
+		return "", nil, fmt.Errorf("invalid STIX id: %q", objectId)
+	}
+
+	// Objects exported by ExportStix
+	if omnsightId := stixString(object, StixIdProperty); omnsightId != "" {
+		fields, _ := object[StixEntityProperty].(map[string]interface{})
+		entityType, key, err := w.dbClient.ParseDocID(omnsightId)
+		if err != nil || fields == nil {
+			return "", nil, fmt.Errorf("invalid %s or %s", StixIdProperty, StixEntityProperty)
+		}
+		entity, err := w.stixEntityFromFields(entityType, fields)
+		if err != nil {
+			return "", nil, err
+		}
+		w.SetEntityMeta(entity, omnsightId, key, "")
+		return entityType, entity, nil
+	}
+
+	var entityType string
+	fields := map[string]interface{}{}
+	set := func(field string, value interface{}) {
+		if value != nil && value != "" && value != int64(0) {
+			fields[field] = value
+		}
+	}
+	set("tags", object["labels"])
+
+	switch stixType {
+	case "incident", "report":
+		entityType = "event"
+		set("title", object["name"])
+		set("description", object["description"])
+		set("happened_at", parseStixTimestamp(object["published"]))
+	case "identity":
+		if stixString(object, "identity_class") == "individual" {
+			entityType = "person"
+			set("name", object["name"])
+			if roles, _ := object["roles"].([]interface{}); len(roles) > 0 {
+				set("role", roles[0])
+			}
+		} else {
+			entityType = "organization"
+			set("name", object["name"])
+			if class := stixString(object, "identity_class"); class != "organization" {
+				set("type", class)
+			}
+		}
+	case "infrastructure":
+		entityType = "website"
+		set("title", object["name"])
+		set("description", object["description"])
+		set("discovered_at", parseStixTimestamp(object["first_seen"]))
+		set("last_visited", parseStixTimestamp(object["last_seen"]))
+		// Infrastructure has no URL property, its name often is one
+		if parsed, err := url.Parse(stixString(object, "name")); err == nil && parsed.Scheme != "" && parsed.Host != "" {
+			set("url", parsed.String())
+		}
+	case "url":
+		entityType = "website"
+		set("url", object["value"])
+	default:
+		return "", nil, fmt.Errorf("unsupported STIX type: %s", stixType)
+	}
+
+	id := entityType + "/" + stixUUID
+	if objectId != StixId(stixTypes[entityType], id) {
+		fields["attributes"] = map[string]interface{}{StixIdAttribute: objectId}
+	}
+	entity, err := w.stixEntityFromFields(entityType, fields)
+	if err != nil {
+		return "", nil, err
+	}
+	w.SetEntityMeta(entity, id, stixUUID, "")
+	return entityType, entity, nil
+}
+
+func (w *Worker) stixEntityFromFields(entityType string, fields map[string]interface{}) (ConcereteEntityCommon, error) {
+	entity, err := w.CreateEntityStruct(entityType)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, entity.(proto.Message)); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", entityType, err)
+	}
+	return entity, nil
+}
+
+// stixReferenceRecords returns the sources of an object and the relations
+// connecting them to the entity it is imported as. Sources already imported
+// from another object are only connected.
+func (w *Worker) stixReferenceRecords(object map[string]interface{}, entityId string, referenceRelation string, importedSources map[string]bool) []*ImportRecord {
+	var records []*ImportRecord
+	addSource := func(sourceId string, fields map[string]interface{}) {
+		if importedSources[sourceId] {
+			return
+		}
+		importedSources[sourceId] = true
+		entity, err := w.stixEntityFromFields("source", fields)
+		if err != nil {
+			records = append(records, &ImportRecord{Err: err})
+			return
+		}
+		_, key, _ := strings.Cut(sourceId, "/")
+		w.SetEntityMeta(entity, sourceId, key, "")
+		wrapped, err := w.WrapEntityResponse(entity)
+		if err != nil {
+			records = append(records, &ImportRecord{Err: err})
+			return
+		}
+		records = append(records, &ImportRecord{EntityType: "source", Entity: wrapped})
+	}
+
+	// Sources exported by ExportStix
+	if entries, ok := object[StixSourcesProperty].([]interface{}); ok {
+		for _, item := range entries {
+			entry, _ := item.(map[string]interface{})
+			sourceId := stixString(entry, "source_id")
+			sourceFields, _ := entry["source"].(map[string]interface{})
+			relationFields, _ := entry["relation"].(map[string]interface{})
+			if !strings.HasPrefix(sourceId, "source/") || sourceFields == nil || relationFields == nil {
+				records = append(records, &ImportRecord{Err: fmt.Errorf("invalid %s entry", StixSourcesProperty)})
+				continue
+			}
+			addSource(sourceId, sourceFields)
+
+			relation, err := stixRelationFromFields(relationFields)
+			if err != nil {
+				records = append(records, &ImportRecord{Err: err})
+				continue
+			}
+			relation.From, relation.To = entityId, sourceId
+			if inbound, _ := entry["inbound"].(bool); inbound {
+				relation.From, relation.To = sourceId, entityId
+			}
+			records = append(records, &ImportRecord{Relation: relation})
+		}
+		return records
+	}
+
+	if referenceRelation == "" {
+		return nil
+	}
+	references, _ := object["external_references"].([]interface{})
+	for _, item := range references {
+		reference, _ := item.(map[string]interface{})
+		referenceUrl := stixString(reference, "url")
+		if referenceUrl == "" {
+			continue
+		}
+		// The same URL cited by several objects is imported as one source
+		sourceId := "source/" + uuid.NewSHA1(stixNamespace, []byte(referenceUrl)).String()
+		addSource(sourceId, map[string]interface{}{
+			"name":        stixString(reference, "source_name"),
+			"url":         referenceUrl,
+			"description": stixString(reference, "description"),
+		})
+		records = append(records, &ImportRecord{Relation: &model.Relation{
+			From: entityId,
+			To:   sourceId,
+			Name: referenceRelation,
+		}})
+	}
+	return records
+}
+
+// stixRelation maps a STIX relationship to a relation between the entities
+// its refs are imported as.
+func stixRelation(object map[string]interface{}, ids map[string]string) (*model.Relation, error) {
+	var relation *model.Relation
+	if fields, ok := object[StixRelationProperty].(map[string]interface{}); ok {
+		var err error
+		if relation, err = stixRelationFromFields(fields); err != nil {
+			return nil, err
+		}
+	} else {
+		relation = &model.Relation{
+			Name:  strings.ReplaceAll(stixString(object, "relationship_type"), "-", "_"),
+			Label: stixString(object, "description"),
+		}
+		if confidence, ok := object["confidence"].(float64); ok {
+			relation.Confidence = int32(confidence)
+		}
+	}
+
+	// Relationships exported by ExportStix keep their key as entities do,
+	// others are keyed by their STIX UUID and keep the STIX id to export it again
+	objectId := stixString(object, "id")
+	attributes := relation.GetAttributes().AsMap()
+	if omnsightId := stixString(object, StixIdProperty); omnsightId != "" {
+		_, key, ok := strings.Cut(omnsightId, "/")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid %s: %q", StixIdProperty, omnsightId)
+		}
+		relation.Key = key
+	} else {
+		stixUUID, ok := strings.CutPrefix(objectId, "relationship--")
+		if !ok || uuid.Validate(stixUUID) != nil {
+			return nil, fmt.Errorf("invalid STIX id: %q", objectId)
+		}
+		relation.Key = stixUUID
+		attributes[StixIdAttribute] = objectId
+	}
+	if _, ok := object[StixRelationProperty]; !ok {
+		for property, attribute := range map[string]string{"start_time": ValidFromAttribute, "stop_time": ValidToAttribute} {
+			if seconds := parseStixTimestamp(object[property]); seconds != 0 {
+				attributes[attribute] = float64(seconds)
+			}
+		}
+	}
+	var err error
+	if relation.Attributes, err = structpb.NewStruct(attributes); err != nil {
+		return nil, fmt.Errorf("invalid relationship attributes: %w", err)
+	}
+
+	for _, ref := range []struct {
+		property string
+		id       *string
+	}{{"source_ref", &relation.From}, {"target_ref", &relation.To}} {
+		id, ok := ids[stixString(object, ref.property)]
+		if !ok {
+			return nil, fmt.Errorf("%s %q is not an object of the bundle", ref.property, stixString(object, ref.property))
+		}
+		*ref.id = id
+	}
+	return relation, nil
+}
+
+func stixRelationFromFields(fields map[string]interface{}) (*model.Relation, error) {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	relation := &model.Relation{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, relation); err != nil {
+		return nil, fmt.Errorf("invalid relationship: %w", err)
+	}
+	return relation, nil
+}
+
+// parseStixTimestamp returns the unix seconds of a STIX timestamp, or 0 if
+// the value is not one.
+func parseStixTimestamp(value interface{}) int64 {
+	text, _ := value.(string)
+	parsed, err := time.Parse(time.RFC3339Nano, text)
+	if err != nil {
+		return 0
+	}
+	return parsed.Unix()
+}
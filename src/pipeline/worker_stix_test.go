@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// stixObject returns object as read from a bundle, with JSON numbers.
+func stixObject(t *testing.T, object map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	data, err := json.Marshal(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var read map[string]interface{}
+	if err := json.Unmarshal(data, &read); err != nil {
+		t.Fatal(err)
+	}
+	return read
+}
+
+func TestStixRelationKeepsKey(t *testing.T) {
+	attributes, err := structpb.NewStruct(map[string]interface{}{ValidFromAttribute: float64(1262304000)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	relation := &model.Relation{
+		Id:         "event_sponsor_organization/rel1",
+		Key:        "rel1",
+		From:       "event/e1",
+		To:         "organization/o1",
+		Name:       "sponsor",
+		Label:      "main sponsor",
+		Confidence: 70,
+		Attributes: attributes,
+	}
+	sourceRef, targetRef := StixId("incident", relation.From), StixId("identity", relation.To)
+	object, err := stixRelationshipObject(relation, sourceRef, targetRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Endpoints are imported under other keys
+	ids := map[string]string{sourceRef: "event/e2", targetRef: "organization/o2"}
+	imported, err := stixRelation(stixObject(t, object), ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported.GetKey() != "rel1" || imported.GetFrom() != "event/e2" || imported.GetTo() != "organization/o2" {
+		t.Errorf("got key %q from %q to %q", imported.GetKey(), imported.GetFrom(), imported.GetTo())
+	}
+	if imported.GetName() != "sponsor" || imported.GetLabel() != "main sponsor" || imported.GetConfidence() != 70 {
+		t.Errorf("got relation %v", imported)
+	}
+	if got := imported.GetAttributes().AsMap(); len(got) != 1 || got[ValidFromAttribute] != float64(1262304000) {
+		t.Errorf("got attributes %v", got)
+	}
+}
+
+func TestStixRelationKeyedByUUID(t *testing.T) {
+	stixUUID := uuid.NewString()
+	object := map[string]interface{}{
+		"type":              "relationship",
+		"id":                "relationship--" + stixUUID,
+		"relationship_type": "sponsored-by",
+		"source_ref":        "report--a",
+		"target_ref":        "identity--b",
+		"confidence":        40,
+		"start_time":        "2010-01-01T00:00:00Z",
+	}
+	ids := map[string]string{"report--a": "event/a", "identity--b": "organization/b"}
+	imported, err := stixRelation(stixObject(t, object), ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported.GetKey() != stixUUID || imported.GetName() != "sponsored_by" || imported.GetConfidence() != 40 {
+		t.Errorf("got relation %v", imported)
+	}
+	attributes := imported.GetAttributes().AsMap()
+	if attributes[StixIdAttribute] != "relationship--"+stixUUID || attributes[ValidFromAttribute] != float64(1262304000) {
+		t.Errorf("got attributes %v", attributes)
+	}
+
+	object["id"] = "relationship--1"
+	if _, err := stixRelation(stixObject(t, object), ids); err == nil {
+		t.Error("accepted an invalid STIX id")
+	}
+}
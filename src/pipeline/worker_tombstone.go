@@ -0,0 +1,102 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arangodb/go-driver"
+)
+
+const TombstoneCollection = "tombstone"
+
+// Tombstone redirects the _id of a merged entity to the entity it was merged into.
+type Tombstone struct {
+	Key        string `json:"_key,omitempty"`
+	EntityType string `json:"entity_type"`
+	MergedId   string `json:"merged_id"`
+	SurvivorId string `json:"survivor_id"`
+	MergedBy   string `json:"merged_by"`
+	MergedAt   int64  `json:"merged_at"`
+}
+
+func (w *Worker) RegisterTombstoneCollection(col driver.Collection) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.tombstones = col
+}
+
+// GetTombstoneCollection retrieves the registered tombstone collection.
+func (w *Worker) GetTombstoneCollection() (driver.Collection, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.tombstones == nil {
+		return nil, fmt.Errorf("tombstone collection not registered")
+	}
+	return w.tombstones, nil
+}
+
+// TombstoneKey returns the tombstone key of the entity with the given _id.
+func TombstoneKey(id string) string {
+	return strings.ReplaceAll(id, "/", ":")
+}
+
+// CreateTombstone records that the entity mergedId was merged into survivorId.
+// Tombstones already pointing at mergedId are redirected to survivorId so
+// that every old key resolves in a single lookup.
+func (w *Worker) CreateTombstone(ctx context.Context, entityType string, mergedId string, survivorId string, userId string) error {
+	query := `
+		FOR t IN tombstone
+		FILTER t.survivor_id == @mergedId
+		UPDATE t WITH { survivor_id: @survivorId } IN tombstone
+	`
+	cursor, err := w.dbClient.DB.Query(ctx, query, map[string]interface{}{
+		"mergedId":   mergedId,
+		"survivorId": survivorId,
+	})
+	if err != nil {
+		return err
+	}
+	cursor.Close()
+
+	upsertQuery := `
+		UPSERT { _key: @tombstone._key }
+		INSERT @tombstone
+		REPLACE @tombstone
+		IN tombstone
+	`
+	upsertCursor, err := w.dbClient.DB.Query(ctx, upsertQuery, map[string]interface{}{
+		"tombstone": Tombstone{
+			Key:        TombstoneKey(mergedId),
+			EntityType: entityType,
+			MergedId:   mergedId,
+			SurvivorId: survivorId,
+			MergedBy:   userId,
+			MergedAt:   time.Now().Unix(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	upsertCursor.Close()
+	return nil
+}
+
+// ResolveTombstone returns the _id of the entity that the entity with the
+// given _id was merged into, or false if it was never merged.
+func (w *Worker) ResolveTombstone(ctx context.Context, id string) (string, bool, error) {
+	col, err := w.GetTombstoneCollection()
+	if err != nil {
+		return "", false, err
+	}
+
+	var tombstone Tombstone
+	if _, err := col.ReadDocument(ctx, TombstoneKey(id), &tombstone); err != nil {
+		if driver.IsNotFoundGeneral(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return tombstone.SurvivorId, true, nil
+}
@@ -0,0 +1,56 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+	"github.com/sirupsen/logrus"
+)
+
+// RunTransaction runs fn inside a stream transaction writing to the given
+// collections. The transaction is committed if fn succeeds and aborted otherwise.
+func (w *Worker) RunTransaction(ctx context.Context, writeCollections []string, fn func(ctx context.Context) error) error {
+	return w.runTransaction(ctx, driver.TransactionCollections{Write: writeCollections}, fn)
+}
+
+// RunExclusiveTransaction is like RunTransaction but locks the collections
+// exclusively, so no other write to them interleaves with fn.
+func (w *Worker) RunExclusiveTransaction(ctx context.Context, exclusiveCollections []string, fn func(ctx context.Context) error) error {
+	return w.runTransaction(ctx, driver.TransactionCollections{Exclusive: exclusiveCollections}, fn)
+}
+
+// RunReadTransaction runs fn inside a stream transaction reading the given
+// collections, so every query of fn sees the same snapshot.
+func (w *Worker) RunReadTransaction(ctx context.Context, readCollections []string, fn func(ctx context.Context) error) error {
+	return w.runTransaction(ctx, driver.TransactionCollections{Read: readCollections}, fn)
+}
+
+func (w *Worker) runTransaction(ctx context.Context, collections driver.TransactionCollections, fn func(ctx context.Context) error) error {
+	trxId, err := w.dbClient.DB.BeginTransaction(ctx, collections, nil)
+	if err != nil {
+		logrus.WithContext(ctx).WithFields(logrus.Fields{
+			"collections": collections,
+			"error":       err,
+		}).Error("Failed to begin transaction")
+		return w.TranslateDBError(err, "", "")
+	}
+
+	if err := fn(driver.WithTransactionID(ctx, trxId)); err != nil {
+		if abortErr := w.dbClient.DB.AbortTransaction(ctx, trxId, nil); abortErr != nil {
+			logrus.WithContext(ctx).WithFields(logrus.Fields{
+				"transaction": trxId,
+				"error":       abortErr,
+			}).Error("Failed to abort transaction")
+		}
+		return err
+	}
+
+	if err := w.dbClient.DB.CommitTransaction(ctx, trxId, nil); err != nil {
+		logrus.WithContext(ctx).WithFields(logrus.Fields{
+			"transaction": trxId,
+			"error":       err,
+		}).Error("Failed to commit transaction")
+		return w.TranslateDBError(err, "", "")
+	}
+	return nil
+}
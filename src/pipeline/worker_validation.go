@@ -0,0 +1,140 @@
+package pipeline
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// Earliest accepted timestamp, 0001-01-01
+	MinTimestamp = -62135596800
+	// Events may be scheduled up to this far ahead
+	MaxEventLeadTime = 10 * 365 * 24 * time.Hour
+	// Allowed clock skew for timestamps that cannot be in the future
+	maxClockSkew = 24 * time.Hour
+)
+
+// FieldViolations collects the invalid fields of a request.
+type FieldViolations []*errdetails.BadRequest_FieldViolation
+
+func (v *FieldViolations) Add(field string, format string, args ...interface{}) {
+	*v = append(*v, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+// Prefix prepends prefix to the field path of every violation.
+func (v FieldViolations) Prefix(prefix string) FieldViolations {
+	for _, violation := range v {
+		violation.Field = prefix + "." + violation.Field
+	}
+	return v
+}
+
+// Err returns an InvalidArgument error with a BadRequest detail listing every
+// violation, or nil if there is none.
+func (v FieldViolations) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	var descriptions []string
+	for _, violation := range v {
+		descriptions = append(descriptions, violation.Field+": "+violation.Description)
+	}
+	return statusWithDetails(codes.InvalidArgument, "invalid request: "+strings.Join(descriptions, "; "), &errdetails.BadRequest{
+		FieldViolations: v,
+	})
+}
+
+// ValidateEntity checks the fields of the input entity of a request and
+// returns an InvalidArgument error listing every violation. Partial
+// entities, as sent to updates, may leave required fields empty.
+func (w *Worker) ValidateEntity(entity ConcereteEntityCommon, partial bool) error {
+	return w.EntityViolations(entity, partial).Prefix("entity").Err()
+}
+
+// EntityViolations lists the invalid fields of an input entity, with field
+// paths relative to the entity wrapper.
+func (w *Worker) EntityViolations(entity ConcereteEntityCommon, partial bool) FieldViolations {
+	var violations FieldViolations
+	now := time.Now()
+
+	switch v := entity.(type) {
+	case *model.Event:
+		checkTimestamp(&violations, "event.happened_at", v.GetHappenedAt(), now.Add(MaxEventLeadTime))
+		if location := v.GetLocation(); location != nil {
+			if lat := float64(location.GetLatitude()); math.IsNaN(lat) || lat < -90 || lat > 90 {
+				violations.Add("event.location.latitude", "must be between -90 and 90")
+			}
+			if lng := float64(location.GetLongitude()); math.IsNaN(lng) || lng < -180 || lng > 180 {
+				violations.Add("event.location.longitude", "must be between -180 and 180")
+			}
+			// Country codes are upper-cased by normalization
+			if code := location.GetCountryCode(); code != "" && !IsCountryCode(normalizeCountryCode(code)) {
+				violations.Add("event.location.country_code", "%q is not an ISO 3166-1 alpha-2 country code", code)
+			}
+		}
+	case *model.Source:
+		if v.GetUrl() != "" {
+			checkURL(&violations, "source.url", v.GetUrl())
+		}
+	case *model.Website:
+		if v.GetUrl() != "" || !partial {
+			checkURL(&violations, "website.url", v.GetUrl())
+		}
+		checkTimestamp(&violations, "website.founded_at", v.GetFoundedAt(), now.Add(maxClockSkew))
+		checkTimestamp(&violations, "website.discovered_at", v.GetDiscoveredAt(), now.Add(maxClockSkew))
+		checkTimestamp(&violations, "website.last_visited", v.GetLastVisited(), now.Add(maxClockSkew))
+	case *model.Person:
+		if v.GetName() == "" && !partial {
+			violations.Add("person.name", "is required")
+		}
+		checkTimestamp(&violations, "person.birth_date", v.GetBirthDate(), now.Add(maxClockSkew))
+	case *model.Organization:
+		if v.GetName() == "" && !partial {
+			violations.Add("organization.name", "is required")
+		}
+		checkTimestamp(&violations, "organization.founded_at", v.GetFoundedAt(), now.Add(maxClockSkew))
+		checkTimestamp(&violations, "organization.discovered_at", v.GetDiscoveredAt(), now.Add(maxClockSkew))
+		checkTimestamp(&violations, "organization.last_visited", v.GetLastVisited(), now.Add(maxClockSkew))
+	}
+	return violations
+}
+
+// IsCountryCode reports whether code is an ISO 3166-1 alpha-2 country code.
+func IsCountryCode(code string) bool {
+	if len(code) != 2 || strings.ToUpper(code) != code {
+		return false
+	}
+	region, err := language.ParseRegion(code)
+	return err == nil && region.IsCountry() && region.ISO3() != "ZZZ"
+}
+
+// checkTimestamp checks that a unix timestamp in seconds, if set, lies
+// between MinTimestamp and latest.
+func checkTimestamp(violations *FieldViolations, field string, timestamp int64, latest time.Time) {
+	if timestamp == 0 {
+		return
+	}
+	if timestamp < MinTimestamp {
+		violations.Add(field, "must not be before year 1")
+	} else if timestamp > latest.Unix() {
+		violations.Add(field, "must not be after %s", latest.UTC().Format(time.RFC3339))
+	}
+}
+
+func checkURL(violations *FieldViolations, field string, rawURL string) {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		violations.Add(field, "must be an absolute http or https URL")
+	}
+}
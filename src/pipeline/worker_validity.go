@@ -0,0 +1,70 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Relationships hold their validity interval as unix seconds in these
+// attributes. A missing bound leaves the interval open on that side.
+const (
+	ValidFromAttribute = "valid_from"
+	ValidToAttribute   = "valid_to"
+)
+
+// ValidityFilter keeps the edge bound to the variable e when its validity
+// interval overlaps the @validFrom..@validTo window set by AddValidityBindVars.
+func ValidityFilter(e string) string {
+	return fmt.Sprintf(`(
+					(@validTo == 0 OR NOT IS_NUMBER(%[1]s.attributes.%[2]s) OR %[1]s.attributes.%[2]s <= @validTo) AND
+					(@validFrom == 0 OR NOT IS_NUMBER(%[1]s.attributes.%[3]s) OR %[1]s.attributes.%[3]s >= @validFrom)
+				)`, e, ValidFromAttribute, ValidToAttribute)
+}
+
+// AddValidityBindVars adds the bind variables required by ValidityFilter.
+// asOf selects the edges valid at a single point in time and cannot be combined
+// with a window. Zero values leave the window unbounded.
+func (w *Worker) AddValidityBindVars(bindVars map[string]interface{}, asOf int64, windowStart int64, windowEnd int64) error {
+	if asOf < 0 || windowStart < 0 || windowEnd < 0 {
+		return status.Errorf(codes.InvalidArgument, "validity times must not be negative")
+	}
+	if asOf != 0 {
+		if windowStart != 0 || windowEnd != 0 {
+			return status.Errorf(codes.InvalidArgument, "as_of cannot be combined with a time window")
+		}
+		windowStart, windowEnd = asOf, asOf
+	}
+	if windowStart != 0 && windowEnd != 0 && windowStart > windowEnd {
+		return status.Errorf(codes.InvalidArgument, "window start must not be after window end")
+	}
+
+	bindVars["validFrom"] = windowStart
+	bindVars["validTo"] = windowEnd
+	return nil
+}
+
+// CheckValidityAttributes validates the validity interval stored in the
+// attributes of a relationship.
+func (w *Worker) CheckValidityAttributes(attributes map[string]interface{}) error {
+	bounds := make(map[string]float64)
+	for _, name := range []string{ValidFromAttribute, ValidToAttribute} {
+		value, ok := attributes[name]
+		if !ok || value == nil {
+			continue
+		}
+		number, ok := value.(float64)
+		if !ok || number < 0 {
+			return status.Errorf(codes.InvalidArgument, "attribute %s must be a unix timestamp in seconds", name)
+		}
+		bounds[name] = number
+	}
+
+	from, hasFrom := bounds[ValidFromAttribute]
+	to, hasTo := bounds[ValidToAttribute]
+	if hasFrom && hasTo && from > to {
+		return status.Errorf(codes.InvalidArgument, "attribute %s must not be after %s", ValidFromAttribute, ValidToAttribute)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package relationshipservice
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *RelationshipService) CreateRelationType(ctx context.Context, req *dapi.CreateRelationTypeRequest) (*dapi.CreateRelationTypeResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.Infof("[%s, %v] requests to create relation type: %s", userId, userRoles, req.GetRelationType().GetName())
+
+	if err := s.Pipeline.CheckAdminPermission(userRoles); err != nil {
+		return nil, err
+	}
+
+	relationType := req.GetRelationType()
+	if relationType == nil {
+		logger.Error("relation type is nil")
+		return nil, status.Errorf(codes.InvalidArgument, "Bad parameter")
+	}
+
+	relationName, err := s.Pipeline.NormalizeRelationName(relationType.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Pipeline.CheckRelationTypeDefinition(relationType); err != nil {
+		return nil, err
+	}
+
+	col, err := s.Pipeline.GetRelationTypeCollection()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal service error")
+	}
+
+	exists, err := col.DocumentExists(ctx, relationName)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"name":  relationName,
+		}).Error("failed to check relation type existence")
+		return nil, status.Errorf(codes.Internal, "Internal service error")
+	}
+	if exists {
+		return nil, status.Errorf(codes.AlreadyExists, "relation type %s already exists", relationName)
+	}
+
+	relationType.Id = ""
+	relationType.Key = relationName
+	relationType.Rev = ""
+	relationType.Name = relationName
+
+	data, err := json.Marshal(relationType)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal relation type")
+	}
+	var dataMap map[string]interface{}
+	if err := json.Unmarshal(data, &dataMap); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal relation type")
+	}
+
+	var createdRelationType dapi.RelationType
+	meta, err := s.Pipeline.CreateDocument(ctx, col, dataMap, &createdRelationType)
+	if err != nil {
+		return nil, err
+	}
+
+	createdRelationType.Id = meta.ID.String()
+	createdRelationType.Key = meta.Key
+	createdRelationType.Rev = meta.Rev
+	return &dapi.CreateRelationTypeResponse{RelationType: &createdRelationType}, nil
+}
@@ -2,13 +2,9 @@ package relationshipservice
 
 import (
 	"context"
-	"fmt"
-	"strings"
 
-	"github.com/arangodb/go-driver"
 	"github.com/omnsight/omndapi/gen/dapi/v1"
 	"github.com/omnsight/omndapi/src/utils"
-	"github.com/omnsight/omniscent-library/gen/model/v1"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -36,71 +32,22 @@ func (s *RelationshipService) CreateRelationship(ctx context.Context, req *dapi.
 		return nil, status.Errorf(codes.InvalidArgument, "Bad parameter")
 	}
 
-	if err := s.Pipeline.SetPermissions(relationship, userId, true); err != nil {
-		logger.WithFields(logrus.Fields{
-			"error": err,
-		}).Error("failed to set permissions")
-		return nil, err
-	}
-
-	fromColl, _, err := s.DBClient.ParseDocID(relationship.From)
-	if err != nil {
-		logger.WithFields(logrus.Fields{
-			"error": err,
-			"id":    relationship.From,
-		}).Error("failed to parse from entitity id")
-		return nil, status.Errorf(codes.InvalidArgument, "Bad parameter")
-	}
-
-	toColl, _, err := s.DBClient.ParseDocID(relationship.To)
-	if err != nil {
-		logger.WithFields(logrus.Fields{
-			"error": err,
-			"id":    relationship.From,
-		}).Error("failed to parse to entitity id")
-		return nil, status.Errorf(codes.InvalidArgument, "Bad parameter")
-	}
+	// Keys of created relationships are chosen by the database
+	relationship.Key = ""
 
-	// Process relation name
-	relationName := strings.ToLower(strings.ReplaceAll(relationship.Name, " ", "_"))
-	if len(relationName) == 0 {
-		logger.Error("invalid relation name")
-		return nil, status.Errorf(codes.InvalidArgument, "invalid relation name")
+	// Both endpoints must exist and be readable by the caller
+	if err := s.Pipeline.CheckRelationshipEndpoints(ctx, relationship, userId, userRoles); err != nil {
+		return nil, err
 	}
 
-	collectionName := fmt.Sprintf("%s_%s_%s", fromColl, relationName, toColl)
-
-	// Create the edge collection if it doesn't exist
-	collection, err := s.DBClient.GetCreateEdgeCollection(ctx, collectionName, driver.VertexConstraints{
-		From: []string{fromColl},
-		To:   []string{toColl},
-	}, driver.CreateEdgeCollectionOptions{})
+	collection, err := s.Pipeline.RelationshipCollection(ctx, relationship)
 	if err != nil {
-		logger.WithFields(logrus.Fields{
-			"error": err,
-			"name":  collectionName,
-		}).Errorf("failed to get or create collection %s", collectionName)
-		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+		return nil, err
 	}
 
-	// Create document in collection
-	relationship.Id = ""
-	relationship.Key = ""
-	relationship.Rev = ""
-
-	var createdRelationship model.Relation
-	ctxWithReturnNew := driver.WithReturnNew(ctx, &createdRelationship)
-	meta, err := collection.CreateDocument(ctxWithReturnNew, relationship)
+	createdRelationship, err := s.Pipeline.CreateRelationship(ctx, collection, relationship, userId)
 	if err != nil {
-		logger.WithFields(logrus.Fields{
-			"error": err,
-			"data":  relationship,
-		}).Error("failed to create relationship document")
-		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+		return nil, err
 	}
-
-	createdRelationship.Id = meta.ID.String()
-	createdRelationship.Key = meta.Key
-	createdRelationship.Rev = meta.Rev
-	return &dapi.CreateRelationshipResponse{Relationship: &createdRelationship}, nil
+	return &dapi.CreateRelationshipResponse{Relationship: createdRelationship}, nil
 }
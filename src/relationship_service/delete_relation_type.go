@@ -0,0 +1,42 @@
+package relationshipservice
+
+import (
+	"context"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeleteRelationType removes a relation type from the registry. Existing edges
+// are kept, but no new relationship of that type can be created.
+func (s *RelationshipService) DeleteRelationType(ctx context.Context, req *dapi.DeleteRelationTypeRequest) (*dapi.DeleteRelationTypeResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.Infof("[%s, %v] requests to delete relation type: %s", userId, userRoles, req.GetName())
+
+	if err := s.Pipeline.CheckAdminPermission(userRoles); err != nil {
+		return nil, err
+	}
+
+	col, err := s.Pipeline.GetRelationTypeCollection()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal service error")
+	}
+
+	var existingRelationType dapi.RelationType
+	if _, err := s.Pipeline.ReadDocument(ctx, col, req.GetName(), &existingRelationType); err != nil {
+		return nil, err
+	}
+
+	if err := s.Pipeline.DeleteDocument(ctx, col, req.GetName()); err != nil {
+		return nil, err
+	}
+
+	return &dapi.DeleteRelationTypeResponse{}, nil
+}
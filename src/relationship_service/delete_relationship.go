@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
 	"github.com/omnsight/omndapi/src/utils"
 	"github.com/omnsight/omniscent-library/gen/model/v1"
 	"github.com/sirupsen/logrus"
@@ -20,6 +21,10 @@ func (s *RelationshipService) DeleteRelationship(ctx context.Context, req *dapi.
 	logger := utils.GetLogger(ctx)
 	logger.Infof("[%s, %v] requests to delete relationship: %s/%s", userId, userRoles, req.GetCollection(), req.GetKey())
 
+	if _, _, _, ok := pipeline.ParseEdgeCollectionName(req.GetCollection()); !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is not a relationship collection", req.GetCollection())
+	}
+
 	col, err := s.DBClient.DB.Collection(ctx, req.GetCollection())
 	if err != nil {
 		logger.WithFields(logrus.Fields{
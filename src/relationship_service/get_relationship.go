@@ -0,0 +1,58 @@
+package relationshipservice
+
+import (
+	"context"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *RelationshipService) GetRelationship(ctx context.Context, req *dapi.GetRelationshipRequest) (*dapi.GetRelationshipResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.Infof("[%s, %v] requests to get relationship: %s/%s", userId, userRoles, req.GetCollection(), req.GetKey())
+
+	if _, _, _, ok := pipeline.ParseEdgeCollectionName(req.GetCollection()); !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is not a relationship collection", req.GetCollection())
+	}
+
+	col, err := s.DBClient.DB.Collection(ctx, req.GetCollection())
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":      err,
+			"collection": req.GetCollection(),
+		}).Error("failed to get collection")
+		return nil, status.Errorf(codes.NotFound, "Collection not found")
+	}
+
+	var relationship model.Relation
+	meta, err := s.Pipeline.ReadDocument(ctx, col, req.GetKey(), &relationship)
+	if err != nil {
+		return nil, err
+	}
+
+	// The relationship and both of its endpoints must be readable
+	if err := s.Pipeline.CheckReadPermission(ctx, &relationship, userId, userRoles); err != nil {
+		return nil, err
+	}
+	if _, err := s.Pipeline.ResolveEndpoint(ctx, relationship.From, userId, userRoles); err != nil {
+		return nil, err
+	}
+	if _, err := s.Pipeline.ResolveEndpoint(ctx, relationship.To, userId, userRoles); err != nil {
+		return nil, err
+	}
+
+	relationship.Id = meta.ID.String()
+	relationship.Key = meta.Key
+	relationship.Rev = meta.Rev
+	return &dapi.GetRelationshipResponse{Relationship: &relationship}, nil
+}
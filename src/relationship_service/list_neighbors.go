@@ -0,0 +1,109 @@
+package relationshipservice
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type neighborResult struct {
+	Direction    string                `json:"direction"`
+	Relationship model.Relation        `json:"relationship"`
+	Entity       pipeline.EntityResult `json:"entity"`
+}
+
+func (s *RelationshipService) ListNeighbors(ctx context.Context, req *dapi.ListNeighborsRequest) (*dapi.ListNeighborsResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.Infof("[%s, %v] requests to list neighbors of %s", userId, userRoles, req.GetEntityId())
+
+	if _, err := s.Pipeline.ResolveEndpoint(ctx, req.GetEntityId(), userId, userRoles); err != nil {
+		return nil, err
+	}
+
+	direction, err := s.Pipeline.TraversalDirection(req.GetDirection())
+	if err != nil {
+		return nil, err
+	}
+
+	bindVars := map[string]interface{}{
+		"startNode": req.GetEntityId(),
+		"userId":    userId,
+		"userRoles": userRoles,
+	}
+	if err := s.Pipeline.AddRelationFilterBindVars(bindVars, req.GetFilter()); err != nil {
+		return nil, err
+	}
+	s.Pipeline.AddGrantBindVars(ctx, bindVars, userId, userRoles)
+	s.Pipeline.AddPageBindVars(bindVars, req.GetOffset(), req.GetLimit())
+
+	query := pipeline.GrantedIdsQuery + `
+		FOR v, e IN 1..1 ` + direction + ` @startNode GRAPH @graphName
+			FILTER ` + pipeline.RelationFilterQuery("e") + `
+			FILTER ` + pipeline.ReadFilter("e") + `
+			FILTER ` + pipeline.ReadFilter("v") + `
+			SORT e.created_at DESC, e._id ASC
+			LIMIT @offset, @limit
+			RETURN {
+				direction: e._from == @startNode ? "outbound" : "inbound",
+				relationship: e,
+				entity: { type: PARSE_IDENTIFIER(v._id).collection, data: v }
+			}
+	`
+
+	cursor, err := s.DBClient.DB.Query(driver.WithQueryFullCount(ctx), query, bindVars)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"query": query,
+			"vars":  bindVars,
+		}).Error("failed to execute AQL query")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	defer cursor.Close()
+
+	var neighbors []*dapi.Neighbor
+	for {
+		var result neighborResult
+		if _, err := cursor.ReadDocument(ctx, &result); err != nil {
+			if driver.IsNoMoreDocuments(err) {
+				break
+			}
+			logger.WithFields(logrus.Fields{
+				"error": err,
+			}).Error("failed to read query result")
+			return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+		}
+
+		entity, _, err := s.Pipeline.DecodeEntity(result.Entity)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"type":  result.Entity.Type,
+				"error": err,
+			}).Error("failed to unmarshal entity data")
+			continue
+		}
+
+		neighbors = append(neighbors, &dapi.Neighbor{
+			Relationship: &result.Relationship,
+			Entity:       entity,
+			Direction:    result.Direction,
+		})
+	}
+
+	return &dapi.ListNeighborsResponse{
+		Neighbors:  neighbors,
+		TotalCount: cursor.Statistics().FullCount(),
+	}, nil
+}
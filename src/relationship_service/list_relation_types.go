@@ -0,0 +1,55 @@
+package relationshipservice
+
+import (
+	"context"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *RelationshipService) ListRelationTypes(ctx context.Context, req *dapi.ListRelationTypesRequest) (*dapi.ListRelationTypesResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.Infof("[%s, %v] requests to list relation types", userId, userRoles)
+
+	query := `
+		FOR t IN relation_type
+		SORT t.name ASC
+		RETURN t
+	`
+
+	cursor, err := s.DBClient.DB.Query(ctx, query, nil)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"query": query,
+		}).Error("failed to execute AQL query")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	defer cursor.Close()
+
+	var relationTypes []*dapi.RelationType
+	for {
+		var relationType dapi.RelationType
+		if _, err := cursor.ReadDocument(ctx, &relationType); err != nil {
+			if driver.IsNoMoreDocuments(err) {
+				break
+			}
+			logger.WithFields(logrus.Fields{
+				"error": err,
+			}).Error("failed to read query result")
+			return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+		}
+		relationTypes = append(relationTypes, &relationType)
+	}
+
+	return &dapi.ListRelationTypesResponse{RelationTypes: relationTypes}, nil
+}
@@ -0,0 +1,127 @@
+package relationshipservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *RelationshipService) ListRelationships(ctx context.Context, req *dapi.ListRelationshipsRequest) (*dapi.ListRelationshipsResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.WithFields(logrus.Fields{
+		"from": req.GetFrom(),
+		"to":   req.GetTo(),
+	}).Infof("[%s, %v] requests to list relationships", userId, userRoles)
+
+	// Edges are only looked up through the _from and _to indexes
+	if req.GetFrom() == "" && req.GetTo() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "from or to is required")
+	}
+
+	bindVars := map[string]interface{}{
+		"from":      req.GetFrom(),
+		"to":        req.GetTo(),
+		"userId":    userId,
+		"userRoles": userRoles,
+	}
+	if err := s.Pipeline.AddRelationFilterBindVars(bindVars, req.GetFilter()); err != nil {
+		return nil, err
+	}
+	s.Pipeline.AddGrantBindVars(ctx, bindVars, userId, userRoles)
+	s.Pipeline.AddPageBindVars(bindVars, req.GetOffset(), req.GetLimit())
+
+	// Only scan the edge collections that can hold matching relationships
+	var fromType, toType string
+	if req.GetFrom() != "" {
+		if fromType, _, err = s.DBClient.ParseDocID(req.GetFrom()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid entity id: %s", req.GetFrom())
+		}
+	}
+	if req.GetTo() != "" {
+		if toType, _, err = s.DBClient.ParseDocID(req.GetTo()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid entity id: %s", req.GetTo())
+		}
+	}
+
+	relationName, _ := bindVars["relationName"].(string)
+	collectionNames, err := s.Pipeline.ListEdgeCollections(ctx, fromType, relationName, toType)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to list edge collections")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	if len(collectionNames) == 0 {
+		return &dapi.ListRelationshipsResponse{}, nil
+	}
+
+	var subqueries []string
+	for i, name := range collectionNames {
+		bindVars[fmt.Sprintf("@col%d", i)] = name
+		subqueries = append(subqueries, fmt.Sprintf(`(
+				FOR e IN @@col%d
+				FILTER (@from == "" OR e._from == @from)
+				FILTER (@to == "" OR e._to == @to)
+				RETURN e
+			)`, i))
+	}
+
+	query := pipeline.GrantedIdsQuery + `
+		FOR e IN FLATTEN([` + strings.Join(subqueries, ", ") + `])
+			FILTER ` + pipeline.RelationFilterQuery("e") + `
+			FILTER ` + pipeline.ReadFilter("e") + `
+			LET from_doc = DOCUMENT(e._from)
+			LET to_doc = DOCUMENT(e._to)
+			FILTER from_doc != null AND to_doc != null
+			FILTER ` + pipeline.ReadFilter("from_doc") + `
+			FILTER ` + pipeline.ReadFilter("to_doc") + `
+			SORT e.created_at DESC, e._id ASC
+			LIMIT @offset, @limit
+			RETURN e
+	`
+
+	cursor, err := s.DBClient.DB.Query(driver.WithQueryFullCount(ctx), query, bindVars)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"query": query,
+			"vars":  bindVars,
+		}).Error("failed to execute AQL query")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	defer cursor.Close()
+
+	var relationships []*model.Relation
+	for {
+		var relationship model.Relation
+		if _, err := cursor.ReadDocument(ctx, &relationship); err != nil {
+			if driver.IsNoMoreDocuments(err) {
+				break
+			}
+			logger.WithFields(logrus.Fields{
+				"error": err,
+			}).Error("failed to read query result")
+			return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+		}
+		relationships = append(relationships, &relationship)
+	}
+
+	return &dapi.ListRelationshipsResponse{
+		Relationships: relationships,
+		TotalCount:    cursor.Statistics().FullCount(),
+	}, nil
+}
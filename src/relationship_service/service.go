@@ -1,7 +1,10 @@
 package relationshipservice
 
 import (
+	"context"
+
 	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/entity_service/collections"
 	"github.com/omnsight/omndapi/src/pipeline"
 	"github.com/omnsight/omndapi/src/utils"
 )
@@ -16,7 +19,11 @@ type RelationshipService struct {
 func NewRelationshipService(client *utils.ArangoDBClient) (*RelationshipService, error) {
 	service := &RelationshipService{
 		DBClient: client,
-		Pipeline: pipeline.NewWorker(),
+		Pipeline: pipeline.NewWorker(client),
+	}
+
+	if err := collections.RegisterRelationType(context.Background(), client, service.Pipeline); err != nil {
+		return nil, err
 	}
 
 	return service, nil
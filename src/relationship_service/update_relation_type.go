@@ -0,0 +1,81 @@
+package relationshipservice
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *RelationshipService) UpdateRelationType(ctx context.Context, req *dapi.UpdateRelationTypeRequest) (*dapi.UpdateRelationTypeResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.Infof("[%s, %v] requests to update relation type: %s", userId, userRoles, req.GetName())
+
+	if err := s.Pipeline.CheckAdminPermission(userRoles); err != nil {
+		return nil, err
+	}
+
+	relationType := req.GetRelationType()
+	if relationType == nil {
+		logger.Error("relation type is nil")
+		return nil, status.Errorf(codes.InvalidArgument, "Bad parameter")
+	}
+
+	if err := s.Pipeline.CheckRelationTypeDefinition(relationType); err != nil {
+		return nil, err
+	}
+
+	// Existing edges depend on the name, it cannot be changed
+	existingRelationType, err := s.Pipeline.GetRelationType(ctx, req.GetName())
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, status.Errorf(codes.NotFound, "relation type %s not found", req.GetName())
+		}
+		return nil, err
+	}
+
+	col, err := s.Pipeline.GetRelationTypeCollection()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal service error")
+	}
+
+	data, err := json.Marshal(relationType)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal update data")
+	}
+	var dataMap map[string]interface{}
+	if err := json.Unmarshal(data, &dataMap); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal update data")
+	}
+
+	delete(dataMap, "_id")
+	delete(dataMap, "_key")
+	delete(dataMap, "_rev")
+	dataMap["name"] = existingRelationType.GetName()
+	// Updates merge objects, so explicitly reset fields that were cleared
+	dataMap["from_types"] = relationType.GetFromTypes()
+	dataMap["to_types"] = relationType.GetToTypes()
+	dataMap["directed"] = relationType.GetDirected()
+	dataMap["inverse_name"] = relationType.GetInverseName()
+	dataMap["label"] = relationType.GetLabel()
+	dataMap["description"] = relationType.GetDescription()
+
+	var updatedRelationType dapi.RelationType
+	meta, err := s.Pipeline.UpdateDocument(ctx, col, existingRelationType.GetKey(), dataMap, &updatedRelationType)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedRelationType.Id = meta.ID.String()
+	updatedRelationType.Key = meta.Key
+	updatedRelationType.Rev = meta.Rev
+	return &dapi.UpdateRelationTypeResponse{RelationType: &updatedRelationType}, nil
+}
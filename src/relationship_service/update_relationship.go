@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/arangodb/go-driver"
 	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
 	"github.com/omnsight/omndapi/src/utils"
 	"github.com/omnsight/omniscent-library/gen/model/v1"
 	"github.com/sirupsen/logrus"
@@ -21,6 +23,10 @@ func (s *RelationshipService) UpdateRelationship(ctx context.Context, req *dapi.
 	logger := utils.GetLogger(ctx)
 	logger.Infof("[%s, %v] requests to update relationship: %s/%s", userId, userRoles, req.GetCollection(), req.GetKey())
 
+	if _, _, _, ok := pipeline.ParseEdgeCollectionName(req.GetCollection()); !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is not a relationship collection", req.GetCollection())
+	}
+
 	col, err := s.DBClient.DB.Collection(ctx, req.GetCollection())
 	if err != nil {
 		logger.WithFields(logrus.Fields{
@@ -40,10 +46,54 @@ func (s *RelationshipService) UpdateRelationship(ctx context.Context, req *dapi.
 	}
 
 	relationshipToUpdate := req.GetRelationship()
+	if relationshipToUpdate == nil {
+		logger.Error("relationship is nil")
+		return nil, status.Errorf(codes.InvalidArgument, "Bad parameter")
+	}
+
 	if err := s.Pipeline.SetPermissions(relationshipToUpdate, userId, false); err != nil {
 		return nil, err
 	}
 
+	// =====================================================
+	// Resolve endpoints and relation name
+	// =====================================================
+	from := existingRelationship.From
+	if relationshipToUpdate.From != "" {
+		from = relationshipToUpdate.From
+	}
+	to := existingRelationship.To
+	if relationshipToUpdate.To != "" {
+		to = relationshipToUpdate.To
+	}
+	relationName := existingRelationship.Name
+	if relationshipToUpdate.Name != "" {
+		if relationName, err = s.Pipeline.NormalizeRelationName(relationshipToUpdate.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	targetCollection := req.GetCollection()
+	var fromColl, toColl string
+	if from != existingRelationship.From || to != existingRelationship.To || relationName != existingRelationship.Name {
+		if fromColl, err = s.Pipeline.ResolveEndpoint(ctx, from, userId, userRoles); err != nil {
+			return nil, err
+		}
+		if toColl, err = s.Pipeline.ResolveEndpoint(ctx, to, userId, userRoles); err != nil {
+			return nil, err
+		}
+		if _, err := s.Pipeline.ValidateRelationType(ctx, relationName, fromColl, toColl); err != nil {
+			return nil, err
+		}
+		targetCollection = pipeline.EdgeCollectionName(fromColl, relationName, toColl)
+	}
+
+	// Attributes are merged into the stored ones, validate the resulting interval
+	attributes := s.Pipeline.MergeDocumentData(existingRelationship.GetAttributes().AsMap(), relationshipToUpdate.GetAttributes().AsMap())
+	if err := s.Pipeline.CheckValidityAttributes(attributes); err != nil {
+		return nil, err
+	}
+
 	data, err := json.Marshal(relationshipToUpdate)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to marshal update data")
@@ -56,9 +106,22 @@ func (s *RelationshipService) UpdateRelationship(ctx context.Context, req *dapi.
 	delete(dataMap, "_id")
 	delete(dataMap, "_key")
 	delete(dataMap, "_rev")
+	dataMap["_from"] = from
+	dataMap["_to"] = to
+	dataMap["name"] = relationName
+	pipeline.SetPathWeight(dataMap)
+	s.Pipeline.SetAuditFields(dataMap, userId, false)
 
+	// =====================================================
+	// Write into db
+	// =====================================================
 	var updatedRelationship model.Relation
-	meta, err := s.Pipeline.UpdateDocument(ctx, col, req.GetKey(), dataMap, &updatedRelationship)
+	var meta driver.DocumentMeta
+	if targetCollection == req.GetCollection() {
+		meta, err = s.Pipeline.UpdateDocument(ctx, col, req.GetKey(), dataMap, &updatedRelationship)
+	} else {
+		meta, err = s.moveRelationship(ctx, col, req.GetKey(), targetCollection, fromColl, toColl, dataMap, &updatedRelationship)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -68,3 +131,48 @@ func (s *RelationshipService) UpdateRelationship(ctx context.Context, req *dapi.
 	updatedRelationship.Rev = meta.Rev
 	return &dapi.UpdateRelationshipResponse{Relationship: &updatedRelationship}, nil
 }
+
+// moveRelationship moves an edge into the edge collection matching its new
+// endpoints and name, keeping its key, owner, ACL and attributes.
+func (s *RelationshipService) moveRelationship(ctx context.Context, col driver.Collection, key string, targetCollection string, fromColl string, toColl string, dataMap map[string]interface{}, resultStruct interface{}) (driver.DocumentMeta, error) {
+	logger := utils.GetLogger(ctx)
+
+	targetCol, err := s.DBClient.GetCreateEdgeCollection(ctx, targetCollection, driver.VertexConstraints{
+		From: []string{fromColl},
+		To:   []string{toColl},
+	}, driver.CreateEdgeCollectionOptions{})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"name":  targetCollection,
+		}).Errorf("failed to get or create collection %s", targetCollection)
+		return driver.DocumentMeta{}, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	if err := s.Pipeline.ApplyRelationSchema(ctx, targetCol); err != nil {
+		return driver.DocumentMeta{}, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	var meta driver.DocumentMeta
+	err = s.Pipeline.RunTransaction(ctx, []string{col.Name(), targetCollection}, func(ctx context.Context) error {
+		var existingMap map[string]interface{}
+		if _, err := s.Pipeline.ReadDocument(ctx, col, key, &existingMap); err != nil {
+			return err
+		}
+
+		movedMap := s.Pipeline.MergeDocumentData(existingMap, dataMap)
+		delete(movedMap, "_id")
+		delete(movedMap, "_rev")
+		movedMap["_key"] = key
+
+		if meta, err = s.Pipeline.CreateDocument(ctx, targetCol, movedMap, resultStruct); err != nil {
+			return err
+		}
+		return s.Pipeline.DeleteDocument(ctx, col, key)
+	})
+	if err != nil {
+		return driver.DocumentMeta{}, err
+	}
+
+	logger.Infof("moved relationship %s/%s to %s", col.Name(), key, targetCollection)
+	return meta, nil
+}
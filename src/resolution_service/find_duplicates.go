@@ -0,0 +1,206 @@
+package resolutionservice
+
+import (
+	"context"
+	"slices"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	DefaultClusterLimit = 20
+	MaxClusterLimit     = 100
+	// Maximum number of candidate pairs clustered per request
+	maxCandidatePairs = 5000
+)
+
+type candidateResult struct {
+	Pair     *dapi.DuplicatePair     `json:"pair"`
+	Entities []pipeline.EntityResult `json:"entities"`
+}
+
+func (s *ResolutionService) FindDuplicates(ctx context.Context, req *dapi.FindDuplicatesRequest) (*dapi.FindDuplicatesResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.Infof("[%s, %v] requests to find duplicate %s entities", userId, userRoles, req.GetEntityType())
+
+	// =====================================================
+	// Validate request
+	// =====================================================
+	if !slices.Contains(pipeline.DuplicateEntityTypes, req.GetEntityType()) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is not scanned for duplicates", req.GetEntityType())
+	}
+
+	minScore := req.GetMinScore()
+	if minScore < 0 || minScore > 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "min score must be between 0 and 1")
+	}
+	minScore = max(minScore, pipeline.MinDuplicateScore)
+
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = DefaultClusterLimit
+	}
+	limit = min(limit, MaxClusterLimit)
+
+	if req.GetEntityId() != "" {
+		entityType, err := s.Pipeline.ResolveEndpoint(ctx, req.GetEntityId(), userId, userRoles)
+		if err != nil {
+			return nil, err
+		}
+		if entityType != req.GetEntityType() {
+			return nil, status.Errorf(codes.InvalidArgument, "entity %s is not a %s", req.GetEntityId(), req.GetEntityType())
+		}
+	}
+
+	if req.GetRescan() {
+		if err := s.Pipeline.CheckAdminPermission(userRoles); err != nil {
+			return nil, err
+		}
+		if _, err := s.Pipeline.ScanDuplicates(ctx, req.GetEntityType()); err != nil {
+			logger.WithFields(logrus.Fields{
+				"error":       err,
+				"entity_type": req.GetEntityType(),
+			}).Error("failed to scan for duplicates")
+			return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+		}
+	}
+
+	// =====================================================
+	// Read candidate pairs the caller can see both ends of
+	// =====================================================
+	query := pipeline.GrantedIdsQuery + `
+		FOR c IN duplicate_candidate
+		FILTER c.entity_type == @entityType AND c.score >= @minScore
+		SORT c.score DESC
+		LET a = DOCUMENT(c.entity_a)
+		LET b = DOCUMENT(c.entity_b)
+		FILTER a != null AND b != null
+		FILTER ` + pipeline.ReadFilter("a") + `
+		FILTER ` + pipeline.ReadFilter("b") + `
+		LIMIT @maxPairs
+		RETURN {
+			pair: c,
+			entities: [
+				{ type: @entityType, data: a },
+				{ type: @entityType, data: b }
+			]
+		}
+	`
+	bindVars := map[string]interface{}{
+		"entityType": req.GetEntityType(),
+		"minScore":   minScore,
+		"maxPairs":   maxCandidatePairs,
+		"userId":     userId,
+		"userRoles":  userRoles,
+	}
+	s.Pipeline.AddGrantBindVars(ctx, bindVars, userId, userRoles)
+
+	cursor, err := s.DBClient.DB.Query(ctx, query, bindVars)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"query": query,
+			"vars":  bindVars,
+		}).Error("failed to execute AQL query")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	defer cursor.Close()
+
+	var pairs []*dapi.DuplicatePair
+	entities := make(map[string]*model.Entity)
+	for {
+		var result candidateResult
+		if _, err := cursor.ReadDocument(ctx, &result); err != nil {
+			if driver.IsNoMoreDocuments(err) {
+				break
+			}
+			logger.WithFields(logrus.Fields{
+				"error": err,
+			}).Error("failed to read query result")
+			return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+		}
+
+		for _, er := range result.Entities {
+			entity, id, err := s.Pipeline.DecodeEntity(er)
+			if err != nil {
+				logger.WithFields(logrus.Fields{
+					"type":  er.Type,
+					"error": err,
+				}).Error("failed to unmarshal entity data")
+				return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+			}
+			entities[id] = entity
+		}
+		pairs = append(pairs, result.Pair)
+	}
+
+	// =====================================================
+	// Group pairs into clusters
+	// =====================================================
+	parents := make(map[string]string)
+	var find func(id string) string
+	find = func(id string) string {
+		parent, ok := parents[id]
+		if !ok || parent == id {
+			return id
+		}
+		root := find(parent)
+		parents[id] = root
+		return root
+	}
+	for _, pair := range pairs {
+		if a, b := find(pair.GetEntityA()), find(pair.GetEntityB()); a != b {
+			parents[b] = a
+		}
+	}
+
+	clustersByRoot := make(map[string]*dapi.DuplicateCluster)
+	var clusters []*dapi.DuplicateCluster
+	for _, pair := range pairs {
+		root := find(pair.GetEntityA())
+		cluster, ok := clustersByRoot[root]
+		if !ok {
+			// Pairs are sorted by score, so the first pair holds the best score
+			cluster = &dapi.DuplicateCluster{Score: pair.GetScore()}
+			clustersByRoot[root] = cluster
+			clusters = append(clusters, cluster)
+		}
+		cluster.Pairs = append(cluster.Pairs, pair)
+	}
+
+	var result []*dapi.DuplicateCluster
+	for _, cluster := range clusters {
+		var ids []string
+		for _, pair := range cluster.Pairs {
+			ids = append(ids, pair.GetEntityA(), pair.GetEntityB())
+		}
+		slices.Sort(ids)
+		ids = slices.Compact(ids)
+
+		if req.GetEntityId() != "" && !slices.Contains(ids, req.GetEntityId()) {
+			continue
+		}
+		for _, id := range ids {
+			cluster.Entities = append(cluster.Entities, entities[id])
+		}
+
+		result = append(result, cluster)
+		if len(result) == limit {
+			break
+		}
+	}
+
+	return &dapi.FindDuplicatesResponse{Clusters: result}, nil
+}
@@ -0,0 +1,299 @@
+package resolutionservice
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ResolutionService) MergeEntities(ctx context.Context, req *dapi.MergeEntitiesRequest) (*dapi.MergeEntitiesResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.Infof("[%s, %v] requests to merge %s entities %v into %s", userId, userRoles, req.GetEntityType(), req.GetMergedKeys(), req.GetSurvivorKey())
+
+	// =====================================================
+	// Validate request
+	// =====================================================
+	col, err := s.Pipeline.GetCollection(req.GetEntityType())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid entity type: %v", err)
+	}
+
+	if req.GetSurvivorKey() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "survivor key is required")
+	}
+	if len(req.GetMergedKeys()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one merged key is required")
+	}
+	if len(req.GetMergedKeys()) > pipeline.MaxMergedEntities {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d entities can be merged at once", pipeline.MaxMergedEntities)
+	}
+	for i, key := range req.GetMergedKeys() {
+		if key == "" || key == req.GetSurvivorKey() || slices.Contains(req.GetMergedKeys()[:i], key) {
+			return nil, status.Errorf(codes.InvalidArgument, "merged keys must be distinct and differ from the survivor key")
+		}
+	}
+
+	rule, err := s.Pipeline.CheckMergeRule(req.GetConflictRule())
+	if err != nil {
+		return nil, err
+	}
+
+	// Documents are ordered from highest to lowest priority
+	keys := append([]string{req.GetSurvivorKey()}, req.GetMergedKeys()...)
+	if rule == pipeline.MergeRuleMerged {
+		keys = append(slices.Clone(req.GetMergedKeys()), req.GetSurvivorKey())
+	}
+
+	fieldSources := make(map[string]int)
+	for field, key := range req.GetFieldSources() {
+		index := slices.Index(keys, key)
+		if index < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "field source %s of %s is not a merged entity", key, field)
+		}
+		fieldSources[field] = index
+	}
+
+	// =====================================================
+	// Read entities and check permission
+	// =====================================================
+	docs := make([]map[string]interface{}, len(keys))
+	for i, key := range keys {
+		if _, err := s.Pipeline.ReadDocument(ctx, col, key, &docs[i]); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil, status.Errorf(codes.NotFound, "entity %s/%s not found", req.GetEntityType(), key)
+			}
+			return nil, err
+		}
+
+		entity, err := s.decodeEntity(req.GetEntityType(), docs[i])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"error": err,
+				"key":   key,
+			}).Error("failed to decode entity")
+			return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+		}
+
+		// The survivor is updated, the merged entities are removed
+		if key == req.GetSurvivorKey() {
+			if err := s.Pipeline.CheckWritePermission(entity, userId, userRoles); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := s.Pipeline.CheckDeletePermission(entity, userId); err != nil {
+			return nil, err
+		}
+	}
+
+	var mergedIds []string
+	for _, key := range req.GetMergedKeys() {
+		mergedIds = append(mergedIds, req.GetEntityType()+"/"+key)
+	}
+
+	// Every relationship moved to the survivor is rewritten
+	for _, id := range mergedIds {
+		relations, err := s.Pipeline.IncidentRelationships(ctx, id)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"error": err,
+				"id":    id,
+			}).Error("failed to list relationships")
+			return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+		}
+		for _, relation := range relations {
+			if err := s.Pipeline.CheckWritePermission(relation, userId, userRoles); err != nil {
+				return nil, status.Errorf(codes.PermissionDenied, "Access denied: cannot move relationship %s", relation.GetId())
+			}
+		}
+	}
+
+	// =====================================================
+	// Merge fields
+	// =====================================================
+	merged := s.Pipeline.MergeEntityData(docs, fieldSources)
+	if req.GetEntityType() == "person" {
+		// Names of the merged persons are kept as aliases
+		aliases, _ := merged["aliases"].([]interface{})
+		for _, doc := range docs {
+			if name, ok := doc["name"].(string); ok && name != "" && name != merged["name"] && !slices.Contains(aliases, interface{}(name)) {
+				aliases = append(aliases, name)
+			}
+		}
+		merged["aliases"] = aliases
+	}
+
+	mergedEntity, err := s.decodeEntity(req.GetEntityType(), merged)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to decode merged entity")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+
+	dataMap, err := s.Pipeline.SetAdditionalFields(ctx, mergedEntity)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to set additional fields")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	delete(dataMap, "_id")
+	delete(dataMap, "_key")
+	delete(dataMap, "_rev")
+	delete(dataMap, "owner")
+	s.Pipeline.SetAuditFields(dataMap, userId, false)
+
+	// =====================================================
+	// Write into db
+	// =====================================================
+	survivorId := req.GetEntityType() + "/" + req.GetSurvivorKey()
+
+	fromCollections, err := s.Pipeline.ListEdgeCollections(ctx, req.GetEntityType(), "", "")
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to list edge collections")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	toCollections, err := s.Pipeline.ListEdgeCollections(ctx, "", "", req.GetEntityType())
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+		}).Error("failed to list edge collections")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	edgeCollections := append(fromCollections, toCollections...)
+	slices.Sort(edgeCollections)
+	edgeCollections = slices.Compact(edgeCollections)
+
+	writeCollections := append([]string{
+		req.GetEntityType(),
+		pipeline.TombstoneCollection,
+		pipeline.GrantCollection,
+		pipeline.DuplicateCandidateCollection,
+	}, edgeCollections...)
+
+	updatedStruct, err := s.Pipeline.CreateEntityStruct(req.GetEntityType())
+	if err != nil {
+		return nil, err
+	}
+
+	var rewired, removed int64
+	var meta driver.DocumentMeta
+	err = s.Pipeline.RunTransaction(ctx, writeCollections, func(ctx context.Context) error {
+		if meta, err = s.Pipeline.UpdateDocument(ctx, col, req.GetSurvivorKey(), dataMap, updatedStruct); err != nil {
+			return err
+		}
+
+		for _, name := range edgeCollections {
+			moved, dropped, err := s.Pipeline.RewireRelationships(ctx, name, mergedIds, survivorId, userId)
+			if err != nil {
+				return s.internalError(ctx, err, "failed to rewire relationships", name)
+			}
+			deduplicated, err := s.Pipeline.DeduplicateRelationships(ctx, name, survivorId, userId, userRoles)
+			if err != nil {
+				return s.internalError(ctx, err, "failed to deduplicate relationships", name)
+			}
+			rewired += moved
+			removed += dropped + deduplicated
+		}
+
+		for _, key := range req.GetMergedKeys() {
+			if err := s.Pipeline.DeleteDocument(ctx, col, key); err != nil {
+				return err
+			}
+			id := req.GetEntityType() + "/" + key
+			if err := s.Pipeline.CreateTombstone(ctx, req.GetEntityType(), id, survivorId, userId); err != nil {
+				return s.internalError(ctx, err, "failed to create tombstone", id)
+			}
+		}
+
+		// Share grants follow the merged entities, their duplicate candidates are obsolete
+		query := `
+			FOR g IN grant
+			FILTER g.target IN @mergedIds
+			UPDATE g WITH { target: @survivorId } IN grant
+		`
+		cursor, err := s.DBClient.DB.Query(ctx, query, map[string]interface{}{
+			"mergedIds":  mergedIds,
+			"survivorId": survivorId,
+		})
+		if err != nil {
+			return s.internalError(ctx, err, "failed to move share grants", survivorId)
+		}
+		cursor.Close()
+
+		query = `
+			FOR c IN duplicate_candidate
+			FILTER c.entity_a IN @mergedIds OR c.entity_b IN @mergedIds
+			REMOVE c IN duplicate_candidate
+		`
+		cursor, err = s.DBClient.DB.Query(ctx, query, map[string]interface{}{
+			"mergedIds": mergedIds,
+		})
+		if err != nil {
+			return s.internalError(ctx, err, "failed to remove duplicate candidates", survivorId)
+		}
+		cursor.Close()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("merged %v into %s, rewired %d and removed %d relationships", mergedIds, survivorId, rewired, removed)
+
+	// =====================================================
+	// Wrap response
+	// =====================================================
+	s.Pipeline.SetEntityMeta(updatedStruct, meta.ID.String(), meta.Key, meta.Rev)
+	responseEntity, err := s.Pipeline.WrapEntityResponse(updatedStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dapi.MergeEntitiesResponse{
+		Entity:               responseEntity,
+		RelationshipsRewired: rewired,
+		RelationshipsRemoved: removed,
+	}, nil
+}
+
+// decodeEntity converts a stored document into the entity struct of its type.
+func (s *ResolutionService) decodeEntity(entityType string, doc map[string]interface{}) (pipeline.ConcereteEntityCommon, error) {
+	entity, err := s.Pipeline.CreateEntityStruct(entityType)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// internalError logs a failed database operation and translates it for the caller.
+func (s *ResolutionService) internalError(ctx context.Context, err error, message string, target string) error {
+	utils.GetLogger(ctx).WithFields(logrus.Fields{
+		"error":  err,
+		"target": target,
+	}).Error(message)
+	return s.Pipeline.TranslateDBError(err, "", "")
+}
@@ -0,0 +1,54 @@
+package resolutionservice
+
+import (
+	"context"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/entity_service/collections"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+)
+
+type ResolutionService struct {
+	dapi.UnimplementedResolutionServiceServer
+
+	DBClient *utils.ArangoDBClient
+	Pipeline *pipeline.Worker
+}
+
+func NewResolutionService(client *utils.ArangoDBClient) (*ResolutionService, error) {
+	service := &ResolutionService{
+		DBClient: client,
+		Pipeline: pipeline.NewWorker(client),
+	}
+
+	ctx := context.Background()
+
+	// Merges write to the entity collections and every collection referencing entities
+	if err := collections.RegisterEvent(ctx, client, service.Pipeline); err != nil {
+		return nil, err
+	}
+	if err := collections.RegisterSource(ctx, client, service.Pipeline); err != nil {
+		return nil, err
+	}
+	if err := collections.RegisterWebsite(ctx, client, service.Pipeline); err != nil {
+		return nil, err
+	}
+	if err := collections.RegisterPerson(ctx, client, service.Pipeline); err != nil {
+		return nil, err
+	}
+	if err := collections.RegisterOrganization(ctx, client, service.Pipeline); err != nil {
+		return nil, err
+	}
+	if err := collections.RegisterGrant(ctx, client, service.Pipeline); err != nil {
+		return nil, err
+	}
+	if err := collections.RegisterDuplicateCandidate(ctx, client, service.Pipeline); err != nil {
+		return nil, err
+	}
+	if err := collections.RegisterTombstone(ctx, client, service.Pipeline); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
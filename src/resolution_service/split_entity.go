@@ -0,0 +1,240 @@
+package resolutionservice
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/omnsight/omniscent-library/gen/model/v1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ResolutionService) SplitEntity(ctx context.Context, req *dapi.SplitEntityRequest) (*dapi.SplitEntityResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.Infof("[%s, %v] requests to split %s/%s", userId, userRoles, req.GetEntityType(), req.GetKey())
+
+	if err := s.Pipeline.CheckCreatePermission(userRoles); err != nil {
+		return nil, err
+	}
+
+	col, err := s.Pipeline.GetCollection(req.GetEntityType())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid entity type: %v", err)
+	}
+
+	// =====================================================
+	// Read entity and check permission
+	// =====================================================
+	var original map[string]interface{}
+	if _, err := s.Pipeline.ReadDocument(ctx, col, req.GetKey(), &original); err != nil {
+		return nil, err
+	}
+
+	originalEntity, err := s.decodeEntity(req.GetEntityType(), original)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"key":   req.GetKey(),
+		}).Error("failed to decode entity")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	if err := s.Pipeline.CheckWritePermission(originalEntity, userId, userRoles); err != nil {
+		return nil, err
+	}
+	originalId := req.GetEntityType() + "/" + req.GetKey()
+
+	// =====================================================
+	// Build the new entity
+	// =====================================================
+	splitData := make(map[string]interface{})
+	for _, field := range req.GetFields() {
+		if slices.Contains(pipeline.ProtectedEntityFields, field) {
+			return nil, status.Errorf(codes.InvalidArgument, "field %s cannot be copied", field)
+		}
+		value, ok := original[field]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "field %s is not set on the entity", field)
+		}
+		splitData[field] = value
+	}
+
+	remainingAliases := []interface{}{}
+	if len(req.GetAliases()) > 0 {
+		aliases, _ := original["aliases"].([]interface{})
+		for _, alias := range req.GetAliases() {
+			if !slices.Contains(aliases, interface{}(alias)) {
+				return nil, status.Errorf(codes.InvalidArgument, "alias %s is not set on the entity", alias)
+			}
+		}
+		splitAliases, _ := splitData["aliases"].([]interface{})
+		for _, alias := range aliases {
+			if name, _ := alias.(string); !slices.Contains(req.GetAliases(), name) {
+				remainingAliases = append(remainingAliases, alias)
+			} else if !slices.Contains(splitAliases, alias) {
+				splitAliases = append(splitAliases, alias)
+			}
+		}
+		splitData["aliases"] = splitAliases
+	}
+
+	if req.GetEntity() != nil {
+		inputEntity, err := s.Pipeline.ExtractInputEntity(req)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.Pipeline.ValidateEntity(inputEntity, true); err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(inputEntity)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to marshal entity data")
+		}
+		var inputMap map[string]interface{}
+		if err := json.Unmarshal(data, &inputMap); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to unmarshal entity data")
+		}
+		for _, field := range pipeline.ProtectedEntityFields {
+			delete(inputMap, field)
+		}
+		splitData = s.Pipeline.MergeDocumentData(splitData, inputMap)
+	}
+
+	if len(splitData) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "the new entity needs at least one field, alias or value")
+	}
+
+	// The new entity is shared like the entity it is split from unless told otherwise
+	if _, ok := splitData["read"]; !ok {
+		splitData["read"] = original["read"]
+	}
+	if _, ok := splitData["write"]; !ok {
+		splitData["write"] = original["write"]
+	}
+
+	splitEntity, err := s.decodeEntity(req.GetEntityType(), splitData)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid entity data: %v", err)
+	}
+	if err := s.Pipeline.SetPermissions(splitEntity, userId, true); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set permissions: %v", err)
+	}
+	raw := s.Pipeline.NormalizeEntity(splitEntity)
+	dataMap, err := s.Pipeline.SetAdditionalFields(ctx, splitEntity)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set additional fields: %v", err)
+	}
+	raw.AddTo(dataMap, true)
+	s.Pipeline.SetAuditFields(dataMap, userId, true)
+
+	// =====================================================
+	// Check moved relationships
+	// =====================================================
+	var relations []*model.Relation
+	writeCollections := []string{req.GetEntityType()}
+	for i, id := range req.GetRelationshipIds() {
+		if slices.Contains(req.GetRelationshipIds()[:i], id) {
+			continue
+		}
+
+		collectionName, key, err := s.DBClient.ParseDocID(id)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid relationship id: %s", id)
+		}
+		edgeCol, err := s.DBClient.DB.Collection(ctx, collectionName)
+		if err != nil {
+			return nil, status.Errorf(codes.NotFound, "relationship %s not found", id)
+		}
+
+		var relation model.Relation
+		if _, err := s.Pipeline.ReadDocument(ctx, edgeCol, key, &relation); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil, status.Errorf(codes.NotFound, "relationship %s not found", id)
+			}
+			return nil, err
+		}
+		if relation.GetFrom() != originalId && relation.GetTo() != originalId {
+			return nil, status.Errorf(codes.InvalidArgument, "relationship %s does not belong to %s", id, originalId)
+		}
+		if err := s.Pipeline.CheckWritePermission(&relation, userId, userRoles); err != nil {
+			return nil, status.Errorf(codes.PermissionDenied, "Access denied: cannot move relationship %s", id)
+		}
+
+		relation.Id = id
+		relations = append(relations, &relation)
+		if !slices.Contains(writeCollections, collectionName) {
+			writeCollections = append(writeCollections, collectionName)
+		}
+	}
+
+	// =====================================================
+	// Write into db
+	// =====================================================
+	updatedStruct, err := s.Pipeline.CreateEntityStruct(req.GetEntityType())
+	if err != nil {
+		return nil, err
+	}
+	createdStruct, err := s.Pipeline.CreateEntityStruct(req.GetEntityType())
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedMeta, createdMeta driver.DocumentMeta
+	err = s.Pipeline.RunTransaction(ctx, writeCollections, func(ctx context.Context) error {
+		if createdMeta, err = s.Pipeline.CreateDocument(ctx, col, dataMap, createdStruct); err != nil {
+			return err
+		}
+
+		// Moved aliases belong to the new entity only
+		update := map[string]interface{}{}
+		if len(req.GetAliases()) > 0 {
+			update["aliases"] = remainingAliases
+		}
+		s.Pipeline.SetAuditFields(update, userId, false)
+		if updatedMeta, err = s.Pipeline.UpdateDocument(ctx, col, req.GetKey(), update, updatedStruct); err != nil {
+			return err
+		}
+
+		for _, relation := range relations {
+			if err := s.Pipeline.MoveRelationshipEndpoint(ctx, relation, originalId, createdMeta.ID.String(), userId); err != nil {
+				return s.internalError(ctx, err, "failed to move relationship", relation.GetId())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("split %s from %s, moved %d relationships", createdMeta.ID.String(), originalId, len(relations))
+
+	// =====================================================
+	// Wrap response
+	// =====================================================
+	s.Pipeline.SetEntityMeta(updatedStruct, updatedMeta.ID.String(), updatedMeta.Key, updatedMeta.Rev)
+	responseEntity, err := s.Pipeline.WrapEntityResponse(updatedStruct)
+	if err != nil {
+		return nil, err
+	}
+	s.Pipeline.SetEntityMeta(createdStruct, createdMeta.ID.String(), createdMeta.Key, createdMeta.Rev)
+	splitResponseEntity, err := s.Pipeline.WrapEntityResponse(createdStruct)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dapi.SplitEntityResponse{
+		Entity:             responseEntity,
+		SplitEntity:        splitResponseEntity,
+		RelationshipsMoved: int64(len(relations)),
+	}, nil
+}
@@ -0,0 +1,115 @@
+package shareservice
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ShareService) CreateGrant(ctx context.Context, req *dapi.CreateGrantRequest) (*dapi.CreateGrantResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.WithFields(logrus.Fields{
+		"target":    req.GetGrant().GetTarget(),
+		"principal": req.GetGrant().GetPrincipal(),
+	}).Infof("[%s, %v] requests to create grant", userId, userRoles)
+
+	grant := req.GetGrant()
+	if grant == nil {
+		logger.Error("grant is nil")
+		return nil, status.Errorf(codes.InvalidArgument, "Bad parameter")
+	}
+
+	now := time.Now().Unix()
+	if grant.GetExpiresAt() <= now {
+		return nil, status.Errorf(codes.InvalidArgument, "grant must expire in the future")
+	}
+	if grant.GetDepth() < 0 || grant.GetDepth() > pipeline.MaxGrantDepth {
+		return nil, status.Errorf(codes.InvalidArgument, "grant depth must be between 0 and %d", pipeline.MaxGrantDepth)
+	}
+
+	// =====================================================
+	// Check permission on the shared entity
+	// =====================================================
+	targetColl, targetKey, err := s.DBClient.ParseDocID(grant.GetTarget())
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"id":    grant.GetTarget(),
+		}).Error("failed to parse target entity id")
+		return nil, status.Errorf(codes.InvalidArgument, "Bad parameter")
+	}
+
+	targetStruct, err := s.Pipeline.CreateEntityStruct(targetColl)
+	if err != nil {
+		return nil, err
+	}
+
+	col, err := s.DBClient.DB.Collection(ctx, targetColl)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":      err,
+			"collection": targetColl,
+		}).Error("failed to get collection")
+		return nil, status.Errorf(codes.NotFound, "Collection not found")
+	}
+
+	if _, err := s.Pipeline.ReadDocument(ctx, col, targetKey, targetStruct); err != nil {
+		return nil, err
+	}
+
+	if err := s.Pipeline.CheckSharePermission(targetStruct, userId, userRoles); err != nil {
+		return nil, err
+	}
+
+	// =====================================================
+	// Write grant into db
+	// =====================================================
+	grant.Id = ""
+	grant.Key = ""
+	grant.Rev = ""
+	grant.Owner = userId
+	grant.CreatedAt = now
+	grant.Token = ""
+	if grant.GetPrincipal() == "" {
+		grant.Token = uuid.New().String()
+		grant.Principal = pipeline.ShareLinkPrefix + grant.Token
+	}
+
+	data, err := json.Marshal(grant)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal grant")
+	}
+	var dataMap map[string]interface{}
+	if err := json.Unmarshal(data, &dataMap); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal grant")
+	}
+
+	grantCol, err := s.Pipeline.GetGrantCollection()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal service error")
+	}
+
+	var createdGrant dapi.Grant
+	meta, err := s.Pipeline.CreateDocument(ctx, grantCol, dataMap, &createdGrant)
+	if err != nil {
+		return nil, err
+	}
+
+	createdGrant.Id = meta.ID.String()
+	createdGrant.Key = meta.Key
+	createdGrant.Rev = meta.Rev
+	return &dapi.CreateGrantResponse{Grant: &createdGrant}, nil
+}
@@ -0,0 +1,41 @@
+package shareservice
+
+import (
+	"context"
+	"slices"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ShareService) DeleteGrant(ctx context.Context, req *dapi.DeleteGrantRequest) (*dapi.DeleteGrantResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.Infof("[%s, %v] requests to delete grant: %s", userId, userRoles, req.GetKey())
+
+	col, err := s.Pipeline.GetGrantCollection()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal service error")
+	}
+
+	var existingGrant dapi.Grant
+	if _, err := s.Pipeline.ReadDocument(ctx, col, req.GetKey(), &existingGrant); err != nil {
+		return nil, err
+	}
+
+	if existingGrant.GetOwner() != userId && !slices.Contains(userRoles, "admin") {
+		return nil, status.Errorf(codes.PermissionDenied, "Access denied: only grant owner can revoke grant")
+	}
+
+	if err := s.Pipeline.DeleteDocument(ctx, col, req.GetKey()); err != nil {
+		return nil, err
+	}
+
+	return &dapi.DeleteGrantResponse{}, nil
+}
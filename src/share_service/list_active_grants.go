@@ -0,0 +1,69 @@
+package shareservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/arangodb/go-driver"
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/utils"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ShareService) ListActiveGrants(ctx context.Context, req *dapi.ListActiveGrantsRequest) (*dapi.ListActiveGrantsResponse, error) {
+	userId, userRoles, err := s.Pipeline.GetAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.GetLogger(ctx)
+	logger.Infof("[%s, %v] requests to list active grants", userId, userRoles)
+
+	if err := s.Pipeline.CheckAdminPermission(userRoles); err != nil {
+		return nil, err
+	}
+
+	query := `
+		FOR g IN grant
+		FILTER g.expires_at > @now
+		FILTER (@target == "" OR g.target == @target)
+		FILTER (@principal == "" OR g.principal == @principal)
+		SORT g.expires_at ASC
+		RETURN g
+	`
+	bindVars := map[string]interface{}{
+		"now":       time.Now().Unix(),
+		"target":    req.GetTarget(),
+		"principal": req.GetPrincipal(),
+	}
+
+	cursor, err := s.DBClient.DB.Query(ctx, query, bindVars)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err,
+			"query": query,
+			"vars":  bindVars,
+		}).Error("failed to execute AQL query")
+		return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+	}
+	defer cursor.Close()
+
+	var grants []*dapi.Grant
+	for {
+		var grant dapi.Grant
+		if _, err := cursor.ReadDocument(ctx, &grant); err != nil {
+			if driver.IsNoMoreDocuments(err) {
+				break
+			}
+			logger.WithFields(logrus.Fields{
+				"error": err,
+			}).Error("failed to read query result")
+			return nil, status.Errorf(codes.Internal, "Internal service error. Please try again later.")
+		}
+		grants = append(grants, &grant)
+	}
+
+	return &dapi.ListActiveGrantsResponse{Grants: grants}, nil
+}
@@ -0,0 +1,30 @@
+package shareservice
+
+import (
+	"context"
+
+	"github.com/omnsight/omndapi/gen/dapi/v1"
+	"github.com/omnsight/omndapi/src/entity_service/collections"
+	"github.com/omnsight/omndapi/src/pipeline"
+	"github.com/omnsight/omndapi/src/utils"
+)
+
+type ShareService struct {
+	dapi.UnimplementedShareServiceServer
+
+	DBClient *utils.ArangoDBClient
+	Pipeline *pipeline.Worker
+}
+
+func NewShareService(client *utils.ArangoDBClient) (*ShareService, error) {
+	service := &ShareService{
+		DBClient: client,
+		Pipeline: pipeline.NewWorker(client),
+	}
+
+	if err := collections.RegisterGrant(context.Background(), client, service.Pipeline); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
@@ -148,6 +148,30 @@ func (c *ArangoDBClient) GetCreateEdgeCollection(ctx context.Context, name strin
 	return c.OsintGraph.CreateEdgeCollectionWithOptions(ctx, name, constraints, options)
 }
 
+func (c *ArangoDBClient) GetCreateDocumentCollection(ctx context.Context, name string, options *driver.CreateCollectionOptions) (driver.Collection, error) {
+	exists, err := c.DB.CollectionExists(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return c.DB.Collection(ctx, name)
+	}
+
+	c.GraphSchemaMu.Lock()
+	defer c.GraphSchemaMu.Unlock()
+
+	exists, err = c.DB.CollectionExists(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return c.DB.Collection(ctx, name)
+	}
+
+	return c.DB.CreateCollection(ctx, name, options)
+}
+
 func CreateOrGetGraph(db driver.Database, ctx context.Context, name string, options *driver.CreateGraphOptions) (driver.Graph, error) {
 	exists, err := db.GraphExists(ctx, name)
 	if err != nil {
@@ -1,7 +1,12 @@
 package utils
 
 const (
-	KeycloakClientID = "KEYCLOAK_CLIENT_ID"
-	GrpcPort         = "GRPC_PORT"
-	ServerPort       = "SERVER_PORT"
+	KeycloakClientID      = "KEYCLOAK_CLIENT_ID"
+	GrpcPort              = "GRPC_PORT"
+	ServerPort            = "SERVER_PORT"
+	GrantSweepInterval    = "GRANT_SWEEP_INTERVAL"
+	DuplicateScanInterval = "DUPLICATE_SCAN_INTERVAL"
+	Normalizers           = "NORMALIZERS"
+	SchemaLevel           = "SCHEMA_LEVEL"
+	ApiToken              = "API_TOKEN"
 )
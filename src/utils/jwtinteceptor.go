@@ -15,9 +15,19 @@ import (
 type ContextKey string
 
 const (
-	UserIDKey    ContextKey = "user_id"
-	UserNameKey  ContextKey = "user_name"
-	UserRolesKey ContextKey = "user_roles"
+	UserIDKey      ContextKey = "user_id"
+	UserNameKey    ContextKey = "user_name"
+	UserRolesKey   ContextKey = "user_roles"
+	ShareTokensKey ContextKey = "share_tokens"
+	IdempotencyKey ContextKey = "idempotency_key"
+)
+
+const (
+	ShareTokenHeader     = "x-share-token"
+	IdempotencyKeyHeader = "idempotency-key"
+	// Response headers forwarded by the gateway as is
+	ContentDispositionHeader = "content-disposition"
+	GraphTruncatedHeader     = "x-graph-truncated"
 )
 
 // IdentityInterceptor parses claims without verifying signature (Gateway trusted)
@@ -28,48 +38,96 @@ func GrpcGatewayIdentityInterceptor(clientID string) grpc.UnaryServerInterceptor
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		// 1. Extract Token
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, "missing metadata")
-		}
-		values := md["authorization"]
-		if len(values) == 0 {
-			return nil, status.Error(codes.Unauthenticated, "missing auth header")
+		ctx, err := withIdentity(ctx)
+		if err != nil {
+			return nil, err
 		}
-		tokenString := strings.TrimPrefix(values[0], "Bearer ")
+		return handler(ctx, req)
+	}
+}
 
-		// 2. Parse Claims (Unverified because Gateway already verified it)
-		parser := jwt.NewParser()
-		token, _, err := parser.ParseUnverified(tokenString, jwt.MapClaims{})
+// GrpcGatewayIdentityStreamInterceptor is the streaming counterpart of
+// GrpcGatewayIdentityInterceptor.
+func GrpcGatewayIdentityStreamInterceptor(clientID string) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := withIdentity(stream.Context())
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to parse token: %v", err)
+			return err
 		}
+		return handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+	}
+}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			return nil, status.Error(codes.Internal, "invalid claims structure")
-		}
+// withIdentity adds the user of the request token, and the optional share
+// tokens and idempotency key, to the context.
+func withIdentity(ctx context.Context) (context.Context, error) {
+	// 1. Extract Token
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+	values := md["authorization"]
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing auth header")
+	}
+	tokenString := strings.TrimPrefix(values[0], "Bearer ")
+
+	// 2. Parse Claims (Unverified because Gateway already verified it)
+	parser := jwt.NewParser()
+	token, _, err := parser.ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to parse token: %v", err)
+	}
 
-		userName, _ := claims["preferred_username"].(string)
-		
-		var roles []string
-		if rolesInterface, ok := claims["roles"].([]interface{}); ok {
-			for _, r := range rolesInterface {
-				if rStr, ok := r.(string); ok {
-					roles = append(roles, rStr)
-				}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, status.Error(codes.Internal, "invalid claims structure")
+	}
+
+	userName, _ := claims["preferred_username"].(string)
+
+	var roles []string
+	if rolesInterface, ok := claims["roles"].([]interface{}); ok {
+		for _, r := range rolesInterface {
+			if rStr, ok := r.(string); ok {
+				roles = append(roles, rStr)
 			}
-		} else if rolesStr, ok := claims["roles"].([]string); ok {
-			// In case it somehow IS a []string (unlikely with jwt.MapClaims but possible if custom parser used)
-			roles = rolesStr
 		}
+	} else if rolesStr, ok := claims["roles"].([]string); ok {
+		// In case it somehow IS a []string (unlikely with jwt.MapClaims but possible if custom parser used)
+		roles = rolesStr
+	}
 
-		ctx = context.WithValue(ctx, UserNameKey, userName)
-		ctx = context.WithValue(ctx, UserRolesKey, roles)
+	ctx = context.WithValue(ctx, UserNameKey, userName)
+	ctx = context.WithValue(ctx, UserRolesKey, roles)
 
-		return handler(ctx, req)
+	// 3. Share link tokens (optional, evaluated against active grants)
+	if tokens := md.Get(ShareTokenHeader); len(tokens) > 0 {
+		ctx = context.WithValue(ctx, ShareTokensKey, tokens)
+	}
+
+	// 4. Idempotency key of retried ingestion calls (optional)
+	if keys := md.Get(IdempotencyKeyHeader); len(keys) > 0 && keys[0] != "" {
+		ctx = context.WithValue(ctx, IdempotencyKey, keys[0])
 	}
+
+	return ctx, nil
+}
+
+// contextServerStream overrides the context of a server stream, as
+// interceptors cannot pass a new context to streaming handlers otherwise.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
 }
 
 func GetUser(ctx context.Context) (string, []string, error) {
@@ -88,3 +146,15 @@ func GetUser(ctx context.Context) (string, []string, error) {
 
 	return userName, roles, nil
 }
+
+// GetShareTokens returns the share link tokens presented with the request, if any.
+func GetShareTokens(ctx context.Context) []string {
+	tokens, _ := ctx.Value(ShareTokensKey).([]string)
+	return tokens
+}
+
+// GetIdempotencyKey returns the idempotency key sent with the request, if any.
+func GetIdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(IdempotencyKey).(string)
+	return key
+}
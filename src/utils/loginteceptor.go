@@ -17,7 +17,52 @@ func LoggingInterceptor(
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler,
 ) (interface{}, error) {
+	ctx, requestLogger := withRequestLogger(ctx)
 
+	// Add a log entry for the start of the request
+	requestLogger.WithFields(logrus.Fields{
+		"method": info.FullMethod,
+	}).Debug("gRPC request started")
+
+	// Call the original handler with the new context
+	resp, err := handler(ctx, req)
+
+	// Log the end of the request
+	if err != nil {
+		requestLogger.WithError(err).Error("gRPC request finished with error")
+	} else {
+		requestLogger.Debug("gRPC request finished successfully")
+	}
+
+	return resp, err
+}
+
+// LoggingStreamInterceptor is a gRPC stream interceptor for logging.
+func LoggingStreamInterceptor(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, requestLogger := withRequestLogger(stream.Context())
+
+	requestLogger.WithFields(logrus.Fields{
+		"method": info.FullMethod,
+	}).Debug("gRPC stream started")
+
+	err := handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+
+	if err != nil {
+		requestLogger.WithError(err).Error("gRPC stream finished with error")
+	} else {
+		requestLogger.Debug("gRPC stream finished successfully")
+	}
+
+	return err
+}
+
+// withRequestLogger adds a logger tagged with the request ID to the context.
+func withRequestLogger(ctx context.Context) (context.Context, *logrus.Entry) {
 	// 1. Get or Generate Request ID
 	var requestID string
 
@@ -39,22 +84,5 @@ func LoggingInterceptor(
 	requestLogger := logrus.WithField("request_id", requestID)
 
 	// 3. Add the logger to the context
-	ctx = WithLogger(ctx, requestLogger)
-
-	// Add a log entry for the start of the request
-	requestLogger.WithFields(logrus.Fields{
-		"method": info.FullMethod,
-	}).Debug("gRPC request started")
-
-	// 4. Call the original handler with the new context
-	resp, err := handler(ctx, req)
-
-	// 5. Log the end of the request
-	if err != nil {
-		requestLogger.WithError(err).Error("gRPC request finished with error")
-	} else {
-		requestLogger.Debug("gRPC request finished successfully")
-	}
-
-	return resp, err
+	return WithLogger(ctx, requestLogger), requestLogger
 }